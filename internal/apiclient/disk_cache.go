@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// DefaultPriceCacheTTL is how long a cached price is used before it's fetched again.
+	DefaultPriceCacheTTL = 24 * time.Hour
+	// DefaultPriceCacheMaxSize is the size in bytes the cache directory is pruned to.
+	DefaultPriceCacheMaxSize = 100 * 1024 * 1024
+	// MaxStalePriceAge is how long after they were fetched expired prices are still used when the pricing API
+	// can't be reached. Older prices are too likely to be outdated, so the estimate fails instead.
+	MaxStalePriceAge = 7 * 24 * time.Hour
+
+	diskCacheFileExt = ".json"
+)
+
+// DiskCache stores pricing API results on disk so that they can be reused by later provider processes.
+// Terraform starts a new provider process for every plan, so the in-memory cache of the PricingAPIClient
+// only helps within a single run. Entries are keyed by the query hash and written atomically, so several
+// processes can share a cache directory.
+type DiskCache struct {
+	dir     string
+	ttl     time.Duration
+	maxSize int64
+}
+
+type diskCacheEntry struct {
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Result    json.RawMessage `json:"result"`
+}
+
+// NewDiskCache returns a DiskCache that stores results in dir for ttl and keeps the directory below maxSize
+// bytes. The directory is created if it doesn't exist.
+func NewDiskCache(dir string, ttl time.Duration, maxSize int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating price cache directory: %w", err)
+	}
+
+	return &DiskCache{
+		dir:     dir,
+		ttl:     ttl,
+		maxSize: maxSize,
+	}, nil
+}
+
+// DefaultDiskCacheDir returns the default price cache directory in the user's cache directory.
+func DefaultDiskCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plancost", "prices"), nil
+}
+
+// Get returns the cached result for the query hash if it exists and was fetched within the TTL.
+func (c *DiskCache) Get(hash uint64) (gjson.Result, bool) {
+	entry, ok := c.read(hash)
+	if !ok || time.Since(entry.FetchedAt) > c.ttl {
+		return gjson.Result{}, false
+	}
+	return gjson.ParseBytes(entry.Result), true
+}
+
+// GetStale returns the cached result for the query hash and when it was fetched, even if it has expired, as long as
+// it was fetched within MaxStalePriceAge. It is used when the pricing API can't be reached.
+func (c *DiskCache) GetStale(hash uint64) (gjson.Result, time.Time, bool) {
+	entry, ok := c.read(hash)
+	if !ok || time.Since(entry.FetchedAt) > MaxStalePriceAge {
+		return gjson.Result{}, time.Time{}, false
+	}
+	return gjson.ParseBytes(entry.Result), entry.FetchedAt, true
+}
+
+// Set stores the result for the query hash.
+func (c *DiskCache) Set(hash uint64, result gjson.Result) error {
+	if result.Raw == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(diskCacheEntry{
+		FetchedAt: time.Now(),
+		Result:    json.RawMessage(result.Raw),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a partial entry.
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(hash))
+}
+
+// Prune removes the least recently written entries until the cache directory is below its maximum size.
+// Expired entries are kept until then, as a fallback for when the pricing API can't be reached.
+func (c *DiskCache) Prune() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	for _, f := range files {
+		size += f.Size()
+	}
+	if size <= c.maxSize {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, f := range files {
+		if size <= c.maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, f.Name())); err == nil {
+			size -= f.Size()
+		}
+	}
+
+	return nil
+}
+
+// Clear removes all entries from the cache.
+func (c *DiskCache) Clear() error {
+	files, err := c.files()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := os.Remove(filepath.Join(c.dir, f.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error clearing price cache: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *DiskCache) read(hash uint64) (diskCacheEntry, bool) {
+	var entry diskCacheEntry
+
+	b, err := os.ReadFile(c.path(hash))
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		logging.Logger.Debug().Err(err).Msgf("ignoring invalid price cache entry %d", hash)
+		return entry, false
+	}
+	return entry, true
+}
+
+func (c *DiskCache) files() ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading price cache directory: %w", err)
+	}
+
+	files := make([]os.FileInfo, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), diskCacheFileExt) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+	}
+	return files, nil
+}
+
+func (c *DiskCache) path(hash uint64) string {
+	return filepath.Join(c.dir, strconv.FormatUint(hash, 16)+diskCacheFileExt)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package apiclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestDiskCache(t *testing.T) {
+	cache, err := NewDiskCache(filepath.Join(t.TempDir(), "prices"), time.Hour, 0)
+	require.NoError(t, err)
+
+	_, ok := cache.Get(1)
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Set(1, gjson.Parse(`{"data":{"products":[]}}`)))
+	result, ok := cache.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, `{"data":{"products":[]}}`, result.Raw)
+
+	require.NoError(t, cache.Clear())
+	_, ok = cache.Get(1)
+	assert.False(t, ok)
+}
+
+func TestDiskCache_Expired(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), -time.Minute, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set(1, gjson.Parse(`{"data":{"products":[]}}`)))
+
+	_, ok := cache.Get(1)
+	assert.False(t, ok)
+
+	// Expired entries are still available for when the pricing API can't be reached
+	result, fetchedAt, ok := cache.GetStale(1)
+	assert.True(t, ok)
+	assert.Equal(t, `{"data":{"products":[]}}`, result.Raw)
+	assert.WithinDuration(t, time.Now(), fetchedAt, time.Minute)
+
+	// Entries that are too old aren't used at all
+	old := time.Now().Add(-MaxStalePriceAge - time.Hour)
+	b, err := json.Marshal(diskCacheEntry{FetchedAt: old, Result: json.RawMessage(`{"data":{"products":[]}}`)})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cache.path(2), b, 0644))
+	_, _, ok = cache.GetStale(2)
+	assert.False(t, ok)
+}
+
+func TestDiskCache_Prune(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), time.Hour, 0)
+	require.NoError(t, err)
+
+	result := gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.005"}]}]}}`)
+	require.NoError(t, cache.Set(1, result))
+	require.NoError(t, os.Chtimes(cache.path(1), time.Now().Add(-time.Minute), time.Now().Add(-time.Minute)))
+	require.NoError(t, cache.Set(2, result))
+
+	// Limit the cache to a single entry
+	info, err := os.Stat(cache.path(2))
+	require.NoError(t, err)
+	cache.maxSize = info.Size()
+
+	require.NoError(t, cache.Prune())
+
+	// The oldest entry is removed first
+	_, ok := cache.Get(1)
+	assert.False(t, ok)
+	_, ok = cache.Get(2)
+	assert.True(t, ok)
+}
+
+func TestPricingAPIClient_DiskCache(t *testing.T) {
+	var requests atomic.Int32
+	available := atomic.Bool{}
+	available.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.005"}]}]}}]`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	newClient := func(ttl time.Duration) *PricingAPIClient {
+		cache, err := NewDiskCache(dir, ttl, DefaultPriceCacheMaxSize)
+		require.NoError(t, err)
+
+		c := NewPricingAPIClient(server.URL, "")
+		c.httpClient = server.Client()
+		c.DiskCache = cache
+		return c
+	}
+
+	vendor := "azure"
+	resources := []*schema.Resource{
+		{
+			Name: "azurerm_public_ip.example",
+			CostComponents: []*schema.CostComponent{
+				{Name: "IP address", ProductFilter: &schema.ProductFilter{VendorName: &vendor}},
+			},
+		},
+	}
+
+	// The first run fetches the price from the pricing API
+	c := newClient(time.Hour)
+	results, err := c.PerformRequest(c.BatchRequests(resources, 10, "USD")[0])
+	require.NoError(t, err)
+	assert.Equal(t, "0.005", results[0].Result.Get("data.products.0.prices.0.USD").String())
+	assert.Equal(t, int32(1), requests.Load())
+
+	// A later run, i.e. a new provider process, reads it from the disk cache
+	c = newClient(time.Hour)
+	results, err = c.PerformRequest(c.BatchRequests(resources, 10, "USD")[0])
+	require.NoError(t, err)
+	assert.Equal(t, "0.005", results[0].Result.Get("data.products.0.prices.0.USD").String())
+	assert.Equal(t, int32(1), requests.Load())
+	assert.True(t, results[0].StaleFetchedAt.IsZero())
+
+	// Expired prices are used if the pricing API is unavailable
+	available.Store(false)
+	c = newClient(-time.Minute)
+	results, err = c.PerformRequest(c.BatchRequests(resources, 10, "USD")[0])
+	require.NoError(t, err)
+	assert.Equal(t, "0.005", results[0].Result.Get("data.products.0.prices.0.USD").String())
+	assert.Equal(t, int32(2), requests.Load())
+	assert.False(t, results[0].StaleFetchedAt.IsZero())
+}
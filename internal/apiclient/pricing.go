@@ -17,6 +17,7 @@ package apiclient
 import (
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
@@ -31,8 +32,15 @@ type PricingAPIClient struct {
 	APIClient
 
 	cache *lru.TwoQueueCache[uint64, cacheValue]
+
+	// Snapshot, if set, is used to resolve prices instead of the pricing API.
+	Snapshot *PriceSnapshot
+	// DiskCache, if set, keeps pricing API results across provider processes.
+	DiskCache *DiskCache
 }
 
+const memoryCacheSize = 10000
+
 type cacheValue struct {
 	Result    gjson.Result
 	ExpiresAt time.Time
@@ -47,6 +55,9 @@ type PriceQueryResult struct {
 	PriceQueryKey
 	Result gjson.Result
 	Query  GraphQLQuery
+	// Note-plancost: StaleFetchedAt is when the result was fetched if it is an expired result of the disk cache,
+	// used because the pricing API couldn't be reached. It is zero for current results.
+	StaleFetchedAt time.Time
 
 	filled bool
 }
@@ -56,6 +67,23 @@ type BatchRequest struct {
 	queries []GraphQLQuery
 }
 
+// NewPricingAPIClient returns a PricingAPIClient for the pricing API at endpoint, with an in-memory cache
+// of the results.
+func NewPricingAPIClient(endpoint, apiKey string) *PricingAPIClient {
+	c := &PricingAPIClient{
+		APIClient: *NewAPIClient(endpoint, apiKey),
+	}
+
+	cache, err := lru.New2Q[uint64, cacheValue](memoryCacheSize)
+	if err != nil {
+		logging.Logger.Debug().Err(err).Msg("failed to create the in-memory price cache")
+	} else {
+		c.cache = cache
+	}
+
+	return c
+}
+
 func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *schema.PriceFilter, currency string) GraphQLQuery {
 	if currency == "" {
 		currency = "USD"
@@ -80,9 +108,57 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 	return GraphQLQuery{query, v}
 }
 
+// Note-plancost: savings plan prices aren't separate prices, they are nested in the consumption prices of a product
+// with one price per term, e.g. "1 Year".
+func (c *PricingAPIClient) buildSavingsPlanQuery(product *schema.ProductFilter, price *schema.PriceFilter, currency string) GraphQLQuery {
+	if currency == "" {
+		currency = "USD"
+	}
+
+	v := map[string]interface{}{}
+	v["productFilter"] = product
+	v["priceFilter"] = price
+
+	query := fmt.Sprintf(`
+		query($productFilter: ProductFilter!, $priceFilter: PriceFilter) {
+			products(filter: $productFilter) {
+				prices(filter: $priceFilter) {
+					priceHash
+					%s
+					%s {
+						term
+						%s
+					}
+				}
+			}
+		}
+	`, currency, savingsPlanField, currency)
+
+	return GraphQLQuery{query, v}
+}
+
+// savingsPlanField is the field of the consumption prices that holds their savings plan prices.
+const savingsPlanField = "savingsPlan"
+
+// IsSavingsPlanQuery reports whether the GraphQL query is a savings plan query, see BatchSavingsPlanRequests.
+func IsSavingsPlanQuery(query string) bool {
+	return strings.Contains(query, savingsPlanField+" {")
+}
+
 // BatchRequests batches all the queries for these resources so we can use less GraphQL requests
 // Use PriceQueryKeys to keep track of which query maps to which sub-resource and price component.
 func (c *PricingAPIClient) BatchRequests(resources []*schema.Resource, batchSize int, currency string) []BatchRequest {
+	return c.batchRequests(resources, batchSize, currency, c.buildQuery)
+}
+
+// BatchSavingsPlanRequests batches the savings plan queries of the cost components of these resources. The cost
+// components are queried with their consumption price filters, and the results hold the savings plan prices of each
+// consumption price.
+func (c *PricingAPIClient) BatchSavingsPlanRequests(resources []*schema.Resource, batchSize int, currency string) []BatchRequest {
+	return c.batchRequests(resources, batchSize, currency, c.buildSavingsPlanQuery)
+}
+
+func (c *PricingAPIClient) batchRequests(resources []*schema.Resource, batchSize int, currency string, buildQuery func(*schema.ProductFilter, *schema.PriceFilter, string) GraphQLQuery) []BatchRequest {
 	reqs := make([]BatchRequest, 0)
 
 	keys := make([]PriceQueryKey, 0)
@@ -91,13 +167,13 @@ func (c *PricingAPIClient) BatchRequests(resources []*schema.Resource, batchSize
 	for _, r := range resources {
 		for _, component := range r.CostComponents {
 			keys = append(keys, PriceQueryKey{r, component})
-			queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter, currency))
+			queries = append(queries, buildQuery(component.ProductFilter, component.PriceFilter, currency))
 		}
 
 		for _, subresource := range r.FlattenedSubResources() {
 			for _, component := range subresource.CostComponents {
 				keys = append(keys, PriceQueryKey{subresource, component})
-				queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter, currency))
+				queries = append(queries, buildQuery(component.ProductFilter, component.PriceFilter, currency))
 			}
 		}
 	}
@@ -112,11 +188,76 @@ func (c *PricingAPIClient) BatchRequests(resources []*schema.Resource, batchSize
 	return reqs
 }
 
+// doPriceQueries resolves the queries from the price snapshot when one is set, and from the pricing API otherwise.
+func (c *PricingAPIClient) doPriceQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
+	if c.Snapshot != nil {
+		logging.Logger.Debug().Msgf("Resolving %d queries from the price snapshot", len(queries))
+		return c.Snapshot.Results(queries), nil
+	}
+	return c.DoQueries(queries)
+}
+
+// cachedResult returns the result for the query hash from the in-memory cache, or from the disk cache.
+func (c *PricingAPIClient) cachedResult(hash uint64) (gjson.Result, bool) {
+	if c.cache != nil {
+		if v, ok := c.cache.Get(hash); ok && time.Now().Before(v.ExpiresAt) {
+			return v.Result, true
+		}
+	}
+
+	if c.DiskCache != nil {
+		if result, ok := c.DiskCache.Get(hash); ok {
+			if c.cache != nil {
+				c.cache.Add(hash, cacheValue{Result: result, ExpiresAt: time.Now().Add(c.DiskCache.ttl)})
+			}
+			return result, true
+		}
+	}
+
+	return gjson.Result{}, false
+}
+
+func (c *PricingAPIClient) cacheResult(hash uint64, result gjson.Result) {
+	ttl := DefaultPriceCacheTTL
+	if c.DiskCache != nil {
+		ttl = c.DiskCache.ttl
+		if err := c.DiskCache.Set(hash, result); err != nil {
+			logging.Logger.Debug().Err(err).Msgf("failed to write query hash %d to the price cache", hash)
+		}
+	}
+
+	if c.cache != nil {
+		c.cache.Add(hash, cacheValue{Result: result, ExpiresAt: time.Now().Add(ttl)})
+	}
+}
+
+// staleResults sets the expired disk cache results of the queries, so that a brief pricing API outage doesn't
+// fail the estimate. It only succeeds if every query is in the cache and was fetched within MaxStalePriceAge.
+func (c *PricingAPIClient) staleResults(queries []pricingQuery) bool {
+	if c.DiskCache == nil || c.Snapshot != nil {
+		return false
+	}
+
+	stale := make([]pricingQuery, len(queries))
+	for i, query := range queries {
+		result, fetchedAt, ok := c.DiskCache.GetStale(query.hash)
+		if !ok {
+			return false
+		}
+		query.result = result
+		query.staleFetchedAt = fetchedAt
+		stale[i] = query
+	}
+	copy(queries, stale)
+	return true
+}
+
 type pricingQuery struct {
 	hash  uint64
 	query GraphQLQuery
 
-	result gjson.Result
+	result         gjson.Result
+	staleFetchedAt time.Time
 }
 
 // PerformRequest sends a batch request to the Pricing API endpoint to fetch
@@ -145,25 +286,22 @@ func (c *PricingAPIClient) PerformRequest(req BatchRequest) ([]PriceQueryResult,
 		res[i].Query = query
 	}
 
-	// first filter any queries that have been stored in the cache. We don't need to
-	// send requests for these as we already have the results in memory.
+	// first filter any queries that have been stored in the in-memory or disk cache. We
+	// don't need to send requests for these as we already have the results.
 	var serverQueries []pricingQuery
-	if c.cache == nil {
-		serverQueries = queries
-	} else {
-		var hit int
-		for i, query := range queries {
-			v, ok := c.cache.Get(query.hash)
-			if ok {
-				logging.Logger.Debug().Msgf("cache hit for query hash: %d", query.hash)
-				hit++
-				res[i].Result = v.Result
-				res[i].filled = true
-			} else {
-				serverQueries = append(serverQueries, query)
-			}
+	var hit int
+	for i, query := range queries {
+		if result, ok := c.cachedResult(query.hash); ok {
+			logging.Logger.Debug().Msgf("cache hit for query hash: %d", query.hash)
+			hit++
+			res[i].Result = result
+			res[i].filled = true
+		} else {
+			serverQueries = append(serverQueries, query)
 		}
+	}
 
+	if c.cache != nil || c.DiskCache != nil {
 		logging.Logger.Debug().Msgf("%d/%d queries were built from cache", hit, len(queries))
 	}
 
@@ -184,16 +322,25 @@ func (c *PricingAPIClient) PerformRequest(req BatchRequest) ([]PriceQueryResult,
 	for i, query := range deduplicatedServerQueries {
 		rawQueries[i] = query.query
 	}
-	resultsFromServer, err := c.DoQueries(rawQueries)
+	resultsFromServer, err := c.doPriceQueries(rawQueries)
 	if err != nil {
-		return []PriceQueryResult{}, err
-	}
-
-	// if the cache is enabled lets store each pricing result returned in the cache.
-	if c.cache != nil {
+		if !c.staleResults(deduplicatedServerQueries) {
+			return []PriceQueryResult{}, err
+		}
+		logging.Logger.Warn().Msgf("Failed to fetch prices, using expired prices from the price cache: %s", err)
+		// the expired results are already set on the deduplicated queries
+		resultsFromServer = nil
+	} else if c.Snapshot == nil {
+		// if the cache is enabled lets store each pricing result returned in the cache.
 		for i, query := range deduplicatedServerQueries {
 			if len(resultsFromServer)-1 >= i {
-				(*c.cache).Add(query.hash, cacheValue{Result: resultsFromServer[i], ExpiresAt: time.Now().Add(time.Hour * 24)})
+				c.cacheResult(query.hash, resultsFromServer[i])
+			}
+		}
+
+		if c.DiskCache != nil && len(deduplicatedServerQueries) > 0 {
+			if err := c.DiskCache.Prune(); err != nil {
+				logging.Logger.Debug().Err(err).Msg("failed to prune the price cache")
 			}
 		}
 	}
@@ -205,13 +352,14 @@ func (c *PricingAPIClient) PerformRequest(req BatchRequest) ([]PriceQueryResult,
 
 	// Then we match deduplicated server queries to the initial list using the unique
 	// query hash to tie a query to it's deduped query.
-	resultMap := make(map[uint64]gjson.Result, len(deduplicatedServerQueries))
+	resultMap := make(map[uint64]pricingQuery, len(deduplicatedServerQueries))
 	for _, query := range deduplicatedServerQueries {
-		resultMap[query.hash] = query.result
+		resultMap[query.hash] = query
 	}
 
 	for i, query := range serverQueries {
-		serverQueries[i].result = resultMap[query.hash]
+		serverQueries[i].result = resultMap[query.hash].result
+		serverQueries[i].staleFetchedAt = resultMap[query.hash].staleFetchedAt
 	}
 
 	// finally let's use the server queries to fill any results that haven't been
@@ -220,6 +368,7 @@ func (c *PricingAPIClient) PerformRequest(req BatchRequest) ([]PriceQueryResult,
 	for i, re := range res {
 		if !re.filled {
 			res[i].Result = serverQueries[x].result
+			res[i].StaleFetchedAt = serverQueries[x].staleFetchedAt
 			x++
 		}
 	}
@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package apiclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/tidwall/gjson"
+)
+
+// PriceSnapshotVersion is the version of the price snapshot file format.
+const PriceSnapshotVersion = 1
+
+// PriceSnapshot is a local price database that can be used instead of the pricing API. Each entry holds the
+// pricing API result for a product filter and price filter pair, so prices resolved from a snapshot go
+// through the same selection rules as live prices.
+type PriceSnapshot struct {
+	Version   int                  `json:"version"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Currency  string               `json:"currency"`
+	Entries   []PriceSnapshotEntry `json:"entries"`
+
+	mux     sync.RWMutex
+	results map[string]gjson.Result
+}
+
+// PriceSnapshotEntry is the pricing API result for a single product filter and price filter pair. Savings plan
+// queries have the same filters as the consumption prices they are nested in, so their results are separate entries.
+type PriceSnapshotEntry struct {
+	ProductFilter *schema.ProductFilter `json:"productFilter"`
+	PriceFilter   *schema.PriceFilter   `json:"priceFilter,omitempty"`
+	SavingsPlan   bool                  `json:"savingsPlan,omitempty"`
+	Result        json.RawMessage       `json:"result"`
+}
+
+// NewPriceSnapshot returns an empty PriceSnapshot for prices in the given currency.
+func NewPriceSnapshot(currency string) *PriceSnapshot {
+	if currency == "" {
+		currency = "USD"
+	}
+
+	return &PriceSnapshot{
+		Version:   PriceSnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		Currency:  currency,
+		Entries:   make([]PriceSnapshotEntry, 0),
+		results:   make(map[string]gjson.Result),
+	}
+}
+
+// LoadPriceSnapshot reads a price snapshot file. Both plain and gzip compressed JSON files are supported.
+func LoadPriceSnapshot(path string) (*PriceSnapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading price snapshot file: %w", err)
+	}
+
+	// gzip streams always start with the magic bytes 0x1f 0x8b
+	if bytes.HasPrefix(b, []byte{0x1f, 0x8b}) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing price snapshot file: %w", err)
+		}
+		defer r.Close()
+
+		b, err = io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing price snapshot file: %w", err)
+		}
+	}
+
+	snapshot := &PriceSnapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, fmt.Errorf("error parsing price snapshot file: %w", err)
+	}
+	if snapshot.Version != PriceSnapshotVersion {
+		return nil, fmt.Errorf("unsupported price snapshot version %d, expected %d", snapshot.Version, PriceSnapshotVersion)
+	}
+
+	snapshot.results = make(map[string]gjson.Result, len(snapshot.Entries))
+	for _, entry := range snapshot.Entries {
+		snapshot.results[snapshotKey(entry.ProductFilter, entry.PriceFilter, entry.SavingsPlan)] = gjson.ParseBytes(entry.Result)
+	}
+
+	return snapshot, nil
+}
+
+// Add stores the pricing API result of a query built by the PricingAPIClient. Adding a query that is
+// already in the snapshot replaces its result.
+func (s *PriceSnapshot) Add(query GraphQLQuery, result gjson.Result) {
+	product, price := queryFilters(query)
+	s.add(product, price, IsSavingsPlanQuery(query.Query), result)
+}
+
+// AddFilters stores the pricing API result for a product filter and price filter pair.
+func (s *PriceSnapshot) AddFilters(product *schema.ProductFilter, price *schema.PriceFilter, result gjson.Result) {
+	s.add(product, price, false, result)
+}
+
+// AddSavingsPlanFilters stores the pricing API result of the savings plan query for a product filter and price filter pair.
+func (s *PriceSnapshot) AddSavingsPlanFilters(product *schema.ProductFilter, price *schema.PriceFilter, result gjson.Result) {
+	s.add(product, price, true, result)
+}
+
+func (s *PriceSnapshot) add(product *schema.ProductFilter, price *schema.PriceFilter, savingsPlan bool, result gjson.Result) {
+	key := snapshotKey(product, price, savingsPlan)
+
+	var raw json.RawMessage
+	if result.Raw != "" {
+		raw = json.RawMessage(result.Raw)
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if _, ok := s.results[key]; ok {
+		for i, entry := range s.Entries {
+			if snapshotKey(entry.ProductFilter, entry.PriceFilter, entry.SavingsPlan) == key {
+				s.Entries[i].Result = raw
+			}
+		}
+	} else {
+		s.Entries = append(s.Entries, PriceSnapshotEntry{
+			ProductFilter: product,
+			PriceFilter:   price,
+			SavingsPlan:   savingsPlan,
+			Result:        raw,
+		})
+	}
+	s.results[key] = result
+}
+
+// Lookup returns the stored result for a product filter and price filter pair, and whether it is in the snapshot.
+func (s *PriceSnapshot) Lookup(product *schema.ProductFilter, price *schema.PriceFilter) (gjson.Result, bool) {
+	return s.lookup(product, price, false)
+}
+
+// LookupSavingsPlan returns the stored result of the savings plan query for a product filter and price filter pair,
+// and whether it is in the snapshot.
+func (s *PriceSnapshot) LookupSavingsPlan(product *schema.ProductFilter, price *schema.PriceFilter) (gjson.Result, bool) {
+	return s.lookup(product, price, true)
+}
+
+func (s *PriceSnapshot) lookup(product *schema.ProductFilter, price *schema.PriceFilter, savingsPlan bool) (gjson.Result, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	result, ok := s.results[snapshotKey(product, price, savingsPlan)]
+	return result, ok
+}
+
+// Results returns the stored result of each query. Queries that are not in the snapshot get an empty
+// result, which is treated the same as a product that the pricing API doesn't know about.
+func (s *PriceSnapshot) Results(queries []GraphQLQuery) []gjson.Result {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	results := make([]gjson.Result, len(queries))
+	for i, query := range queries {
+		product, price := queryFilters(query)
+		results[i] = s.results[snapshotKey(product, price, IsSavingsPlanQuery(query.Query))]
+	}
+	return results
+}
+
+// Len returns the number of entries in the snapshot.
+func (s *PriceSnapshot) Len() int {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	return len(s.Entries)
+}
+
+// Write writes the snapshot to path, sorted by filter so that snapshots of the same prices are identical.
+// The file is gzip compressed when path ends with ".gz".
+func (s *PriceSnapshot) Write(path string) error {
+	// The lock is held until the file is written, so that concurrent writes of the same snapshot don't interleave
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	sort.Slice(s.Entries, func(i, j int) bool {
+		return snapshotKey(s.Entries[i].ProductFilter, s.Entries[i].PriceFilter, s.Entries[i].SavingsPlan) < snapshotKey(s.Entries[j].ProductFilter, s.Entries[j].PriceFilter, s.Entries[j].SavingsPlan)
+	})
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error generating price snapshot: %w", err)
+	}
+
+	if strings.HasSuffix(path, ".gz") {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(b); err != nil {
+			return fmt.Errorf("error compressing price snapshot: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("error compressing price snapshot: %w", err)
+		}
+		b = buf.Bytes()
+	}
+
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("error writing price snapshot file: %w", err)
+	}
+	return nil
+}
+
+func queryFilters(query GraphQLQuery) (*schema.ProductFilter, *schema.PriceFilter) {
+	product, _ := query.Variables["productFilter"].(*schema.ProductFilter)
+	price, _ := query.Variables["priceFilter"].(*schema.PriceFilter)
+	return product, price
+}
+
+// snapshotKey returns the canonical JSON encoding of the filters, which is stable because the filters are
+// structs with a fixed field order. The keys of consumption queries don't mention savings plans, so the keys of
+// existing snapshots are unchanged.
+func snapshotKey(product *schema.ProductFilter, price *schema.PriceFilter, savingsPlan bool) string {
+	b, _ := json.Marshal(struct {
+		ProductFilter *schema.ProductFilter `json:"productFilter"`
+		PriceFilter   *schema.PriceFilter   `json:"priceFilter"`
+		SavingsPlan   bool                  `json:"savingsPlan,omitempty"`
+	}{product, price, savingsPlan})
+	return string(b)
+}
@@ -462,7 +462,7 @@ func (b BlockBuilder) CloneBlock(block *Block, index cty.Value) *Block {
 // BuildModuleBlocks loads all the Blocks for the module at the given path
 func (b BlockBuilder) BuildModuleBlocks(block *Block, modulePath string, rootPath string) (Blocks, error) {
 	var blocks Blocks
-	moduleFiles, err := loadDirectory(b.HCLParser, b.Logger, modulePath, true)
+	moduleFiles, _, err := loadDirectory(b.HCLParser, b.Logger, modulePath, true)
 	if err != nil {
 		return blocks, fmt.Errorf("failed to load module %s: %w", block.Label(), err)
 	}
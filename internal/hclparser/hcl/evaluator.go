@@ -248,6 +248,61 @@ func (e *Evaluator) MissingVars() []string {
 	return missing
 }
 
+// missingVarDiagnostics returns a diagnostic for every missing variable, with the range of its variable block and
+// the resources in the module that reference it.
+func (e *Evaluator) missingVarDiagnostics(missing []string) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	for _, name := range missing {
+		label := strings.TrimPrefix(name, "variable.")
+
+		var resources []string
+		for _, block := range e.module.Blocks.OfType("resource") {
+			if blockReferences(block, name) {
+				resources = append(resources, block.FullName())
+			}
+		}
+
+		detail := fmt.Sprintf("No value was provided for variable %q, so the resources that use it may be priced with a placeholder value.", label)
+		if len(resources) > 0 {
+			detail += fmt.Sprintf(" Resources: %s.", strings.Join(resources, ", "))
+		}
+		diag := &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Missing Terraform variable",
+			Detail:   detail,
+		}
+		for _, block := range e.module.Blocks.OfType("variable") {
+			if block.Label() == label && block.HCLBlock != nil {
+				diag.Subject = block.HCLBlock.DefRange.Ptr()
+				break
+			}
+		}
+		diags = append(diags, diag)
+	}
+
+	return diags
+}
+
+// blockReferences returns whether an attribute of the block or of its nested blocks references ref, e.g.
+// `variable.size` for `var.size`.
+func blockReferences(block *Block, ref string) bool {
+	for _, attr := range block.GetAttributes() {
+		for _, r := range attr.AllReferences() {
+			s := r.String()
+			if s == ref || strings.HasPrefix(s, ref+".") || strings.HasPrefix(s, ref+"[") {
+				return true
+			}
+		}
+	}
+	for _, child := range block.Children() {
+		if blockReferences(child, ref) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Run builds the Evaluator Context using all the provided Blocks. It will build up the Context to hold
 // variable and reference information so that this can be used by Attribute evaluation. Run will also
 // parse and build up and child modules that are referenced in the Blocks and runs child Evaluator on
@@ -289,7 +344,10 @@ func (e *Evaluator) collectModules() *Module {
 	}
 
 	if v := e.MissingVars(); len(v) > 0 {
-		root.Warnings = append(root.Warnings, schema.NewDiagMissingVars(v...))
+		diag := schema.NewDiagMissingVars(v...)
+		// Note-plancost: keep where the variables are declared and which resources use them
+		diag.Err = e.missingVarDiagnostics(v)
+		root.Warnings = append(root.Warnings, diag)
 	}
 
 	return &root
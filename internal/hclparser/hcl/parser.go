@@ -33,6 +33,7 @@ import (
 	"github.com/plancost/terraform-provider-plancost/internal/hclparser/config"
 	"github.com/plancost/terraform-provider-plancost/internal/hclparser/hcl/modules"
 	"github.com/plancost/terraform-provider-plancost/internal/logging"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
 )
 
 var defaultTerraformWorkspaceName = "default"
@@ -487,7 +488,7 @@ func (p *Parser) ParseDirectory() (m *Module, err error) {
 
 	// load the initial root directory into a list of hcl files
 	// at this point these files have no schema associated with them.
-	files, err := loadDirectory(p.hclParser, p.logger, p.detectedProjectPath, false)
+	files, skipped, err := loadDirectory(p.hclParser, p.logger, p.detectedProjectPath, false)
 	if err != nil {
 		return m, err
 	}
@@ -570,6 +571,11 @@ func (p *Parser) ParseDirectory() (m *Module, err error) {
 	// would just add complexity.
 	root.ProviderConstraints = NewProviderConstraints(blocks)
 
+	// Note-plancost: report the files that were skipped, as their resources are missing from the estimate
+	if len(skipped) > 0 {
+		root.Warnings = append(root.Warnings, schema.NewDiagFileParsingFailure(skipped))
+	}
+
 	root.HasChanges = p.hasChanges
 	root.TerraformVarsPaths = p.tfvarsPaths
 	root.ModuleSuffix = p.moduleSuffix
@@ -799,13 +805,16 @@ type file struct {
 	hclFile *hcl.File
 }
 
-func loadDirectory(hclParser *modules.SharedHCLParser, logger zerolog.Logger, fullPath string, stopOnHCLError bool) ([]file, error) {
+// loadDirectory parses the Terraform files in fullPath. Unless stopOnHCLError is set, the files that can't be parsed
+// are skipped and their diagnostics returned.
+func loadDirectory(hclParser *modules.SharedHCLParser, logger zerolog.Logger, fullPath string, stopOnHCLError bool) ([]file, hcl.Diagnostics, error) {
 	fileInfos, err := os.ReadDir(fullPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	files := make([]file, 0)
+	var skipped hcl.Diagnostics
 
 	opentofuOverrides := make(map[string]struct{})
 
@@ -855,10 +864,11 @@ func loadDirectory(hclParser *modules.SharedHCLParser, logger zerolog.Logger, fu
 		f, diag := parseFunc(path)
 		if diag != nil && diag.HasErrors() {
 			if stopOnHCLError {
-				return nil, diag
+				return nil, nil, diag
 			}
 
 			logger.Debug().Msgf("skipping file: %s hcl parsing err: %s", path, diag.Error())
+			skipped = append(skipped, diag...)
 			continue
 		}
 
@@ -870,5 +880,5 @@ func loadDirectory(hclParser *modules.SharedHCLParser, logger zerolog.Logger, fu
 		return files[i].path < files[j].path
 	})
 
-	return files, nil
+	return files, skipped, nil
 }
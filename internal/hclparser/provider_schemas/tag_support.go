@@ -32,14 +32,14 @@ import (
 //go:embed azurerm.tags.json
 var azurermTagsJSON []byte
 
-////go:embed google.labels.json
-//var googleLabelsJSON []byte
-//
-////go:embed google.user_labels.json
-//var googleUserLabelsJSON []byte
-//
-////go:embed google.settings_user_labels.json
-//var googleSettingsUserLabelsJSON []byte
+//go:embed google.labels.json
+var googleLabelsJSON []byte
+
+//go:embed google.user_labels.json
+var googleUserLabelsJSON []byte
+
+//go:embed google.settings_user_labels.json
+var googleSettingsUserLabelsJSON []byte
 
 var AWSTagsSupport map[string]bool
 var AWSTagsAllSupport map[string]bool
@@ -70,18 +70,18 @@ func init() {
 		panic(err)
 	}
 
-	//err = json.Unmarshal(googleLabelsJSON, &GoogleLabelsSupport)
-	//if err != nil {
-	//	panic(err)
-	//}
-	//
-	//err = json.Unmarshal(googleUserLabelsJSON, &GoogleUserLabelsSupport)
-	//if err != nil {
-	//	panic(err)
-	//}
-	//
-	//err = json.Unmarshal(googleSettingsUserLabelsJSON, &GoogleSettingsUserLabelsSupport)
-	//if err != nil {
-	//	panic(err)
-	//}
+	err = json.Unmarshal(googleLabelsJSON, &GoogleLabelsSupport)
+	if err != nil {
+		panic(err)
+	}
+
+	err = json.Unmarshal(googleUserLabelsJSON, &GoogleUserLabelsSupport)
+	if err != nil {
+		panic(err)
+	}
+
+	err = json.Unmarshal(googleSettingsUserLabelsJSON, &GoogleSettingsUserLabelsSupport)
+	if err != nil {
+		panic(err)
+	}
 }
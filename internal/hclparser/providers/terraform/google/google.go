@@ -21,6 +21,11 @@ import (
 
 var DefaultProviderRegion = "us-central1"
 
+// resourceLabelsSupport lists the resources that are labelled using resource_labels rather than labels.
+var resourceLabelsSupport = map[string]bool{
+	"google_container_cluster": true,
+}
+
 func GetDefaultRefIDFunc(d *schema.ResourceData) []string {
 
 	defaultRefs := []string{d.Get("id").String()}
@@ -61,14 +66,19 @@ func ParseTags(r *schema.ResourceData, externalTags, defaultLabels map[string]st
 	_, supportsSettingsUserLabels := provider_schemas.GoogleSettingsUserLabelsSupport[r.Type]
 	rSettingsUserLabels := r.Get("settings.0.user_labels").Map()
 
+	_, supportsResourceLabels := resourceLabelsSupport[r.Type]
+	rResourceLabels := r.Get("resource_labels").Map()
+
 	missingForLabels := schema.ExtractMissingVarsCausingMissingAttributeKeys(r, "labels")
 	missingForUserLabels := schema.ExtractMissingVarsCausingMissingAttributeKeys(r, "user_labels")
 	missingForSettingsUserLabels := schema.ExtractMissingVarsCausingMissingAttributeKeys(r, "settings.0.user_labels")
-	missing := append(append(missingForLabels, missingForUserLabels...), missingForSettingsUserLabels...)
+	missingForResourceLabels := schema.ExtractMissingVarsCausingMissingAttributeKeys(r, "resource_labels")
+	missing := append(append(append(missingForLabels, missingForUserLabels...), missingForSettingsUserLabels...), missingForResourceLabels...)
 
 	if !supportsLabels && len(rLabels) == 0 &&
 		!supportsUserLabels && len(rUserLabels) == 0 &&
-		!supportsSettingsUserLabels && len(rSettingsUserLabels) == 0 {
+		!supportsSettingsUserLabels && len(rSettingsUserLabels) == 0 &&
+		!supportsResourceLabels && len(rResourceLabels) == 0 {
 		return nil, missing
 	}
 
@@ -86,6 +96,9 @@ func ParseTags(r *schema.ResourceData, externalTags, defaultLabels map[string]st
 	for k, v := range rSettingsUserLabels {
 		tags[k] = v.String()
 	}
+	for k, v := range rResourceLabels {
+		tags[k] = v.String()
+	}
 	for k, v := range externalTags {
 		tags[k] = v
 	}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package terraform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+// PlanJSONProvider loads resources from the output of `terraform show -json <planfile>`. Unlike the
+// HCLProvider, the plan already contains the values Terraform computed, so counts, data source results
+// and module outputs are exact.
+type PlanJSONProvider struct {
+	Path   string
+	parser *Parser
+}
+
+// NewPlanJSONProvider returns a PlanJSONProvider for the plan JSON file at path. If includePastResources
+// is true, the resources in the plan's prior_state are also loaded as the project's past resources.
+func NewPlanJSONProvider(path string, includePastResources bool) *PlanJSONProvider {
+	return &PlanJSONProvider{
+		Path:   path,
+		parser: NewParser(nil, includePastResources),
+	}
+}
+
+func (p *PlanJSONProvider) Type() string        { return "terraform_plan_json" }
+func (p *PlanJSONProvider) DisplayType() string { return "Terraform plan JSON" }
+
+// LoadResources reads the plan JSON file and parses its planned values, and optionally its prior state,
+// into a single project.
+func (p *PlanJSONProvider) LoadResources(usage schema.UsageMap) ([]*schema.Project, error) {
+	j, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Terraform plan JSON file: %w", err)
+	}
+
+	// Plans captured in GitHub Actions through the setup-terraform wrapper contain extra output lines.
+	j, _ = StripSetupTerraformWrapper(j)
+
+	metadata := schema.DetectProjectMetadata(filepath.Dir(p.Path))
+	metadata.Type = p.Type()
+
+	project := schema.NewProject(metadata.Path, metadata)
+	project.DisplayName = filepath.Base(p.Path)
+
+	parsedConf, err := p.parser.parseJSON(j, usage)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Terraform plan JSON file %s: %w", p.Path, err)
+	}
+
+	project.AddProviderMetadata(parsedConf.ProviderMetadata)
+	project.Metadata.RemoteModuleCalls = parsedConf.RemoteModuleCalls
+
+	project.PartialPastResources = parsedConf.PastResources
+	project.PartialResources = parsedConf.CurrentResources
+
+	return []*schema.Project{project}, nil
+}
@@ -0,0 +1,142 @@
+package optimization
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+type advisoryCandidate struct {
+	RecommendationIndex int
+	Original            *schema.Resource
+	Alternative         *schema.Resource
+	Required            []*schema.CostComponent
+}
+
+// GetAdvisories applies the optimization rules to the core resources. For the rules that can build their alternative
+// configuration, both the current and the alternative configuration are priced, and the difference of their monthly
+// costs is reported as the savings of the recommendation.
+func GetAdvisories(coreResources []schema.CoreResource, priceFetcher *prices.PriceFetcher) []OptimizationRecommendation {
+	recommendations := make([]OptimizationRecommendation, 0)
+
+	// Create dummy resources for the current and the alternative configurations
+	dummyResources := make([]*schema.Resource, 0)
+	candidates := make([]advisoryCandidate, 0)
+
+	rules := GetRules()
+	for _, cr := range coreResources {
+		for _, rule := range rules {
+			rec := rule.Apply(&cr)
+			if rec == nil {
+				continue
+			}
+
+			original := cr.BuildResource()
+			if original == nil {
+				continue
+			}
+			recommendations = append(recommendations, OptimizationRecommendation{
+				ResourceAddress: original.Name,
+				Description:     *rec,
+				Type:            "Advisory",
+			})
+
+			alternativeRule, ok := rule.(AlternativeRule)
+			if !ok {
+				continue
+			}
+			alternativeCore := alternativeRule.Alternative(&cr)
+			if alternativeCore == nil {
+				continue
+			}
+			alternative := alternativeCore.BuildResource()
+			if alternative == nil {
+				continue
+			}
+
+			candidate := advisoryCandidate{
+				RecommendationIndex: len(recommendations) - 1,
+				Original:            original,
+				Alternative:         alternative,
+			}
+			candidate.Required = append(requiredCostComponents(original), requiredCostComponents(alternative)...)
+			dummyResources = append(dummyResources, original, alternative)
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	if len(dummyResources) == 0 {
+		return recommendations
+	}
+
+	// Fetch prices
+	err := priceFetcher.PopulatePrices(dummyResources)
+	if err != nil {
+		return recommendations
+	}
+
+	// Calculate savings
+	for _, cand := range candidates {
+		// Check if all prices were found, a missing price would make either configuration look cheaper
+		if !allPriced(cand.Required, cand.Original, cand.Alternative) {
+			continue
+		}
+
+		cand.Original.CalculateCosts()
+		cand.Alternative.CalculateCosts()
+		if cand.Original.MonthlyCost == nil || cand.Alternative.MonthlyCost == nil {
+			continue
+		}
+
+		savingsAmount := cand.Original.MonthlyCost.Sub(*cand.Alternative.MonthlyCost)
+		if savingsAmount.LessThanOrEqual(decimal.Zero) {
+			continue
+		}
+
+		savingsPct := savingsAmount.Div(*cand.Original.MonthlyCost).Round(2)
+
+		recommendations[cand.RecommendationIndex].SavingsAmount = savingsAmount.Round(2).InexactFloat64()
+		recommendations[cand.RecommendationIndex].SavingsPercentage = savingsPct.InexactFloat64()
+	}
+
+	return recommendations
+}
+
+// requiredCostComponents returns the cost components of the resource that must be priced, and marks every cost
+// component as ignorable so that the dummy resources aren't reported as missing prices.
+func requiredCostComponents(res *schema.Resource) []*schema.CostComponent {
+	required := make([]*schema.CostComponent, 0)
+	for _, cc := range allCostComponents(res) {
+		if !cc.IgnoreIfMissingPrice {
+			required = append(required, cc)
+		}
+		cc.IgnoreIfMissingPrice = true
+	}
+	return required
+}
+
+// allPriced reports whether the required cost components are still in the resources, as cost components without a
+// price are removed from their resource.
+func allPriced(required []*schema.CostComponent, resources ...*schema.Resource) bool {
+	priced := make(map[*schema.CostComponent]bool)
+	for _, res := range resources {
+		for _, cc := range allCostComponents(res) {
+			priced[cc] = true
+		}
+	}
+
+	for _, cc := range required {
+		if !priced[cc] {
+			return false
+		}
+	}
+	return true
+}
+
+func allCostComponents(res *schema.Resource) []*schema.CostComponent {
+	costComponents := append([]*schema.CostComponent{}, res.CostComponents...)
+	for _, subRes := range res.FlattenedSubResources() {
+		costComponents = append(costComponents, subRes.CostComponents...)
+	}
+	return costComponents
+}
@@ -0,0 +1,208 @@
+package optimization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	"github.com/plancost/terraform-provider-plancost/internal/resources/azure"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestGetAdvisories(t *testing.T) {
+	premiumDisk := &azure.ManagedDisk{
+		Address:         "azurerm_managed_disk.premium",
+		Region:          "eastus",
+		ManagedDiskData: azure.ManagedDiskData{DiskType: "Premium_LRS", DiskSizeGB: 128},
+	}
+	standardDisk := &azure.ManagedDisk{
+		Address:         "azurerm_managed_disk.standard",
+		Region:          "eastus",
+		ManagedDiskData: azure.ManagedDiskData{DiskType: "StandardSSD_LRS", DiskSizeGB: 128},
+	}
+	storageAccount := &azure.StorageAccount{
+		Address:                "azurerm_storage_account.logs",
+		Region:                 "eastus",
+		AccessTier:             "Hot",
+		AccountKind:            "StorageV2",
+		AccountReplicationType: "LRS",
+		AccountTier:            "Standard",
+	}
+
+	// A P10 disk costs 19.71 per month, and 128 GiB of Premium SSD v2 costs 0.000112 per GiB-hour. The storage
+	// account has no prices, so its savings are unknown.
+	snapshot := apiclient.NewPriceSnapshot("USD")
+	premiumV2Disk := &azure.ManagedDisk{
+		Address:         "azurerm_managed_disk.premium",
+		Region:          "eastus",
+		ManagedDiskData: azure.ManagedDiskData{DiskType: "PremiumV2_LRS", DiskSizeGB: 128},
+	}
+	for _, res := range []*schema.Resource{premiumDisk.BuildResource(), premiumV2Disk.BuildResource()} {
+		for _, cc := range res.CostComponents {
+			price := "19.71"
+			if strings.HasPrefix(cc.Name, "Storage (premium v2") {
+				price = "0.000112"
+			}
+			snapshot.AddFilters(cc.ProductFilter, cc.PriceFilter, gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"`+price+`"}]}]}}`))
+		}
+	}
+	priceFetcher := prices.NewPriceFetcher("", "")
+	priceFetcher.UseSnapshot(snapshot)
+
+	recommendations := GetAdvisories([]schema.CoreResource{premiumDisk, standardDisk, storageAccount}, priceFetcher)
+	require.Len(t, recommendations, 2)
+
+	assert.Equal(t, OptimizationRecommendation{
+		ResourceAddress:   "azurerm_managed_disk.premium",
+		Description:       "Consider updating Premium SSD disk to Premium SSD v2 for better performance and cost",
+		Type:              "Advisory",
+		SavingsAmount:     9.24,
+		SavingsPercentage: 0.47,
+	}, recommendations[0])
+
+	assert.Equal(t, OptimizationRecommendation{
+		ResourceAddress: "azurerm_storage_account.logs",
+		Description:     "Consider using Cool or Archive access tier for infrequently accessed data",
+		Type:            "Advisory",
+	}, recommendations[1])
+
+	// The dummy resources aren't reported as missing prices
+	assert.Equal(t, 0, priceFetcher.MissingPricesLen())
+}
+
+func TestAlternatives(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     AlternativeRule
+		resource schema.CoreResource
+		expected schema.CoreResource
+	}{
+		{
+			name:     "premium disk",
+			rule:     &diskOptimization{},
+			resource: &azure.ManagedDisk{ManagedDiskData: azure.ManagedDiskData{DiskType: "Premium_LRS"}},
+			expected: &azure.ManagedDisk{ManagedDiskData: azure.ManagedDiskData{DiskType: "PremiumV2_LRS", DiskSizeGB: 30}},
+		},
+		{
+			name:     "standard disk",
+			rule:     &diskOptimization{},
+			resource: &azure.ManagedDisk{ManagedDiskData: azure.ManagedDiskData{DiskType: "StandardSSD_LRS"}},
+		},
+		{
+			name:     "storage v1 account",
+			rule:     &storageAccountOptimization{},
+			resource: &azure.StorageAccount{AccountKind: "Storage", AccessTier: "Standard", AccountReplicationType: "LRS"},
+			expected: &azure.StorageAccount{AccountKind: "StorageV2", AccessTier: "Hot", AccountReplicationType: "LRS"},
+		},
+		{
+			name:     "geo-redundant storage account",
+			rule:     &storageAccountOptimization{},
+			resource: &azure.StorageAccount{AccountKind: "StorageV2", AccessTier: "Cool", AccountReplicationType: "RA-GZRS"},
+			expected: &azure.StorageAccount{AccountKind: "StorageV2", AccessTier: "Cool", AccountReplicationType: "ZRS"},
+		},
+		{
+			name:     "previous generation vm",
+			rule:     &latestGenerationVMSizeOptimization{},
+			resource: &azure.VirtualMachine{VMSize: "Standard_D2s_v3"},
+			expected: &azure.VirtualMachine{VMSize: "Standard_D2s_v5"},
+		},
+		{
+			name:     "vm without a version",
+			rule:     &latestGenerationVMSizeOptimization{},
+			resource: &azure.VirtualMachine{VMSize: "Standard_A1"},
+			expected: &azure.VirtualMachine{VMSize: "Standard_A1_v2"},
+		},
+		{
+			name:     "g series vm",
+			rule:     &latestGenerationVMSizeOptimization{},
+			resource: &azure.VirtualMachine{VMSize: "Standard_G2"},
+		},
+		{
+			name:     "scale set",
+			rule:     &spotOptimization{},
+			resource: &azure.LinuxVirtualMachineScaleSet{SKU: "Standard_D2s_v5"},
+			expected: &azure.LinuxVirtualMachineScaleSet{SKU: "Standard_D2s_v5", Priority: azure.VMPrioritySpot},
+		},
+		{
+			name:     "spot scale set",
+			rule:     &spotOptimization{},
+			resource: &azure.WindowsVirtualMachineScaleSet{SKU: "Standard_D2s_v5", Priority: azure.VMPrioritySpot},
+		},
+		{
+			name:     "user node pool",
+			rule:     &spotOptimization{},
+			resource: &azure.KubernetesClusterNodePool{VMSize: "Standard_D2s_v5", Mode: "User"},
+			expected: &azure.KubernetesClusterNodePool{VMSize: "Standard_D2s_v5", Mode: "User", Priority: azure.VMPrioritySpot},
+		},
+		{
+			name:     "system node pool",
+			rule:     &spotOptimization{},
+			resource: &azure.KubernetesClusterNodePool{VMSize: "Standard_D2s_v5", Mode: "System"},
+		},
+		{
+			name:     "machine learning compute cluster",
+			rule:     &spotOptimization{},
+			resource: &azure.MachineLearningComputeCluster{InstanceType: "Standard_NC6"},
+			expected: &azure.MachineLearningComputeCluster{InstanceType: "Standard_NC6", Priority: azure.VMPriorityLowPriority},
+		},
+		{
+			name:     "standalone vm",
+			rule:     &spotOptimization{},
+			resource: &azure.LinuxVirtualMachine{Size: "Standard_D2s_v5"},
+		},
+		{
+			name:     "windows vm",
+			rule:     &vmOptimization{},
+			resource: &azure.VirtualMachine{VMSize: "Standard_D2s_v5", StorageOSDiskOSType: "Windows"},
+			expected: &azure.VirtualMachine{VMSize: "Standard_D2s_v5", StorageOSDiskOSType: "Windows", LicenseType: "Windows_Server"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alternative := tt.rule.Alternative(&tt.resource)
+			if tt.expected == nil {
+				assert.Nil(t, alternative)
+				return
+			}
+			assert.Equal(t, tt.expected, alternative)
+		})
+	}
+}
+
+func TestGetAdvisoriesSpot(t *testing.T) {
+	instances := int64(3)
+	scaleSet := &azure.LinuxVirtualMachineScaleSet{
+		Address:   "azurerm_linux_virtual_machine_scale_set.workers",
+		Region:    "eastus",
+		SKU:       "Standard_D2s_v5",
+		Instances: &instances,
+	}
+	spotScaleSet := *scaleSet
+	spotScaleSet.Priority = azure.VMPrioritySpot
+
+	// Spot instances are priced at 20% of the pay as you go price
+	snapshot := apiclient.NewPriceSnapshot("USD")
+	for _, res := range []*schema.Resource{scaleSet.BuildResource(), spotScaleSet.BuildResource()} {
+		cc := res.CostComponents[0]
+		price := "0.096"
+		if strings.Contains(cc.Name, "spot") {
+			price = "0.0192"
+		}
+		snapshot.AddFilters(cc.ProductFilter, cc.PriceFilter, gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"`+price+`"}]}]}}`))
+	}
+	priceFetcher := prices.NewPriceFetcher("", "")
+	priceFetcher.UseSnapshot(snapshot)
+
+	recommendations := GetAdvisories([]schema.CoreResource{scaleSet}, priceFetcher)
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, "azurerm_linux_virtual_machine_scale_set.workers", recommendations[0].ResourceAddress)
+	assert.Equal(t, spotRecommendation, recommendations[0].Description)
+	assert.Equal(t, 168.19, recommendations[0].SavingsAmount)
+	assert.Equal(t, 0.8, recommendations[0].SavingsPercentage)
+}
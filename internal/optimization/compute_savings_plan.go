@@ -0,0 +1,184 @@
+package optimization
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// SavingsPlanAddress is the resource address of the compute savings plan recommendations, which cover all the
+// eligible compute resources of the estimate.
+const SavingsPlanAddress = "Savings Plan for Compute"
+
+// savingsPlanTerms are the savings plan terms of the pricing API by the term of the recommendations.
+var savingsPlanTerms = map[string]string{
+	"1 yr": "1 Year",
+	"3 yr": "3 Years",
+}
+
+type savingsPlanCandidate struct {
+	ResourceName      string
+	OriginalComponent *schema.CostComponent
+}
+
+// GetComputeSavingsPlans recommends 1-year and 3-year Azure Savings Plans for Compute. Savings plan prices are hourly
+// prices nested in the consumption prices of the compute products, so the hourly commitment is the sum of the savings
+// plan prices of all eligible compute components. The reservations of the same resources are reported in the
+// description, to compare both options side by side.
+func GetComputeSavingsPlans(resources []*schema.Resource, reservations []OptimizationRecommendation, priceFetcher *prices.PriceFetcher) []OptimizationRecommendation {
+	recommendations := make([]OptimizationRecommendation, 0)
+
+	// The candidates are looked up with their own consumption filters
+	lookupResources := make([]*schema.Resource, 0)
+	candidates := make([]savingsPlanCandidate, 0)
+
+	for _, res := range resources {
+		costComponents := append([]*schema.CostComponent{}, res.CostComponents...)
+		for _, subRes := range res.FlattenedSubResources() {
+			costComponents = append(costComponents, subRes.CostComponents...)
+		}
+
+		for _, cc := range costComponents {
+			if cc.Price().IsZero() || !isSavingsPlanCandidate(cc) {
+				continue
+			}
+
+			lookupResources = append(lookupResources, &schema.Resource{
+				Name:           res.Name,
+				ResourceType:   res.ResourceType,
+				CostComponents: []*schema.CostComponent{cc},
+			})
+			candidates = append(candidates, savingsPlanCandidate{
+				ResourceName:      res.Name,
+				OriginalComponent: cc,
+			})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return recommendations
+	}
+
+	savingsPlanPrices, err := priceFetcher.SavingsPlanPrices(lookupResources)
+	if err != nil {
+		logging.Logger.Warn().Err(err).Msg("Failed to fetch the savings plan prices, no savings plans are recommended")
+		return recommendations
+	}
+
+	// Aggregate the commitment and the savings of each term
+	for _, term := range []string{"1 yr", "3 yr"} {
+		hourlyCommitment := decimal.Zero
+		monthlyConsumption := decimal.Zero
+		monthlySavingsPlanCost := decimal.Zero
+		covered := make(map[string]bool)
+
+		for _, cand := range candidates {
+			// Components without a savings plan price stay on consumption prices
+			savingsPlanPrice, ok := savingsPlanPrices[cand.OriginalComponent][savingsPlanTerms[term]]
+			if !ok {
+				continue
+			}
+
+			hourlyQuantity, ok := savingsPlanHourlyQuantity(cand.OriginalComponent)
+			if !ok {
+				continue
+			}
+			monthlyHours := hourlyQuantity.Mul(schema.HourToMonthUnitMultiplier)
+
+			consumption := cand.OriginalComponent.Price().Mul(monthlyHours)
+			savingsPlanCost := savingsPlanPrice.Mul(monthlyHours)
+			if savingsPlanCost.GreaterThanOrEqual(consumption) {
+				continue
+			}
+
+			hourlyCommitment = hourlyCommitment.Add(savingsPlanPrice.Mul(hourlyQuantity))
+			monthlyConsumption = monthlyConsumption.Add(consumption)
+			monthlySavingsPlanCost = monthlySavingsPlanCost.Add(savingsPlanCost)
+			covered[cand.ResourceName] = true
+		}
+
+		savingsAmount := monthlyConsumption.Sub(monthlySavingsPlanCost)
+		if savingsAmount.LessThanOrEqual(decimal.Zero) {
+			continue
+		}
+
+		savingsPct := savingsAmount.Div(monthlyConsumption).Round(2)
+		currency := prices.CurrencySymbol(priceFetcher.Currency())
+
+		// Format: "Commit to $1.23/hour with a 1-year Savings Plan for Compute to save $369/mo (28%) on 4 compute resources"
+		formattedTerm := strings.Replace(term, " yr", "-year", 1)
+		description := fmt.Sprintf("Commit to %s%s/hour with a %s Savings Plan for Compute to save %s%.0f/mo (%.0f%%) on %d compute %s",
+			currency,
+			hourlyCommitment.Round(2).StringFixed(2),
+			formattedTerm,
+			currency,
+			savingsAmount.InexactFloat64(),
+			savingsPct.InexactFloat64()*100,
+			len(covered),
+			pluralize("resource", len(covered)),
+		)
+		if reservationSavings, ok := reservationSavings(reservations, covered, term); ok {
+			description += fmt.Sprintf(", compared to %s%.0f/mo with %s Reservations", currency, reservationSavings, formattedTerm)
+		}
+
+		recommendations = append(recommendations, OptimizationRecommendation{
+			ResourceAddress:   SavingsPlanAddress,
+			Description:       description,
+			Type:              "SavingsPlan",
+			Term:              term,
+			SavingsAmount:     savingsAmount.InexactFloat64(),
+			SavingsPercentage: savingsPct.InexactFloat64(),
+			HourlyCommitment:  hourlyCommitment.Round(4).InexactFloat64(),
+		})
+	}
+
+	return recommendations
+}
+
+// reservationSavings returns the total monthly savings of the reservations of the covered resources for the term.
+func reservationSavings(reservations []OptimizationRecommendation, covered map[string]bool, term string) (float64, bool) {
+	var total float64
+	found := false
+	for _, rec := range reservations {
+		if rec.Type != "Reservation" || rec.Term != term || !covered[rec.ResourceAddress] {
+			continue
+		}
+		total += rec.SavingsAmount
+		found = true
+	}
+	return total, found
+}
+
+// isSavingsPlanCandidate reports whether the cost component is eligible for a savings plan for compute, i.e. it is an
+// hourly consumption price of a compute product.
+func isSavingsPlanCandidate(cc *schema.CostComponent) bool {
+	if cc.PriceFilter != nil && cc.PriceFilter.PurchaseOption != nil && *cc.PriceFilter.PurchaseOption != "Consumption" {
+		return false
+	}
+
+	if cc.ProductFilter == nil || cc.ProductFilter.ProductFamily == nil || *cc.ProductFilter.ProductFamily != "Compute" {
+		return false
+	}
+
+	if cc.PriceFilter != nil && cc.PriceFilter.Unit != nil {
+		return *cc.PriceFilter.Unit == "1 Hour"
+	}
+	return strings.EqualFold(cc.Unit, "hours")
+}
+
+// savingsPlanHourlyQuantity returns the number of instances the savings plan commitment covers. Components priced by
+// monthly hours are converted back to an hourly quantity. Components without a quantity, such as usage-based
+// components without usage, aren't covered.
+func savingsPlanHourlyQuantity(cc *schema.CostComponent) (decimal.Decimal, bool) {
+	if cc.HourlyQuantity != nil {
+		return *cc.HourlyQuantity, true
+	}
+	if cc.MonthlyQuantity != nil {
+		return cc.MonthlyQuantity.Div(schema.HourToMonthUnitMultiplier), true
+	}
+	return decimal.Zero, false
+}
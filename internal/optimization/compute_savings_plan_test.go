@@ -0,0 +1,128 @@
+package optimization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func computeResource(name, skuName string, price float64, hourlyQuantity int64) *schema.Resource {
+	cc := &schema.CostComponent{
+		Name:           "Instance usage (Linux, pay as you go, " + skuName + ")",
+		Unit:           "hours",
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(hourlyQuantity)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr("eastus"),
+			Service:       strPtr("Virtual Machines"),
+			ProductFamily: strPtr("Compute"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "skuName", Value: strPtr(skuName)},
+			},
+		},
+		PriceFilter: &schema.PriceFilter{
+			PurchaseOption: strPtr("Consumption"),
+			Unit:           strPtr("1 Hour"),
+		},
+	}
+	cc.SetPrice(decimal.NewFromFloat(price))
+	cc.SetPriceHash(strings.ToLower(strings.ReplaceAll(skuName, " ", "")))
+	return &schema.Resource{
+		Name:           name,
+		ResourceType:   "azurerm_linux_virtual_machine",
+		CostComponents: []*schema.CostComponent{cc},
+	}
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func TestGetComputeSavingsPlans(t *testing.T) {
+	resources := []*schema.Resource{
+		computeResource("azurerm_linux_virtual_machine.web", "D2s v5", 0.096, 2),
+		computeResource("azurerm_linux_virtual_machine.db", "E4s v5", 0.252, 1),
+		// No savings plan price, so it stays on consumption prices
+		computeResource("azurerm_linux_virtual_machine.legacy", "A1 v2", 0.043, 1),
+	}
+
+	// The savings plan prices are nested in the consumption prices, as the pricing API returns them
+	snapshot, err := apiclient.LoadPriceSnapshot("testdata/savings_plan_prices.json")
+	require.NoError(t, err)
+	priceFetcher := prices.NewPriceFetcher("", "")
+	priceFetcher.UseSnapshot(snapshot)
+
+	reservations := []OptimizationRecommendation{
+		{ResourceAddress: "azurerm_linux_virtual_machine.web", Type: "Reservation", Term: "1 yr", SavingsAmount: 50},
+		{ResourceAddress: "azurerm_linux_virtual_machine.db", Type: "Reservation", Term: "1 yr", SavingsAmount: 70},
+		{ResourceAddress: "azurerm_linux_virtual_machine.legacy", Type: "Reservation", Term: "1 yr", SavingsAmount: 10},
+	}
+
+	recommendations := GetComputeSavingsPlans(resources, reservations, priceFetcher)
+	require.Len(t, recommendations, 2)
+
+	// 1 yr: (2 * 0.096 + 0.252) * 730 = 324.12 on consumption, (2 * 0.0798 + 0.2094) * 730 = 269.37 with the plan
+	assert.Equal(t, SavingsPlanAddress, recommendations[0].ResourceAddress)
+	assert.Equal(t, "SavingsPlan", recommendations[0].Type)
+	assert.Equal(t, "1 yr", recommendations[0].Term)
+	assert.Equal(t, 0.369, recommendations[0].HourlyCommitment)
+	assert.InDelta(t, 54.75, recommendations[0].SavingsAmount, 0.001)
+	assert.Equal(t, 0.17, recommendations[0].SavingsPercentage)
+	assert.Equal(t, "Commit to $0.37/hour with a 1-year Savings Plan for Compute to save $55/mo (17%) on 2 compute resources, compared to $120/mo with 1-year Reservations", recommendations[0].Description)
+
+	// 3 yr: (2 * 0.0571 + 0.1499) * 730 = 192.79 with the plan, there are no 3 yr reservations to compare to
+	assert.Equal(t, "3 yr", recommendations[1].Term)
+	assert.Equal(t, 0.2641, recommendations[1].HourlyCommitment)
+	assert.InDelta(t, 131.327, recommendations[1].SavingsAmount, 0.001)
+	assert.Equal(t, "Commit to $0.26/hour with a 3-year Savings Plan for Compute to save $131/mo (41%) on 2 compute resources", recommendations[1].Description)
+
+	assert.Equal(t, 0, priceFetcher.MissingPricesLen())
+}
+
+func TestIsSavingsPlanCandidate(t *testing.T) {
+	compute := computeResource("vm", "D2s v5", 0.096, 1).CostComponents[0]
+	assert.True(t, isSavingsPlanCandidate(compute))
+
+	spot := computeResource("vm", "D2s v5", 0.096, 1).CostComponents[0]
+	spot.PriceFilter.PurchaseOption = strPtr("Spot")
+	assert.False(t, isSavingsPlanCandidate(spot))
+
+	storage := computeResource("disk", "P10", 19.71, 1).CostComponents[0]
+	storage.ProductFilter.ProductFamily = strPtr("Storage")
+	assert.False(t, isSavingsPlanCandidate(storage))
+
+	monthly := computeResource("plan", "P1v3", 0.1, 1).CostComponents[0]
+	monthly.PriceFilter.Unit = strPtr("1/Month")
+	assert.False(t, isSavingsPlanCandidate(monthly))
+}
+
+func TestGetComputeSavingsPlans_MonthlyQuantity(t *testing.T) {
+	// Priced by monthly hours, e.g. a compute cluster with a monthly_hrs usage
+	monthly := computeResource("azurerm_machine_learning_compute_cluster.ml", "D2s v5", 0.096, 0)
+	monthly.CostComponents[0].HourlyQuantity = nil
+	monthly.CostComponents[0].MonthlyQuantity = decimalPtr(decimal.NewFromInt(1460))
+
+	// Usage-based without usage, so there's nothing to commit to
+	noUsage := computeResource("azurerm_machine_learning_compute_cluster.idle", "E4s v5", 0.252, 0)
+	noUsage.CostComponents[0].HourlyQuantity = nil
+
+	snapshot, err := apiclient.LoadPriceSnapshot("testdata/savings_plan_prices.json")
+	require.NoError(t, err)
+	priceFetcher := prices.NewPriceFetcher("", "")
+	priceFetcher.UseSnapshot(snapshot)
+
+	recommendations := GetComputeSavingsPlans([]*schema.Resource{monthly, noUsage}, nil, priceFetcher)
+	require.Len(t, recommendations, 2)
+
+	// 1 yr: 1460 / 730 = 2 instances, (0.096 - 0.0798) * 1460 = 23.65
+	assert.Equal(t, 0.1596, recommendations[0].HourlyCommitment)
+	assert.InDelta(t, 23.652, recommendations[0].SavingsAmount, 0.001)
+	assert.Equal(t, "Commit to $0.16/hour with a 1-year Savings Plan for Compute to save $24/mo (17%) on 1 compute resource", recommendations[0].Description)
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/plancost/terraform-provider-plancost/internal/schema"
 )
 
+// defaultDiskSizeGB is the size that Premium SSD disks without a disk_size_gb are priced at.
+const defaultDiskSizeGB = 30
+
 type diskOptimization struct{}
 
 func (r diskOptimization) Description() string {
@@ -30,4 +33,19 @@ func (r diskOptimization) Apply(input *schema.CoreResource) *string {
 	return nil
 }
 
-var _ Rule = &diskOptimization{}
+// Alternative returns the disk as a Premium SSD v2 disk of the same size, with the baseline IOPS and throughput.
+func (r diskOptimization) Alternative(input *schema.CoreResource) schema.CoreResource {
+	if r.Apply(input) == nil {
+		return nil
+	}
+	resource := (*input).(*azure.ManagedDisk)
+
+	alternative := *resource
+	alternative.DiskType = "PremiumV2_LRS"
+	if alternative.DiskSizeGB == 0 {
+		alternative.DiskSizeGB = defaultDiskSizeGB
+	}
+	return &alternative
+}
+
+var _ AlternativeRule = &diskOptimization{}
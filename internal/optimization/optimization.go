@@ -7,6 +7,14 @@ type Rule interface {
 	Apply(resource *tfschema.CoreResource) *string
 }
 
+// AlternativeRule is a Rule that can build the alternative configuration it recommends, e.g. the resource with a new
+// disk SKU, so that the savings of the recommendation can be priced. Alternative returns nil if the rule doesn't apply
+// to the resource or has no priceable alternative.
+type AlternativeRule interface {
+	Rule
+	Alternative(resource *tfschema.CoreResource) tfschema.CoreResource
+}
+
 func GetRules() []Rule {
 	return []Rule{
 		&latestGenerationVMSizeOptimization{},
@@ -16,5 +24,6 @@ func GetRules() []Rule {
 		&vmOptimization{},
 		&databaseOptimization{},
 		&monitorOptimization{},
+		&spotOptimization{},
 	}
 }
@@ -119,7 +119,8 @@ func GetSavingPlans(resources []*schema.Resource, priceFetcher *prices.PriceFetc
 		// Term formatting: "1 yr" -> "1-year"
 		formattedTerm := strings.Replace(cand.TermLength, " yr", "-year", 1)
 
-		description := fmt.Sprintf("Save $%.0f/mo (%.0f%%) on %s with a %s Reservation",
+		description := fmt.Sprintf("Save %s%.0f/mo (%.0f%%) on %s with a %s Reservation",
+			prices.CurrencySymbol(priceFetcher.Currency()),
 			savingsAmount.InexactFloat64(),
 			savingsPct.InexactFloat64()*100,
 			cand.ResourceName,
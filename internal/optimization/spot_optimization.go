@@ -0,0 +1,90 @@
+package optimization
+
+import (
+	"strings"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources/azure"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const (
+	spotRecommendation = "Consider using Spot instances if the workload is stateless and tolerates interruptions. " +
+		"Spot VMs can be evicted with 30 seconds notice whenever Azure needs the capacity, and eviction rates vary by VM size and region"
+	lowPriorityRecommendation = "Consider using low priority VMs if the jobs tolerate interruptions. " +
+		"Low priority VMs can be preempted whenever Azure needs the capacity, and preempted jobs wait until nodes are available again"
+)
+
+// spotOptimization suggests moving the pools of stateless workloads to Spot, e.g. scale sets and AKS user node pools.
+// Standalone VMs and AKS system node pools usually aren't stateless, so they are not eligible.
+type spotOptimization struct{}
+
+func (r spotOptimization) Description() string {
+	return "Suggests Spot instances for stateless Azure compute pools."
+}
+
+func (r spotOptimization) Apply(input *schema.CoreResource) *string {
+	if input == nil {
+		return nil
+	}
+
+	switch resource := (*input).(type) {
+	case *azure.LinuxVirtualMachineScaleSet:
+		if resource.Priority == "" {
+			return stringPtr(spotRecommendation)
+		}
+	case *azure.WindowsVirtualMachineScaleSet:
+		if resource.Priority == "" {
+			return stringPtr(spotRecommendation)
+		}
+	case *azure.VirtualMachineScaleSet:
+		if resource.Priority == "" {
+			return stringPtr(spotRecommendation)
+		}
+	case *azure.KubernetesClusterNodePool:
+		// System node pools can't use Spot instances
+		if resource.Priority == "" && !strings.EqualFold(resource.Mode, "System") {
+			return stringPtr(spotRecommendation)
+		}
+	case *azure.MachineLearningComputeCluster:
+		// Machine learning compute clusters use low priority VMs rather than Spot VMs
+		if resource.Priority == "" {
+			return stringPtr(lowPriorityRecommendation)
+		}
+	}
+
+	return nil
+}
+
+// Alternative returns the pool with Spot instances, or low priority VMs for machine learning compute clusters.
+func (r spotOptimization) Alternative(input *schema.CoreResource) schema.CoreResource {
+	if r.Apply(input) == nil {
+		return nil
+	}
+
+	switch resource := (*input).(type) {
+	case *azure.LinuxVirtualMachineScaleSet:
+		alternative := *resource
+		alternative.Priority = azure.VMPrioritySpot
+		return &alternative
+	case *azure.WindowsVirtualMachineScaleSet:
+		alternative := *resource
+		alternative.Priority = azure.VMPrioritySpot
+		return &alternative
+	case *azure.VirtualMachineScaleSet:
+		alternative := *resource
+		alternative.Priority = azure.VMPrioritySpot
+		return &alternative
+	case *azure.KubernetesClusterNodePool:
+		alternative := *resource
+		alternative.Priority = azure.VMPrioritySpot
+		return &alternative
+	case *azure.MachineLearningComputeCluster:
+		alternative := *resource
+		alternative.Priority = azure.VMPriorityLowPriority
+		return &alternative
+	}
+
+	return nil
+}
+
+var _ AlternativeRule = &spotOptimization{}
@@ -22,26 +22,63 @@ func (r storageAccountOptimization) Apply(input *schema.CoreResource) *string {
 		return nil
 	}
 
+	if msg, _ := r.recommend(resource); msg != "" {
+		return stringPtr(msg)
+	}
+
+	return nil
+}
+
+// Alternative returns the storage account with the account kind, access tier or replication type of the recommendation.
+func (r storageAccountOptimization) Alternative(input *schema.CoreResource) schema.CoreResource {
+	if input == nil {
+		return nil
+	}
+	resource, ok := (*input).(*azure.StorageAccount)
+	if !ok {
+		return nil
+	}
+
+	if _, alternative := r.recommend(resource); alternative != nil {
+		return alternative
+	}
+
+	return nil
+}
+
+func (r storageAccountOptimization) recommend(resource *azure.StorageAccount) (string, *azure.StorageAccount) {
+	alternative := *resource
+
 	// Storage Accounts - consider upgrading account kind to StorageV2
 	if strings.EqualFold(resource.AccountKind, "Storage") || strings.EqualFold(resource.AccountKind, "BlobStorage") {
-		return stringPtr("Consider upgrading account kind to StorageV2")
+		// StorageV1 accounts are priced with the Standard access tier, StorageV2 accounts default to Hot
+		alternative.AccountKind = "StorageV2"
+		alternative.AccessTier = "Hot"
+		return "Consider upgrading account kind to StorageV2", &alternative
 	}
 
 	// Storage Accounts - consider using a preferred access tier
 	if strings.EqualFold(resource.AccessTier, "Hot") {
-		return stringPtr("Consider using Cool or Archive access tier for infrequently accessed data")
+		alternative.AccessTier = "Cool"
+		return "Consider using Cool or Archive access tier for infrequently accessed data", &alternative
 	}
 
 	// Storage Accounts - consider using preferred replication type
 	// Suggest LRS if using GRS/RA-GRS for non-critical workloads (heuristic)
 	if strings.EqualFold(resource.AccountReplicationType, "GRS") ||
 		strings.EqualFold(resource.AccountReplicationType, "RAGRS") ||
-		strings.EqualFold(resource.AccountReplicationType, "GZRS") ||
-		strings.EqualFold(resource.AccountReplicationType, "RAGZRS") {
-		return stringPtr("Consider using LRS or ZRS replication for cost savings if geo-redundancy is not required")
+		strings.EqualFold(resource.AccountReplicationType, "RA-GRS") {
+		alternative.AccountReplicationType = "LRS"
+		return "Consider using LRS or ZRS replication for cost savings if geo-redundancy is not required", &alternative
+	}
+	if strings.EqualFold(resource.AccountReplicationType, "GZRS") ||
+		strings.EqualFold(resource.AccountReplicationType, "RAGZRS") ||
+		strings.EqualFold(resource.AccountReplicationType, "RA-GZRS") {
+		alternative.AccountReplicationType = "ZRS"
+		return "Consider using LRS or ZRS replication for cost savings if geo-redundancy is not required", &alternative
 	}
 
-	return nil
+	return "", nil
 }
 
-var _ Rule = &storageAccountOptimization{}
+var _ AlternativeRule = &storageAccountOptimization{}
@@ -4,8 +4,11 @@ package optimization
 type OptimizationRecommendation struct {
 	ResourceAddress   string
 	Description       string  // Description of the optimization opportunity
-	Type              string  // e.g., "Reservation", "Advisor"
+	Type              string  // e.g., "Reservation", "SavingsPlan", "Advisory"
 	Term              string  // e.g., "1 Year", "3 Year"
 	SavingsAmount     float64 // The estimated monthly savings amount in USD
 	SavingsPercentage float64
+	Severity          string            // e.g., "info", "warning", only set by recommendation rules
+	Replacement       map[string]string // The suggested attribute values, only set by recommendation rules
+	HourlyCommitment  float64           // The hourly commitment of a savings plan, only set by savings plans
 }
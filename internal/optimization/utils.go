@@ -19,3 +19,11 @@ func GroupOptimizations(opts []OptimizationRecommendation) (map[string][]Optimiz
 
 	return grouped, keys
 }
+
+// pluralize returns the noun in its plural form unless the count is 1.
+func pluralize(noun string, count int) string {
+	if count == 1 {
+		return noun
+	}
+	return noun + "s"
+}
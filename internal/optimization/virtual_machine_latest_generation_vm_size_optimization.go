@@ -25,17 +25,44 @@ func (l latestGenerationVMSizeOptimization) Apply(input *schema.CoreResource) *s
 		return nil
 	}
 
-	vmSize := resource.VMSize
+	if msg, _ := l.recommend(resource.VMSize); msg != "" {
+		return stringPtr(msg)
+	}
+
+	return nil
+}
 
+// Alternative returns the virtual machine with the same size in the latest generation of its series, e.g.
+// Standard_D2s_v5 for Standard_D2s_v3. The G, GS and ND series have no direct successor size, so they have no alternative.
+func (l latestGenerationVMSizeOptimization) Alternative(input *schema.CoreResource) schema.CoreResource {
+	if input == nil {
+		return nil
+	}
+	resource, ok := (*input).(*azure.VirtualMachine)
+	if !ok {
+		return nil
+	}
+
+	if _, vmSize := l.recommend(resource.VMSize); vmSize != "" {
+		alternative := *resource
+		alternative.VMSize = vmSize
+		return &alternative
+	}
+
+	return nil
+}
+
+// recommend returns the recommendation for the VM size, and the latest generation VM size if there is one.
+func (l latestGenerationVMSizeOptimization) recommend(vmSize string) (string, string) {
 	// Specific upgrades
 	if strings.HasPrefix(vmSize, "Standard_G") && !strings.HasPrefix(vmSize, "Standard_GS") {
-		return stringPtr("Consider upgrading G series machines to Ev5")
+		return "Consider upgrading G series machines to Ev5", ""
 	}
 	if strings.HasPrefix(vmSize, "Standard_GS") {
-		return stringPtr("Consider upgrading GS series machines to Esv5 or Mv3")
+		return "Consider upgrading GS series machines to Esv5 or Mv3", ""
 	}
 	if strings.HasPrefix(vmSize, "Standard_ND") {
-		return stringPtr("Consider upgrading ND series machines to NCas T4 v3 or NDamsr A100 v4")
+		return "Consider upgrading ND series machines to NCas T4 v3 or NDamsr A100 v4", ""
 	}
 
 	// Generic latest generation checks
@@ -43,16 +70,22 @@ func (l latestGenerationVMSizeOptimization) Apply(input *schema.CoreResource) *s
 	if family != nil {
 		version := getVMVersion(vmSize)
 		if version < family.Version {
-			return stringPtr(fmt.Sprintf("Consider using the latest generation version %d of %s series for better performance and cost efficiency", family.Version, family.Family))
+			return fmt.Sprintf("Consider using the latest generation version %d of %s series for better performance and cost efficiency", family.Version, family.Family), withVMVersion(vmSize, family.Version)
 		}
 	}
 
-	return nil
+	return "", ""
 }
 
+// withVMVersion returns the VM size with the version suffix, e.g. Standard_A1_v2 for Standard_A1.
+func withVMVersion(vmSize string, version int) string {
+	return fmt.Sprintf("%s_v%d", vmVersionRegex.ReplaceAllString(vmSize, ""), version)
+}
+
+var vmVersionRegex = regexp.MustCompile(`_v(\d+)$`)
+
 func getVMVersion(vmSize string) int {
-	re := regexp.MustCompile(`_v(\d+)$`)
-	matches := re.FindStringSubmatch(vmSize)
+	matches := vmVersionRegex.FindStringSubmatch(vmSize)
 	if len(matches) > 1 {
 		v, _ := strconv.Atoi(matches[1])
 		return v
@@ -140,4 +173,4 @@ func stringPtr(s string) *string {
 	return &s
 }
 
-var _ Rule = &latestGenerationVMSizeOptimization{}
+var _ AlternativeRule = &latestGenerationVMSizeOptimization{}
@@ -34,4 +34,16 @@ func (r vmOptimization) Apply(input *schema.CoreResource) *string {
 	return nil
 }
 
-var _ Rule = &vmOptimization{}
+// Alternative returns the Windows virtual machine with Azure Hybrid Benefit, which is priced without the Windows license.
+func (r vmOptimization) Alternative(input *schema.CoreResource) schema.CoreResource {
+	if r.Apply(input) == nil {
+		return nil
+	}
+	resource := (*input).(*azure.VirtualMachine)
+
+	alternative := *resource
+	alternative.LicenseType = "Windows_Server"
+	return &alternative
+}
+
+var _ AlternativeRule = &vmOptimization{}
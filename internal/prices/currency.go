@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package prices
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultCurrency is the currency prices are fetched in when no currency is configured.
+const DefaultCurrency = "USD"
+
+// CurrencyCodeRegex matches ISO 4217 currency codes.
+var CurrencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "CN¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"BRL": "R$",
+	"AUD": "A$",
+	"CAD": "CA$",
+	"NZD": "NZ$",
+	"HKD": "HK$",
+	"SGD": "S$",
+	"MXN": "MX$",
+}
+
+// CurrencySymbol returns the symbol that is printed in front of amounts in the currency, e.g. "€" for EUR.
+// Currencies without a well-known symbol are printed with their code, e.g. "CHF ".
+func CurrencySymbol(currency string) string {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if symbol, ok := currencySymbols[strings.ToUpper(currency)]; ok {
+		return symbol
+	}
+	return strings.ToUpper(currency) + " "
+}
@@ -0,0 +1,420 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package prices
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// PriceSheet holds negotiated prices, e.g. the prices of an Enterprise Agreement or Microsoft Customer Agreement
+// price sheet export. The price of a row overrides the pricing API price of the cost components that it matches.
+type PriceSheet struct {
+	// Currency is the currency of the prices, or empty if the sheet doesn't say.
+	Currency string
+	Rows     []PriceSheetRow
+}
+
+// PriceSheetRow is the negotiated price of a meter. The identifying fields use the names of the Azure retail price
+// attributes, and empty fields match any value.
+type PriceSheetRow struct {
+	MeterID       string
+	SkuID         string
+	ProductName   string
+	SkuName       string
+	MeterName     string
+	ArmSkuName    string
+	Region        string
+	UnitOfMeasure string
+	PriceType     string
+	UnitPrice     decimal.Decimal
+}
+
+// priceSheetColumns maps the normalized column names of the supported price sheet formats to the row fields.
+var priceSheetColumns = map[string]string{
+	"meterid":       "meterId",
+	"skuid":         "skuId",
+	"productname":   "productName",
+	"skuname":       "skuName",
+	"metername":     "meterName",
+	"armskuname":    "armSkuName",
+	"armregionname": "region",
+	"meterregion":   "region",
+	"region":        "region",
+	"unitofmeasure": "unitOfMeasure",
+	"unitprice":     "unitPrice",
+	"retailprice":   "unitPrice",
+	"currencycode":  "currency",
+	"currency":      "currency",
+	"pricetype":     "priceType",
+	"type":          "priceType",
+}
+
+// LoadPriceSheet reads the price sheet at path. Files with a `.json` extension are read as a JSON array of rows,
+// a retail prices API response (`Items`) or a consumption API price sheet (`properties.pricesheets`). Other files are
+// read as CSV with a header row. Column names are matched case-insensitively, ignoring spaces, dashes and
+// underscores, so both `meterName` and `Meter name` are accepted.
+func LoadPriceSheet(path string) (*PriceSheet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []map[string]string
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		records, err = readPriceSheetJSON(f)
+	} else {
+		records, err = readPriceSheetCSV(f)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newPriceSheet(records)
+}
+
+func readPriceSheetJSON(r io.Reader) ([]map[string]string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !gjson.ValidBytes(b) {
+		return nil, fmt.Errorf("invalid JSON")
+	}
+
+	parsed := gjson.ParseBytes(b)
+	items := parsed
+	if !parsed.IsArray() {
+		items = parsed.Get("Items")
+		if !items.Exists() {
+			items = parsed.Get("properties.pricesheets")
+		}
+	}
+	if !items.IsArray() {
+		return nil, fmt.Errorf("expected an array of rows, an Items array or a properties.pricesheets array")
+	}
+
+	records := make([]map[string]string, 0)
+	for _, item := range items.Array() {
+		record := make(map[string]string)
+		item.ForEach(func(key, value gjson.Result) bool {
+			record[key.String()] = value.String()
+			return true
+		})
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func readPriceSheetCSV(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading the header row: %w", err)
+	}
+	// Excel writes a byte order mark at the start of UTF-8 CSV files
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	records := make([]map[string]string, 0)
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := make(map[string]string)
+		for i, value := range values {
+			if i < len(header) {
+				record[header[i]] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func newPriceSheet(records []map[string]string) (*PriceSheet, error) {
+	sheet := &PriceSheet{Rows: make([]PriceSheetRow, 0, len(records))}
+	for i, record := range records {
+		fields := make(map[string]string)
+		for column, value := range record {
+			if field, ok := priceSheetColumns[normalizeColumn(column)]; ok {
+				fields[field] = strings.TrimSpace(value)
+			}
+		}
+
+		unitPrice, err := decimal.NewFromString(fields["unitPrice"])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid unit price %q", i+1, fields["unitPrice"])
+		}
+
+		if currency := strings.ToUpper(fields["currency"]); currency != "" {
+			if sheet.Currency != "" && sheet.Currency != currency {
+				return nil, fmt.Errorf("row %d: the price sheet mixes %s and %s prices", i+1, sheet.Currency, currency)
+			}
+			sheet.Currency = currency
+		}
+
+		sheet.Rows = append(sheet.Rows, PriceSheetRow{
+			MeterID:       fields["meterId"],
+			SkuID:         fields["skuId"],
+			ProductName:   fields["productName"],
+			SkuName:       fields["skuName"],
+			MeterName:     fields["meterName"],
+			ArmSkuName:    fields["armSkuName"],
+			Region:        fields["region"],
+			UnitOfMeasure: fields["unitOfMeasure"],
+			PriceType:     fields["priceType"],
+			UnitPrice:     unitPrice,
+		})
+	}
+	return sheet, nil
+}
+
+// UnknownRegions returns the regions of the rows that are neither an Azure region nor a region of the pricing API,
+// e.g. a misspelled region. Those rows never match a cost component.
+func (s *PriceSheet) UnknownRegions() []string {
+	seen := make(map[string]bool)
+	regions := make([]string, 0)
+	for _, row := range s.Rows {
+		if row.Region == "" || seen[row.Region] || isKnownRegion(normalizeRegion(row.Region)) {
+			continue
+		}
+		seen[row.Region] = true
+		regions = append(regions, row.Region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// UnmatchedRows returns the number of rows that can't match a cost component, because they have no meter name, SKU
+// name or ARM SKU name. Cost components don't look their prices up by meter ID, so rows that are only identified by
+// their meter ID or SKU ID are never used.
+func (s *PriceSheet) UnmatchedRows() int {
+	count := 0
+	for _, row := range s.Rows {
+		if row.MeterName == "" && row.SkuName == "" && row.ArmSkuName == "" {
+			count++
+		}
+	}
+	return count
+}
+
+func normalizeColumn(column string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(strings.TrimSpace(column)))
+}
+
+// Price returns the negotiated price of the cost component, or false if no row matches it. A row matches when it is
+// for the region and purchase option of the component, and every identifying field that is set on both the row and
+// the component's product filter agrees. At least one field other than the product name has to agree, so that rows
+// don't match every meter of a product. If several rows match, the one that agrees on the most fields wins.
+func (s *PriceSheet) Price(cc *schema.CostComponent) (decimal.Decimal, bool) {
+	if cc.ProductFilter == nil {
+		return decimal.Zero, false
+	}
+
+	purchaseOption := "Consumption"
+	if cc.PriceFilter != nil && cc.PriceFilter.PurchaseOption != nil {
+		purchaseOption = *cc.PriceFilter.PurchaseOption
+	}
+	// Reservation and savings plan prices depend on the term, which price sheets don't describe consistently
+	if !strings.EqualFold(purchaseOption, "Consumption") && !strings.EqualFold(purchaseOption, "DevTestConsumption") {
+		return decimal.Zero, false
+	}
+
+	var best *PriceSheetRow
+	bestScore := 0
+	for i := range s.Rows {
+		row := &s.Rows[i]
+		score, ok := row.matches(cc.ProductFilter, purchaseOption)
+		if ok && score > bestScore {
+			best = row
+			bestScore = score
+		}
+	}
+	if best == nil {
+		return decimal.Zero, false
+	}
+
+	price := best.UnitPrice
+	// Price sheets sometimes quote prices per a multiple of the unit of the pricing API, e.g. per 100 hours
+	if cc.PriceFilter != nil && cc.PriceFilter.Unit != nil && best.UnitOfMeasure != "" {
+		rowQuantity, rowOK := unitQuantity(best.UnitOfMeasure)
+		componentQuantity, componentOK := unitQuantity(*cc.PriceFilter.Unit)
+		if rowOK && componentOK && !rowQuantity.Equal(componentQuantity) {
+			price = price.Mul(componentQuantity).Div(rowQuantity)
+		}
+	}
+	return price, true
+}
+
+// matches reports whether the row matches the product filter and purchase option, and the number of identifying
+// fields that agree.
+func (r *PriceSheetRow) matches(filter *schema.ProductFilter, purchaseOption string) (int, bool) {
+	if r.PriceType != "" && !strings.EqualFold(r.PriceType, purchaseOption) {
+		return 0, false
+	}
+
+	if r.Region != "" {
+		if filter.Region == nil || normalizeRegion(*filter.Region) != normalizeRegion(r.Region) {
+			return 0, false
+		}
+	}
+
+	score := 0
+	specific := false
+	for _, field := range []struct {
+		key   string
+		value string
+	}{
+		{"meterId", r.MeterID},
+		{"skuId", r.SkuID},
+		{"skuName", r.SkuName},
+		{"meterName", r.MeterName},
+		{"armSkuName", r.ArmSkuName},
+		{"productName", r.ProductName},
+	} {
+		if field.value == "" {
+			continue
+		}
+
+		attributeFilter := findAttributeFilter(filter, field.key)
+		if attributeFilter == nil && field.key == "skuId" && filter.Sku != nil {
+			attributeFilter = &schema.AttributeFilter{Key: field.key, Value: filter.Sku}
+		}
+		if attributeFilter == nil {
+			continue
+		}
+
+		if !attributeFilterMatches(attributeFilter, field.value) {
+			return 0, false
+		}
+		score++
+		if field.key != "productName" {
+			specific = true
+		}
+	}
+	return score, specific
+}
+
+func findAttributeFilter(filter *schema.ProductFilter, key string) *schema.AttributeFilter {
+	for _, attributeFilter := range filter.AttributeFilters {
+		if strings.EqualFold(attributeFilter.Key, key) {
+			return attributeFilter
+		}
+	}
+	return nil
+}
+
+func attributeFilterMatches(filter *schema.AttributeFilter, value string) bool {
+	if filter.Value != nil {
+		return strings.EqualFold(*filter.Value, value)
+	}
+	if filter.ValueRegex != nil {
+		return valueRegexMatches(*filter.ValueRegex, value)
+	}
+	return false
+}
+
+// valueRegexMatches matches the value against a pricing API regex, e.g. `/^Standard_D2s_v5$/i`. Go regexps don't
+// support lookaheads, so the `^(?!excluded).*$` pattern that cost components use to exclude SKUs is evaluated as the
+// negation of the excluded pattern. Other patterns that Go can't compile don't match.
+func valueRegexMatches(pattern string, value string) bool {
+	body := pattern
+	flags := ""
+	if strings.HasPrefix(pattern, "/") {
+		if end := strings.LastIndex(pattern, "/"); end > 0 {
+			body = pattern[1:end]
+			flags = pattern[end+1:]
+		}
+	}
+	prefix := ""
+	if strings.Contains(flags, "i") {
+		prefix = "(?i)"
+	}
+
+	if strings.HasPrefix(body, "^(?!") {
+		end := closingParen(body, 1)
+		if end < 0 {
+			return false
+		}
+		excluded, err := regexp.Compile(prefix + "^" + body[4:end])
+		if err != nil {
+			return false
+		}
+		rest, err := regexp.Compile(prefix + "^" + body[end+1:])
+		if err != nil {
+			return false
+		}
+		return !excluded.MatchString(value) && rest.MatchString(value)
+	}
+
+	re, err := regexp.Compile(prefix + body)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// closingParen returns the index of the parenthesis that closes the one at open, or -1.
+func closingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+var unitQuantityRegex = regexp.MustCompile(`^\s*([0-9]+(?:\.[0-9]+)?)\s*([KM])?\b`)
+
+// unitQuantity returns the quantity of a unit of measure, e.g. 100 for `100 Hours` and 10000 for `10K`.
+func unitQuantity(unit string) (decimal.Decimal, bool) {
+	match := unitQuantityRegex.FindStringSubmatch(unit)
+	if match == nil {
+		return decimal.Zero, false
+	}
+	quantity, err := decimal.NewFromString(match[1])
+	if err != nil || quantity.IsZero() {
+		return decimal.Zero, false
+	}
+	switch match[2] {
+	case "K":
+		quantity = quantity.Mul(decimal.NewFromInt(1000))
+	case "M":
+		quantity = quantity.Mul(decimal.NewFromInt(1000000))
+	}
+	return quantity, true
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package prices
+
+import (
+	"strings"
+)
+
+// meterRegionNames maps the normalized meter region names of EA and MCA price sheets, e.g. `US East` and `EU West`,
+// to the ARM names of the regions. Display names such as `East US` don't need an entry, since they normalize to the
+// ARM name.
+var meterRegionNames = map[string]string{
+	"useast":         "eastus",
+	"useast2":        "eastus2",
+	"uswest":         "westus",
+	"uswest2":        "westus2",
+	"uswest3":        "westus3",
+	"uscentral":      "centralus",
+	"usnorthcentral": "northcentralus",
+	"ussouthcentral": "southcentralus",
+	"uswestcentral":  "westcentralus",
+	"euwest":         "westeurope",
+	"eunorth":        "northeurope",
+	"apeast":         "eastasia",
+	"apsoutheast":    "southeastasia",
+	"jaeast":         "japaneast",
+	"jawest":         "japanwest",
+	"aueast":         "australiaeast",
+	"ausoutheast":    "australiasoutheast",
+	"aucentral":      "australiacentral",
+	"aucentral2":     "australiacentral2",
+	"brsouth":        "brazilsouth",
+	"brsoutheast":    "brazilsoutheast",
+	"cacentral":      "canadacentral",
+	"caeast":         "canadaeast",
+	"incentral":      "centralindia",
+	"insouth":        "southindia",
+	"inwest":         "westindia",
+	"krcentral":      "koreacentral",
+	"krsouth":        "koreasouth",
+	"frcentral":      "francecentral",
+	"frsouth":        "francesouth",
+	"dewestcentral":  "germanywestcentral",
+	"denorth":        "germanynorth",
+	"chnorth":        "switzerlandnorth",
+	"chwest":         "switzerlandwest",
+	"noeast":         "norwayeast",
+	"nowest":         "norwaywest",
+	"aenorth":        "uaenorth",
+	"aecentral":      "uaecentral",
+	"zanorth":        "southafricanorth",
+	"zawest":         "southafricawest",
+	"secentral":      "swedencentral",
+	"sesouth":        "swedensouth",
+	"plcentral":      "polandcentral",
+	"itnorth":        "italynorth",
+	"qacentral":      "qatarcentral",
+	"ilcentral":      "israelcentral",
+	"escentral":      "spaincentral",
+	"mxcentral":      "mexicocentral",
+	"nznorth":        "newzealandnorth",
+	"usgovaz":        "usgovarizona",
+	"usgovtx":        "usgovtexas",
+}
+
+// armRegionNames are the ARM names of the Azure regions that cost components are priced in.
+var armRegionNames = map[string]bool{
+	"australiacentral": true, "australiacentral2": true, "australiaeast": true, "australiasoutheast": true,
+	"austriaeast": true, "belgiumcentral": true, "brazilsouth": true, "brazilsoutheast": true, "canadacentral": true,
+	"canadaeast": true, "centralindia": true, "centralus": true, "centraluseuap": true, "chilecentral": true,
+	"chinaeast": true, "chinaeast2": true, "chinaeast3": true, "chinanorth": true, "chinanorth2": true,
+	"chinanorth3": true, "eastasia": true, "eastus": true, "eastus2": true, "eastus2euap": true, "francecentral": true,
+	"francesouth": true, "germanynorth": true, "germanywestcentral": true, "indonesiacentral": true,
+	"israelcentral": true, "italynorth": true, "japaneast": true, "japanwest": true, "jioindiacentral": true,
+	"jioindiawest": true, "koreacentral": true, "koreasouth": true, "malaysiawest": true, "mexicocentral": true,
+	"newzealandnorth": true, "northcentralus": true, "northeurope": true, "norwayeast": true, "norwaywest": true,
+	"polandcentral": true, "qatarcentral": true, "southafricanorth": true, "southafricawest": true,
+	"southcentralus": true, "southeastasia": true, "southindia": true, "spaincentral": true, "swedencentral": true,
+	"swedensouth": true, "switzerlandnorth": true, "switzerlandwest": true, "uaecentral": true, "uaenorth": true,
+	"uksouth": true, "ukwest": true, "usdodcentral": true, "usdodeast": true, "usgovarizona": true,
+	"usgovtexas": true, "usgovvirginia": true, "westcentralus": true, "westeurope": true, "westindia": true,
+	"westus": true, "westus2": true, "westus3": true,
+}
+
+// normalizeRegion returns the ARM name of a region given by its ARM name, display name or price sheet meter region
+// name, e.g. `eastus` for `East US` and `US East`. Other names are only lowercased and stripped of spaces.
+func normalizeRegion(region string) string {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(region)), " ", "")
+	if armName, ok := meterRegionNames[normalized]; ok {
+		return armName
+	}
+	return normalized
+}
+
+// isKnownRegion returns whether the normalized region is an Azure region or one of the regions of the pricing API
+// that aren't Azure regions, such as `Global` and the data transfer zones.
+func isKnownRegion(normalized string) bool {
+	if armRegionNames[normalized] {
+		return true
+	}
+	return normalized == "global" || normalized == "intercontinental" || strings.Contains(normalized, "zone")
+}
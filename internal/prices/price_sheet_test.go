@@ -0,0 +1,257 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package prices
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func writePriceSheet(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	return file
+}
+
+func TestLoadPriceSheet_CSV(t *testing.T) {
+	file := writePriceSheet(t, "pricesheet.csv", "\ufeffMeter ID,Meter name,Meter region,Unit of measure,Unit price,Currency code,Price type\n"+
+		"6f8a1f5e-0000-0000-0000-000000000000,D2s v5,eastus,100 Hours,7.68,usd,Consumption\n")
+
+	sheet, err := LoadPriceSheet(file)
+	require.NoError(t, err)
+	assert.Equal(t, "USD", sheet.Currency)
+	require.Len(t, sheet.Rows, 1)
+	assert.Equal(t, "6f8a1f5e-0000-0000-0000-000000000000", sheet.Rows[0].MeterID)
+	assert.Equal(t, "D2s v5", sheet.Rows[0].MeterName)
+	assert.Equal(t, "eastus", sheet.Rows[0].Region)
+	assert.Equal(t, "100 Hours", sheet.Rows[0].UnitOfMeasure)
+	assert.Equal(t, "Consumption", sheet.Rows[0].PriceType)
+	assert.Equal(t, "7.68", sheet.Rows[0].UnitPrice.String())
+}
+
+func TestLoadPriceSheet_JSON(t *testing.T) {
+	file := writePriceSheet(t, "pricesheet.json", `{"Items":[{"currencyCode":"EUR","retailPrice":0.0824,"armRegionName":"westeurope","productName":"Virtual Machines Dsv5 Series","skuName":"D2s v5","meterName":"D2s v5","armSkuName":"Standard_D2s_v5","unitOfMeasure":"1 Hour","type":"Consumption"}]}`)
+
+	sheet, err := LoadPriceSheet(file)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", sheet.Currency)
+	assert.Equal(t, []PriceSheetRow{
+		{
+			ProductName:   "Virtual Machines Dsv5 Series",
+			SkuName:       "D2s v5",
+			MeterName:     "D2s v5",
+			ArmSkuName:    "Standard_D2s_v5",
+			Region:        "westeurope",
+			UnitOfMeasure: "1 Hour",
+			PriceType:     "Consumption",
+			UnitPrice:     decimal.RequireFromString("0.0824"),
+		},
+	}, sheet.Rows)
+}
+
+func TestLoadPriceSheet_Errors(t *testing.T) {
+	_, err := LoadPriceSheet(writePriceSheet(t, "mixed.csv", "meterName,unitPrice,currencyCode\nD2s v5,0.08,USD\nD4s v5,0.15,EUR\n"))
+	assert.ErrorContains(t, err, "mixes USD and EUR prices")
+
+	_, err = LoadPriceSheet(writePriceSheet(t, "price.csv", "meterName,unitPrice\nD2s v5,n/a\n"))
+	assert.ErrorContains(t, err, `row 1: invalid unit price "n/a"`)
+
+	_, err = LoadPriceSheet(writePriceSheet(t, "object.json", `{"meterName":"D2s v5"}`))
+	assert.Error(t, err)
+}
+
+func TestPriceSheet_Price(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	vm := func(purchaseOption string) *schema.CostComponent {
+		return &schema.CostComponent{
+			Name: "Instance usage (Linux, pay as you go, Standard_D2s_v5)",
+			ProductFilter: &schema.ProductFilter{
+				VendorName: strPtr("azure"),
+				Region:     strPtr("eastus"),
+				Service:    strPtr("Virtual Machines"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "productName", ValueRegex: strPtr("/Virtual Machines .* Series$/i")},
+					{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+					{Key: "armSkuName", ValueRegex: strPtr("/^Standard_D2s_v5$/i")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{PurchaseOption: strPtr(purchaseOption), Unit: strPtr("1 Hour")},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		rows     []PriceSheetRow
+		cc       *schema.CostComponent
+		expected string
+	}{
+		{
+			name:     "matching sku",
+			rows:     []PriceSheetRow{{SkuName: "D2s v5", ArmSkuName: "Standard_D2s_v5", Region: "East US", UnitPrice: decimal.RequireFromString("0.08")}},
+			cc:       vm("Consumption"),
+			expected: "0.08",
+		},
+		{
+			name: "spot sku",
+			rows: []PriceSheetRow{{SkuName: "D2s v5 Spot", ArmSkuName: "Standard_D2s_v5", UnitPrice: decimal.RequireFromString("0.02")}},
+			cc:   vm("Consumption"),
+		},
+		{
+			name:     "meter region name",
+			rows:     []PriceSheetRow{{ArmSkuName: "Standard_D2s_v5", Region: "US East", UnitPrice: decimal.RequireFromString("0.08")}},
+			cc:       vm("Consumption"),
+			expected: "0.08",
+		},
+		{
+			name: "other meter region name",
+			rows: []PriceSheetRow{{ArmSkuName: "Standard_D2s_v5", Region: "EU West", UnitPrice: decimal.RequireFromString("0.08")}},
+			cc:   vm("Consumption"),
+		},
+		{
+			name: "meter id only",
+			rows: []PriceSheetRow{{MeterID: "5c0c4cc8-0e58-4ed2-8b2e-6d1f5bd2e2b1", Region: "eastus", UnitPrice: decimal.RequireFromString("0.08")}},
+			cc:   vm("Consumption"),
+		},
+		{
+			name: "other region",
+			rows: []PriceSheetRow{{ArmSkuName: "Standard_D2s_v5", Region: "westeurope", UnitPrice: decimal.RequireFromString("0.08")}},
+			cc:   vm("Consumption"),
+		},
+		{
+			name: "product name only",
+			rows: []PriceSheetRow{{ProductName: "Virtual Machines Dsv5 Series", UnitPrice: decimal.RequireFromString("0.08")}},
+			cc:   vm("Consumption"),
+		},
+		{
+			name: "reservation",
+			rows: []PriceSheetRow{{ArmSkuName: "Standard_D2s_v5", UnitPrice: decimal.RequireFromString("0.08")}},
+			cc:   vm("Reservation"),
+		},
+		{
+			name: "other price type",
+			rows: []PriceSheetRow{{ArmSkuName: "Standard_D2s_v5", PriceType: "DevTestConsumption", UnitPrice: decimal.RequireFromString("0.05")}},
+			cc:   vm("Consumption"),
+		},
+		{
+			name:     "per 100 hours",
+			rows:     []PriceSheetRow{{ArmSkuName: "Standard_D2s_v5", UnitOfMeasure: "100 Hours", UnitPrice: decimal.RequireFromString("8")}},
+			cc:       vm("Consumption"),
+			expected: "0.08",
+		},
+		{
+			name: "most specific row",
+			rows: []PriceSheetRow{
+				{ArmSkuName: "Standard_D2s_v5", UnitPrice: decimal.RequireFromString("0.09")},
+				{ArmSkuName: "Standard_D2s_v5", SkuName: "D2s v5", Region: "eastus", UnitPrice: decimal.RequireFromString("0.08")},
+			},
+			cc:       vm("Consumption"),
+			expected: "0.08",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet := &PriceSheet{Rows: tt.rows}
+			price, ok := sheet.Price(tt.cc)
+			if tt.expected == "" {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, price.String())
+		})
+	}
+}
+
+func TestPriceFetcher_PriceSheet(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	ipFilter := &schema.ProductFilter{
+		VendorName: strPtr("azure"),
+		Region:     strPtr("eastus"),
+		Service:    strPtr("Virtual Network"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "skuName", Value: strPtr("Standard")},
+			{Key: "meterName", Value: strPtr("Standard IPv4 Static Public IP")},
+		},
+	}
+	gatewayFilter := &schema.ProductFilter{
+		VendorName: strPtr("azure"),
+		Region:     strPtr("eastus"),
+		Service:    strPtr("NAT Gateway"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "meterName", Value: strPtr("Gateway")},
+		},
+	}
+
+	snapshot := apiclient.NewPriceSnapshot("USD")
+	snapshot.AddFilters(ipFilter, nil, gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.005"}]}]}}`))
+	snapshot.AddFilters(gatewayFilter, nil, gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"b","USD":"0.045"}]}]}}`))
+
+	p := NewPriceFetcher("http://127.0.0.1:1", "")
+	p.UseSnapshot(snapshot)
+	p.UsePriceSheet(&PriceSheet{Rows: []PriceSheetRow{
+		{MeterName: "Standard IPv4 Static Public IP", Region: "eastus", UnitPrice: decimal.RequireFromString("0.004")},
+		{MeterName: "Gateway", Region: "eastus", UnitPrice: decimal.RequireFromString("0.04")},
+	}})
+
+	free := decimal.Zero
+	gateway := &schema.CostComponent{Name: "NAT gateway", ProductFilter: gatewayFilter}
+	gateway.SetCustomPrice(&free)
+	res := &schema.Resource{
+		Name: "azurerm_public_ip.example",
+		CostComponents: []*schema.CostComponent{
+			{Name: "IP address", ProductFilter: ipFilter},
+			gateway,
+		},
+	}
+	require.NoError(t, p.PopulatePrices([]*schema.Resource{res}))
+
+	assert.Equal(t, "0.004", res.CostComponents[0].Price().String())
+	assert.Equal(t, schema.PriceSourcePriceSheet, res.CostComponents[0].PriceSource)
+
+	// Custom prices of the resource itself are kept
+	assert.Equal(t, "0", res.CostComponents[1].Price().String())
+	assert.Empty(t, res.CostComponents[1].PriceSource)
+}
+
+func TestNormalizeRegion(t *testing.T) {
+	assert.Equal(t, "eastus", normalizeRegion("eastus"))
+	assert.Equal(t, "eastus", normalizeRegion("East US"))
+	assert.Equal(t, "eastus", normalizeRegion("US East"))
+	assert.Equal(t, "westeurope", normalizeRegion("EU West"))
+	assert.Equal(t, "germanywestcentral", normalizeRegion("DE West Central"))
+	assert.Equal(t, "zone1", normalizeRegion("Zone 1"))
+}
+
+func TestPriceSheet_Warnings(t *testing.T) {
+	sheet := &PriceSheet{Rows: []PriceSheetRow{
+		{MeterName: "D2s v5", Region: "US East"},
+		{MeterName: "D2s v5", Region: "West Europe"},
+		{MeterName: "Standard Data Transfer Out", Region: "Zone 1"},
+		{MeterName: "D2s v5", Region: "US Esat"},
+		{MeterName: "D2s v5", Region: "US Esat"},
+		{MeterName: "D2s v5", Region: "Mars North"},
+		{MeterID: "5c0c4cc8-0e58-4ed2-8b2e-6d1f5bd2e2b1", Region: "eastus"},
+		{SkuID: "DZH318Z0BQ4L/0009", ProductName: "Virtual Machines Dsv5 Series"},
+	}}
+
+	assert.Equal(t, []string{"Mars North", "US Esat"}, sheet.UnknownRegions())
+	assert.Equal(t, 2, sheet.UnmatchedRows())
+}
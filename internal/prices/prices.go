@@ -47,21 +47,66 @@ type PriceFetcher struct {
 	components        map[string]int
 	mux               *sync.RWMutex
 	client            *apiclient.PricingAPIClient
+	currency          string
 	warnOnPriceErrors bool
+	// Note-plancost: priceSheet holds the negotiated prices that override the pricing API prices
+	priceSheet *PriceSheet
 }
 
 func NewPriceFetcher(endpoint string, apikey string) *PriceFetcher {
 	return &PriceFetcher{
-		resources:  make(map[string]*notFoundData),
-		components: make(map[string]int),
-		mux:        &sync.RWMutex{},
-		client: &apiclient.PricingAPIClient{
-			APIClient: *apiclient.NewAPIClient(endpoint, apikey),
-		},
+		resources:         make(map[string]*notFoundData),
+		components:        make(map[string]int),
+		mux:               &sync.RWMutex{},
+		client:            apiclient.NewPricingAPIClient(endpoint, apikey),
+		currency:          DefaultCurrency,
 		warnOnPriceErrors: true,
 	}
 }
 
+// SetCurrency sets the ISO 4217 code of the currency that prices are fetched in.
+func (p *PriceFetcher) SetCurrency(currency string) {
+	p.currency = currency
+}
+
+// Currency returns the ISO 4217 code of the currency that prices are fetched in.
+func (p *PriceFetcher) Currency() string {
+	return p.currency
+}
+
+// UseSnapshot makes the PriceFetcher resolve prices from the given snapshot instead of the pricing API.
+func (p *PriceFetcher) UseSnapshot(snapshot *apiclient.PriceSnapshot) {
+	p.client.Snapshot = snapshot
+}
+
+// UsePriceSheet makes the PriceFetcher price the cost components that match a row of the price sheet at the
+// negotiated price of the row, instead of the pricing API price.
+func (p *PriceFetcher) UsePriceSheet(sheet *PriceSheet) {
+	p.priceSheet = sheet
+}
+
+// UseDiskCache makes the PriceFetcher keep pricing API results in the given disk cache, so they can be reused
+// by later runs.
+func (p *PriceFetcher) UseDiskCache(cache *apiclient.DiskCache) {
+	p.client.DiskCache = cache
+}
+
+// ExportSnapshot writes a price snapshot with the prices of all cost components of the resources to path.
+// The snapshot can be used later to estimate the same resources without access to the pricing API.
+func (p *PriceFetcher) ExportSnapshot(resources []*schema.Resource, path string) error {
+	snapshot := apiclient.NewPriceSnapshot(p.currency)
+	for _, req := range p.client.BatchRequests(resources, batchSize, p.currency) {
+		results, err := p.client.PerformRequest(req)
+		if err != nil {
+			return err
+		}
+		for _, r := range results {
+			snapshot.Add(r.Query, r.Result)
+		}
+	}
+	return snapshot.Write(path)
+}
+
 // addNotFoundResult adds an instance of a missing price to the aggregator.
 func (p *PriceFetcher) addNotFoundResult(result apiclient.PriceQueryResult) {
 	p.mux.Lock()
@@ -200,6 +245,10 @@ func (p *PriceFetcher) LogWarnings() {
 }
 
 func (p *PriceFetcher) PopulatePrices(resources []*schema.Resource) error {
+	// Note-plancost: the negotiated prices are set as custom prices, which setCostComponentPrice prefers
+	if p.priceSheet != nil {
+		p.applyPriceSheet(resources)
+	}
 
 	err := p.getPricesConcurrent(resources)
 	if err != nil {
@@ -208,6 +257,27 @@ func (p *PriceFetcher) PopulatePrices(resources []*schema.Resource) error {
 	return nil
 }
 
+// applyPriceSheet sets the negotiated prices of the price sheet as the custom prices of the matching cost components.
+// Custom prices that resources set themselves, e.g. for free tiers, are kept.
+func (p *PriceFetcher) applyPriceSheet(resources []*schema.Resource) {
+	for _, res := range resources {
+		costComponents := append([]*schema.CostComponent{}, res.CostComponents...)
+		for _, subRes := range res.FlattenedSubResources() {
+			costComponents = append(costComponents, subRes.CostComponents...)
+		}
+
+		for _, cc := range costComponents {
+			if cc.CustomPrice() != nil && cc.PriceSource != schema.PriceSourcePriceSheet {
+				continue
+			}
+			if price, ok := p.priceSheet.Price(cc); ok {
+				cc.SetCustomPrice(&price)
+				cc.PriceSource = schema.PriceSourcePriceSheet
+			}
+		}
+	}
+}
+
 // getPricesConcurrent gets the prices of all resources concurrently.
 // Concurrency level is calculated using the following formula:
 // min(max(4, numCPU * 4), 16)
@@ -222,7 +292,7 @@ func (p *PriceFetcher) getPricesConcurrent(resources []*schema.Resource) error {
 		numWorkers = 16
 	}
 
-	reqs := p.client.BatchRequests(resources, batchSize, "USD")
+	reqs := p.client.BatchRequests(resources, batchSize, p.currency)
 
 	numJobs := len(reqs)
 	jobs := make(chan apiclient.BatchRequest, numJobs)
@@ -288,14 +358,24 @@ type productPrice struct {
 }
 
 func (p *PriceFetcher) setCostComponentPrice(result apiclient.PriceQueryResult) {
-	currency := "USD"
+	currency := p.currency
 
 	if result.CostComponent.CustomPrice() != nil {
 		logging.Logger.Debug().Msgf("Using user-defined custom price %v for %s %s.", *result.CostComponent.CustomPrice(), result.Resource.Name, result.CostComponent.Name)
 		result.CostComponent.SetPrice(*result.CostComponent.CustomPrice())
+		// Note-plancost: record where the custom price comes from
+		reason := schema.PriceReasonCustomPrice
+		if result.CostComponent.PriceSource == schema.PriceSourcePriceSheet {
+			reason = schema.PriceReasonPriceSheet
+		}
+		result.CostComponent.PriceLookup = &schema.PriceLookup{Reason: reason}
 		return
 	}
 
+	// Note-plancost: the lookup is recorded so that the price of the component can be explained
+	lookup := &schema.PriceLookup{StaleFetchedAt: result.StaleFetchedAt}
+	result.CostComponent.PriceLookup = lookup
+
 	products := result.Result.Get("data.products").Array()
 	if len(products) == 0 {
 		if result.CostComponent.IgnoreIfMissingPrice {
@@ -305,6 +385,7 @@ func (p *PriceFetcher) setCostComponentPrice(result apiclient.PriceQueryResult)
 		}
 		p.logPriceLookupErr(result.CostComponent, "No products found for %s %s", result.Resource.Name, result.CostComponent.Name)
 
+		lookup.Reason = schema.PriceReasonNoProducts
 		p.addNotFoundResult(result)
 		return
 	}
@@ -376,6 +457,7 @@ func (p *PriceFetcher) setCostComponentPrice(result apiclient.PriceQueryResult)
 		}
 		p.logPriceLookupErr(result.CostComponent, "No prices found for %s %s", result.Resource.Name, result.CostComponent.Name)
 
+		lookup.Reason = schema.PriceReasonNoPrices
 		p.addNotFoundResult(result)
 		return
 	}
@@ -392,6 +474,22 @@ func (p *PriceFetcher) setCostComponentPrice(result apiclient.PriceQueryResult)
 		}
 	}
 
+	for _, prices := range productPrices {
+		lookupPrices := make([]schema.LookupPrice, 0, len(prices))
+		for _, price := range prices {
+			lookupPrices = append(lookupPrices, schema.LookupPrice{Hash: price.Hash, Price: price.Price})
+		}
+		lookup.Products = append(lookup.Products, lookupPrices)
+	}
+	switch {
+	case len(distinctPrices) > 1:
+		lookup.Reason = schema.PriceReasonSmallestNonZero
+	case len(productPrices) > 1 || len(productPrices[0]) > 1:
+		lookup.Reason = schema.PriceReasonSamePrice
+	default:
+		lookup.Reason = schema.PriceReasonOnlyPrice
+	}
+
 	result.CostComponent.SetPrice(productPrices[0][0].Price)
 	result.CostComponent.SetPriceHash(productPrices[0][0].Hash)
 }
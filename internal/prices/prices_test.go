@@ -15,10 +15,15 @@
 package prices
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
 	"github.com/plancost/terraform-provider-plancost/internal/schema"
@@ -75,3 +80,123 @@ func Test_notFound_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestPriceFetcher_Snapshot(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	ipFilter := &schema.ProductFilter{
+		VendorName: strPtr("azure"),
+		Region:     strPtr("eastus"),
+		Service:    strPtr("Virtual Network"),
+		AttributeFilters: []*schema.AttributeFilter{
+			{Key: "skuName", Value: strPtr("Standard")},
+		},
+	}
+	priceFilter := &schema.PriceFilter{PurchaseOption: strPtr("Consumption")}
+
+	snapshotFile := filepath.Join(t.TempDir(), "prices.json")
+	snapshot := map[string]interface{}{
+		"version":  apiclient.PriceSnapshotVersion,
+		"currency": "USD",
+		"entries": []interface{}{
+			map[string]interface{}{
+				"productFilter": ipFilter,
+				"priceFilter":   priceFilter,
+				"result":        json.RawMessage(`{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0"},{"priceHash":"b","USD":"0.5"}]},{"prices":[{"priceHash":"c","USD":"0.3"}]}]}}`),
+			},
+		},
+	}
+	b, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(snapshotFile, b, 0644))
+
+	newResource := func() *schema.Resource {
+		return &schema.Resource{
+			Name:         "azurerm_public_ip.example",
+			ResourceType: "azurerm_public_ip",
+			CostComponents: []*schema.CostComponent{
+				{Name: "IP address", ProductFilter: ipFilter, PriceFilter: priceFilter},
+				{Name: "Unknown", ProductFilter: &schema.ProductFilter{VendorName: strPtr("azure"), Service: strPtr("Unknown")}},
+			},
+		}
+	}
+
+	loaded, err := apiclient.LoadPriceSnapshot(snapshotFile)
+	require.NoError(t, err)
+
+	// The endpoint is unreachable, so every price has to come from the snapshot.
+	p := NewPriceFetcher("http://127.0.0.1:1", "")
+	p.UseSnapshot(loaded)
+
+	res := newResource()
+	require.NoError(t, p.PopulatePrices([]*schema.Resource{res}))
+	assert.Equal(t, "0.3", res.CostComponents[0].Price().String())
+	assert.Equal(t, "c", res.CostComponents[0].PriceHash())
+	assert.Equal(t, 1, p.MissingPricesLen())
+
+	// The lookup records the prices of every product, with the smallest non-zero price first.
+	price := func(hash, price string) schema.LookupPrice {
+		return schema.LookupPrice{Hash: hash, Price: decimal.RequireFromString(price)}
+	}
+	assert.Equal(t, &schema.PriceLookup{
+		Products: [][]schema.LookupPrice{{price("c", "0.3")}, {price("b", "0.5"), price("a", "0")}},
+		Reason:   schema.PriceReasonSmallestNonZero,
+	}, res.CostComponents[0].PriceLookup)
+	assert.Equal(t, &schema.PriceLookup{Reason: schema.PriceReasonNoProducts}, res.CostComponents[1].PriceLookup)
+
+	// An exported snapshot resolves the same prices.
+	exportFile := filepath.Join(t.TempDir(), "prices.json.gz")
+	require.NoError(t, p.ExportSnapshot([]*schema.Resource{newResource()}, exportFile))
+
+	exported, err := apiclient.LoadPriceSnapshot(exportFile)
+	require.NoError(t, err)
+	assert.Equal(t, 2, exported.Len())
+
+	p = NewPriceFetcher("http://127.0.0.1:1", "")
+	p.UseSnapshot(exported)
+
+	res = newResource()
+	require.NoError(t, p.PopulatePrices([]*schema.Resource{res}))
+	assert.Equal(t, "0.3", res.CostComponents[0].Price().String())
+}
+
+func TestLoadPriceSnapshot_UnsupportedVersion(t *testing.T) {
+	snapshotFile := filepath.Join(t.TempDir(), "prices.json")
+	require.NoError(t, os.WriteFile(snapshotFile, []byte(`{"version":99,"entries":[]}`), 0644))
+
+	_, err := apiclient.LoadPriceSnapshot(snapshotFile)
+	assert.Error(t, err)
+}
+
+func TestPriceFetcher_Currency(t *testing.T) {
+	vendor := "azure"
+	filter := &schema.ProductFilter{VendorName: &vendor}
+
+	snapshotFile := filepath.Join(t.TempDir(), "prices.json")
+	b, err := json.Marshal(map[string]interface{}{
+		"version":  apiclient.PriceSnapshotVersion,
+		"currency": "EUR",
+		"entries": []interface{}{
+			map[string]interface{}{
+				"productFilter": filter,
+				"result":        json.RawMessage(`{"data":{"products":[{"prices":[{"priceHash":"a","EUR":"0.0042"}]}]}}`),
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(snapshotFile, b, 0644))
+
+	snapshot, err := apiclient.LoadPriceSnapshot(snapshotFile)
+	require.NoError(t, err)
+
+	p := NewPriceFetcher("http://127.0.0.1:1", "")
+	p.SetCurrency("EUR")
+	p.UseSnapshot(snapshot)
+
+	res := &schema.Resource{
+		Name:           "azurerm_public_ip.example",
+		CostComponents: []*schema.CostComponent{{Name: "IP address", ProductFilter: filter}},
+	}
+	require.NoError(t, p.PopulatePrices([]*schema.Resource{res}))
+	assert.Equal(t, "0.0042", res.CostComponents[0].Price().String())
+}
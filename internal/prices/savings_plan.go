@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package prices
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// SavingsPlanPrices returns the hourly savings plan prices of the cost components of the resources by the term of the
+// savings plan, e.g. "1 Year". Savings plan prices are nested in the consumption prices of a product, so the cost
+// components are looked up with their own filters, and the savings plan prices of the consumption price that the
+// component is priced at are used. Components without savings plan prices are omitted.
+func (p *PriceFetcher) SavingsPlanPrices(resources []*schema.Resource) (map[*schema.CostComponent]map[string]decimal.Decimal, error) {
+	savingsPlanPrices := make(map[*schema.CostComponent]map[string]decimal.Decimal)
+	for _, req := range p.client.BatchSavingsPlanRequests(resources, batchSize, p.currency) {
+		results, err := p.client.PerformRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			if termPrices := savingsPlanTermPrices(r.Result, r.CostComponent.PriceHash(), p.currency); len(termPrices) > 0 {
+				savingsPlanPrices[r.CostComponent] = termPrices
+			}
+		}
+	}
+	return savingsPlanPrices, nil
+}
+
+// savingsPlanTermPrices returns the non-zero savings plan prices by term of the consumption price with the price hash,
+// or of the first consumption price with savings plan prices if none has the price hash.
+func savingsPlanTermPrices(result gjson.Result, priceHash string, currency string) map[string]decimal.Decimal {
+	var first map[string]decimal.Decimal
+	for _, product := range result.Get("data.products").Array() {
+		for _, price := range product.Get("prices").Array() {
+			termPrices := make(map[string]decimal.Decimal)
+			for _, savingsPlan := range price.Get("savingsPlan").Array() {
+				d, err := decimal.NewFromString(savingsPlan.Get(currency).String())
+				if err != nil || d.IsZero() {
+					continue
+				}
+				termPrices[savingsPlan.Get("term").String()] = d
+			}
+			if len(termPrices) == 0 {
+				continue
+			}
+			if price.Get("priceHash").String() == priceHash {
+				return termPrices
+			}
+			if first == nil {
+				first = termPrices
+			}
+		}
+	}
+	return first
+}
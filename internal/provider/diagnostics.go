@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+// maxMissingPriceComponents is the number of cost components that are named in the missing prices warning.
+const maxMissingPriceComponents = 10
+
+// ProjectDiagnostics returns the errors and warnings recorded while parsing a project as Terraform diagnostics.
+// A project with errors is missing some or all of its resources, so the errors fail the estimate rather than
+// reporting a cost that is too low.
+func ProjectDiagnostics(metadata *tfschema.ProjectMetadata) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if metadata == nil {
+		return diags
+	}
+
+	for _, d := range metadata.Errors {
+		diags.AddError("Terraform Parsing Error", projectDiagDetail(d))
+	}
+	for _, d := range metadata.Warnings {
+		diags.AddWarning("Terraform Parsing Warning", projectDiagDetail(d))
+	}
+	return diags
+}
+
+// projectDiagDetail describes a project diag, listing the source ranges of the HCL diagnostics that caused it, e.g.
+// `- /src/main.tf:3,1-5: Unsupported block type. Blocks of type "resources" are not expected here.`
+func projectDiagDetail(d *tfschema.ProjectDiag) string {
+	message := d.FriendlyMessage
+	if message == "" {
+		message = d.Message
+	}
+
+	var hclDiags hcl.Diagnostics
+	if !errors.As(d, &hclDiags) || len(hclDiags) == 0 {
+		return message
+	}
+
+	lines := make([]string, 0, len(hclDiags)+1)
+	if d.FriendlyMessage != "" {
+		lines = append(lines, d.FriendlyMessage, "")
+	}
+	for _, hclDiag := range hclDiags {
+		line := hclDiag.Summary
+		if hclDiag.Detail != "" {
+			line = fmt.Sprintf("%s. %s", hclDiag.Summary, hclDiag.Detail)
+		}
+		if hclDiag.Subject != nil {
+			line = fmt.Sprintf("%s: %s", hclDiag.Subject.String(), line)
+		}
+		lines = append(lines, "- "+line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MissingPriceDiagnostics warns about the cost components of the priced resources that no price was found for.
+// These components are priced at zero, so the estimate is lower than the actual cost.
+func MissingPriceDiagnostics(project string, resources []*tfschema.Resource, currency string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var missing []string
+	for _, r := range resources {
+		address := r.Name
+		if project != "" {
+			address = project + ":" + r.Name
+		}
+		missing = append(missing, missingPriceComponents(address, r)...)
+	}
+	if len(missing) == 0 {
+		return diags
+	}
+
+	if len(missing) > maxMissingPriceComponents {
+		missing = append(missing[:maxMissingPriceComponents], fmt.Sprintf("and %d more", len(missing)-maxMissingPriceComponents))
+	}
+	diags.AddWarning(
+		"Missing Prices",
+		fmt.Sprintf("No prices were found for these cost components, so they are estimated at %s and the estimate is lower than the actual cost: %s.",
+			formatCost(0, currency), strings.Join(missing, ", ")),
+	)
+	return diags
+}
+
+// missingPriceComponents returns the components of the resource and its sub-resources without a price, e.g.
+// `azurerm_linux_virtual_machine.app.os_disk (Storage (S4, LRS))`.
+func missingPriceComponents(address string, r *tfschema.Resource) []string {
+	var missing []string
+	for _, c := range r.CostComponents {
+		if c.PriceNotFound {
+			missing = append(missing, fmt.Sprintf("%s (%s)", address, c.Name))
+		}
+	}
+	for _, sub := range r.SubResources {
+		missing = append(missing, missingPriceComponents(address+"."+sub.Name, sub)...)
+	}
+	return missing
+}
+
+// StalePriceDiagnostics warns if the priced resources have cost components that were priced with expired prices of
+// the price cache, because the pricing service couldn't be reached.
+func StalePriceDiagnostics(resources []*tfschema.Resource) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	count, oldest := stalePriceComponents(&tfschema.Resource{SubResources: resources})
+	if count == 0 {
+		return diags
+	}
+
+	diags.AddWarning(
+		"Expired Prices",
+		fmt.Sprintf("The pricing service couldn't be reached, so %d cost components are priced with expired prices of the price cache, fetched as early as %s. "+
+			"Expired prices are used for up to %d days after they were fetched.",
+			count, oldest.UTC().Format(time.RFC3339), int(apiclient.MaxStalePriceAge.Hours()/24)),
+	)
+	return diags
+}
+
+// stalePriceComponents returns the number of components of the resource and its sub-resources that were priced with
+// expired prices, and when the oldest of those prices was fetched.
+func stalePriceComponents(r *tfschema.Resource) (int, time.Time) {
+	var count int
+	var oldest time.Time
+	add := func(n int, fetchedAt time.Time) {
+		count += n
+		if n > 0 && (oldest.IsZero() || fetchedAt.Before(oldest)) {
+			oldest = fetchedAt
+		}
+	}
+	for _, c := range r.CostComponents {
+		if c.PriceLookup != nil && !c.PriceLookup.StaleFetchedAt.IsZero() {
+			add(1, c.PriceLookup.StaleFetchedAt)
+		}
+	}
+	for _, sub := range r.SubResources {
+		add(stalePriceComponents(sub))
+	}
+	return count, oldest
+}
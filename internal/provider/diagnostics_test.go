@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestParseEstimateProjectDiagnostics(t *testing.T) {
+	wd, _ := os.Getwd()
+	dir := path.Join(wd, "testdata", "diagnostics")
+
+	resources, _, _, diags := parseEstimateProject(estimateProject{WorkingDirectory: dir}, tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.False(t, diags.HasError())
+	require.Len(t, resources, 1)
+
+	warnings := diags.Warnings()
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "Terraform Parsing Warning", warnings[0].Summary())
+	assert.Equal(t, "Input values were not provided for following Terraform variables: \"variable.vm_size\". Set them in `var_file`, terraform.tfvars or TF_VAR_ environment variables.\n\n"+
+		"- "+path.Join(dir, "main.tf")+":5,1-19: Missing Terraform variable. No value was provided for variable \"vm_size\", so the resources that use it may be priced with a placeholder value. Resources: azurerm_linux_virtual_machine.app.",
+		warnings[0].Detail())
+	assert.Equal(t, "Some Terraform files could not be parsed and were skipped, so their resources are missing from the estimate.\n\n"+
+		"- "+path.Join(dir, "broken.tf")+":1,36-37: Unclosed configuration block. There is no closing brace for this block before the end of the file. This may be caused by incorrect brace nesting elsewhere in this file.",
+		warnings[1].Detail())
+}
+
+func TestParseEstimateProjectDiagnostics_EvaluationFailure(t *testing.T) {
+	resources, _, _, diags := parseEstimateProject(estimateProject{WorkingDirectory: t.TempDir()}, tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	assert.Empty(t, resources)
+	if assert.Len(t, diags.Errors(), 1) {
+		assert.Equal(t, "Terraform Parsing Error", diags.Errors()[0].Summary())
+		assert.Equal(t, "no valid terraform files found given path, try a different directory", diags.Errors()[0].Detail())
+	}
+
+	_, _, _, diags = parseEstimateProject(estimateProject{PlanJSONFile: path.Join(t.TempDir(), "tfplan.json")}, tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	if assert.Len(t, diags.Errors(), 1) {
+		assert.Equal(t, "Module Calculation Error", diags.Errors()[0].Summary())
+	}
+}
+
+func TestMissingPriceDiagnostics(t *testing.T) {
+	missing := &tfschema.CostComponent{Name: "Instance usage (Linux, pay as you go, Standard_D2s_v5)"}
+	missing.SetPriceNotFound()
+	missingDisk := &tfschema.CostComponent{Name: "Storage (S4, LRS)"}
+	missingDisk.SetPriceNotFound()
+
+	resources := []*tfschema.Resource{
+		{
+			Name:           "azurerm_linux_virtual_machine.app",
+			CostComponents: []*tfschema.CostComponent{missing},
+			SubResources: []*tfschema.Resource{
+				{Name: "os_disk", CostComponents: []*tfschema.CostComponent{missingDisk}},
+			},
+		},
+		{
+			Name:           "azurerm_public_ip.web",
+			CostComponents: []*tfschema.CostComponent{{Name: "IP address (static, regional)"}},
+		},
+	}
+
+	assert.Empty(t, MissingPriceDiagnostics("", resources[1:], "USD"))
+
+	diags := MissingPriceDiagnostics("prod", resources, "EUR")
+	if assert.Len(t, diags.Warnings(), 1) {
+		assert.Equal(t, "Missing Prices", diags.Warnings()[0].Summary())
+		assert.Equal(t, "No prices were found for these cost components, so they are estimated at €0.00 and the estimate is lower than the actual cost: "+
+			"prod:azurerm_linux_virtual_machine.app (Instance usage (Linux, pay as you go, Standard_D2s_v5)), prod:azurerm_linux_virtual_machine.app.os_disk (Storage (S4, LRS)).",
+			diags.Warnings()[0].Detail())
+	}
+}
+
+func TestStalePriceDiagnostics(t *testing.T) {
+	fetchedAt := time.Date(2026, 10, 12, 8, 0, 0, 0, time.UTC)
+	current := &tfschema.CostComponent{Name: "IP address (static, regional)", PriceLookup: &tfschema.PriceLookup{}}
+	stale := &tfschema.CostComponent{Name: "Instance usage (Linux, pay as you go, Standard_D2s_v5)", PriceLookup: &tfschema.PriceLookup{StaleFetchedAt: fetchedAt.Add(time.Hour)}}
+	staleDisk := &tfschema.CostComponent{Name: "Storage (S4, LRS)", PriceLookup: &tfschema.PriceLookup{StaleFetchedAt: fetchedAt}}
+
+	resources := []*tfschema.Resource{
+		{Name: "azurerm_public_ip.web", CostComponents: []*tfschema.CostComponent{current}},
+		{
+			Name:           "azurerm_linux_virtual_machine.app",
+			CostComponents: []*tfschema.CostComponent{stale},
+			SubResources: []*tfschema.Resource{
+				{Name: "os_disk", CostComponents: []*tfschema.CostComponent{staleDisk}},
+			},
+		},
+	}
+
+	assert.Empty(t, StalePriceDiagnostics(resources[:1]))
+
+	diags := StalePriceDiagnostics(resources)
+	if assert.Len(t, diags.Warnings(), 1) {
+		assert.Equal(t, "Expired Prices", diags.Warnings()[0].Summary())
+		assert.Equal(t, "The pricing service couldn't be reached, so 2 cost components are priced with expired prices of the price cache, fetched as early as 2026-10-12T08:00:00Z. "+
+			"Expired prices are used for up to 7 days after they were fetched.",
+			diags.Warnings()[0].Detail())
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// discountRule is a discount block with its scope. A rule takes either a percentage off the cost of the matching
+// components or a monthly credit that is shared by the matching components of a resource.
+type discountRule struct {
+	name           string
+	percentage     decimal.Decimal
+	amount         *decimal.Decimal
+	resourceType   string
+	addressRegex   *regexp.Regexp
+	tags           map[string]string
+	componentRegex *regexp.Regexp
+	region         string
+	// startDate and endDate are the first and the last day the rule is valid, in UTC
+	startDate *time.Time
+	endDate   *time.Time
+}
+
+func newDiscountRules(discounts []DiscountModel) []discountRule {
+	rules := make([]discountRule, 0, len(discounts))
+	for i, d := range discounts {
+		rule := discountRule{
+			name:         d.Name.ValueString(),
+			resourceType: d.ResourceType.ValueString(),
+			tags:         make(map[string]string, len(d.Tags)),
+			region:       d.Region.ValueString(),
+		}
+		if rule.name == "" {
+			rule.name = fmt.Sprintf("discount[%d]", i)
+		}
+		if !d.Percentage.IsNull() && !d.Percentage.IsUnknown() {
+			perc, _ := d.Percentage.ValueBigFloat().Float64()
+			rule.percentage = decimal.NewFromFloat(perc)
+		}
+		if !d.Amount.IsNull() && !d.Amount.IsUnknown() {
+			amount, _ := d.Amount.ValueBigFloat().Float64()
+			credit := decimal.NewFromFloat(amount)
+			rule.amount = &credit
+		}
+		if address := d.Address.ValueString(); address != "" {
+			rule.addressRegex = globRegex(address)
+		}
+		if component := d.CostComponent.ValueString(); component != "" {
+			rule.componentRegex = globRegex(strings.ToLower(component))
+		}
+		for k, v := range d.Tags {
+			rule.tags[k] = v.ValueString()
+		}
+		if date, err := time.Parse(time.DateOnly, d.StartDate.ValueString()); err == nil {
+			rule.startDate = &date
+		}
+		if date, err := time.Parse(time.DateOnly, d.EndDate.ValueString()); err == nil {
+			rule.endDate = &date
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// isValidAt returns whether the time is within the validity window of the rule. The end date is the last day the
+// rule is valid, so the rule is valid until the end of that day.
+func (r discountRule) isValidAt(t time.Time) bool {
+	if r.startDate != nil && t.Before(*r.startDate) {
+		return false
+	}
+	if r.endDate != nil && !t.Before(r.endDate.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// isScoped returns whether the rule is limited to some resources or cost components. Rules without a scope are the
+// fallback discount of the components that no scoped rule matched.
+func (r discountRule) isScoped() bool {
+	return r.resourceType != "" || r.addressRegex != nil || len(r.tags) > 0 || r.componentRegex != nil || r.region != ""
+}
+
+func (r discountRule) matchesResource(res *tfschema.Resource) bool {
+	if r.resourceType != "" && res.ResourceType != r.resourceType {
+		return false
+	}
+	if r.addressRegex != nil && !r.addressRegex.MatchString(res.Name) {
+		return false
+	}
+	for k, v := range r.tags {
+		if res.Tags == nil || (*res.Tags)[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (r discountRule) matchesComponent(c *tfschema.CostComponent) bool {
+	if r.componentRegex != nil && !r.componentRegex.MatchString(strings.ToLower(c.Name)) {
+		return false
+	}
+	if r.region != "" {
+		if c.ProductFilter == nil || c.ProductFilter.Region == nil {
+			return false
+		}
+		if !strings.EqualFold(strings.ReplaceAll(*c.ProductFilter.Region, " ", ""), strings.ReplaceAll(r.region, " ", "")) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply takes the discount off the remaining monthly costs of the components and records it on them.
+func (r discountRule) apply(components []*tfschema.CostComponent, monthlyCosts map[*tfschema.CostComponent]decimal.Decimal) {
+	savings := make(map[*tfschema.CostComponent]decimal.Decimal, len(components))
+	if r.amount == nil {
+		for _, c := range components {
+			savings[c] = monthlyCosts[c].Mul(r.percentage)
+		}
+	} else {
+		total := decimal.Zero
+		for _, c := range components {
+			total = total.Add(monthlyCosts[c])
+		}
+		if !total.IsPositive() {
+			return
+		}
+		credit := decimal.Min(*r.amount, total)
+		for _, c := range components {
+			savings[c] = credit.Mul(monthlyCosts[c]).Div(total)
+		}
+	}
+
+	for _, c := range components {
+		if !savings[c].IsPositive() {
+			continue
+		}
+		monthlyCosts[c] = monthlyCosts[c].Sub(savings[c])
+		c.AppliedDiscounts = append(c.AppliedDiscounts, tfschema.AppliedDiscount{Name: r.name, MonthlySavings: savings[c]})
+	}
+}
+
+// resourceCostComponents returns the cost components of the resource and its sub-resources.
+func resourceCostComponents(resource *tfschema.Resource) []*tfschema.CostComponent {
+	components := append([]*tfschema.CostComponent{}, resource.CostComponents...)
+	for _, sub := range resource.SubResources {
+		components = append(components, resourceCostComponents(sub)...)
+	}
+	return components
+}
@@ -15,20 +15,32 @@ import (
 	"unicode/utf8"
 
 	"github.com/plancost/terraform-provider-plancost/internal/optimization"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
 	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
 )
 
-func GenerateConsoleOutput(displayName string, resources []*tfschema.Resource, recommendations []optimization.OptimizationRecommendation, paidTier bool) string {
+// ProjectResources holds the parsed resources of a single project of an estimate.
+type ProjectResources struct {
+	Name      string
+	Resources []*tfschema.Resource
+}
+
+func GenerateConsoleOutput(displayName string, resources []*tfschema.Resource, recommendations []optimization.OptimizationRecommendation, paidTier bool, currency string) string {
+	return GenerateProjectsConsoleOutput([]ProjectResources{{Name: displayName, Resources: resources}}, nil, nil, recommendations, paidTier, currency)
+}
+
+// GenerateProjectsConsoleOutput renders the estimate of one or more projects. When there is more than one project, each
+// project section ends with its subtotal and the summary table has a row per project plus the overall total. The cost
+// changes and the tag costs, if any, follow the summary table. Amounts are printed in the given currency.
+func GenerateProjectsConsoleOutput(projects []ProjectResources, diffs []ResourceDiffModel, tagCosts []TagCostModel, recommendations []optimization.OptimizationRecommendation, paidTier bool, currency string) string {
 	var sb strings.Builder
 
-	if displayName == "" {
-		displayName = "main"
+	type projectSummary struct {
+		name         string
+		baselineCost float64
+		usageCost    float64
 	}
-
-	// Header
-	sb.WriteString(fmt.Sprintf("Project: %s\n\n", displayName))
-	sb.WriteString(fmt.Sprintf(" %-58s %12s  %-14s %12s\n", "Name", "Monthly Qty", "Unit", "Monthly Cost"))
-	sb.WriteString("\n")
+	summaries := make([]projectSummary, 0, len(projects))
 
 	var baselineCost float64
 	var usageCost float64
@@ -38,36 +50,61 @@ func GenerateConsoleOutput(displayName string, resources []*tfschema.Resource, r
 	unsupportedResourceCount := 0
 	unsupportedResourceTypes := make(map[string]bool)
 
-	for _, res := range resources {
-		if res.IsSkipped {
-			if res.NoPrice {
-				freeResourceCount++
-			} else {
-				unsupportedResourceCount++
-				unsupportedResourceTypes[res.ResourceType] = true
-			}
-			continue
+	for _, project := range projects {
+		displayName := project.Name
+		if displayName == "" {
+			displayName = "main"
 		}
-		estimatedResourceCount++
-		resName := res.Name
 
-		resBaseline, resUsage := calculateResourceCosts(res)
-		baselineCost += resBaseline
-		usageCost += resUsage
+		// Header
+		sb.WriteString(fmt.Sprintf("Project: %s\n\n", displayName))
+		sb.WriteString(fmt.Sprintf(" %-58s %12s  %-14s %12s\n", "Name", "Monthly Qty", "Unit", "Monthly Cost"))
+		sb.WriteString("\n")
 
-		// Print Resource Name
-		sb.WriteString(fmt.Sprintf(" %s\n", resName))
+		summary := projectSummary{name: displayName}
+		for _, res := range project.Resources {
+			if res.IsSkipped {
+				if res.NoPrice {
+					freeResourceCount++
+				} else {
+					unsupportedResourceCount++
+					unsupportedResourceTypes[res.ResourceType] = true
+				}
+				continue
+			}
+			estimatedResourceCount++
+			resName := res.Name
 
-		// Print cost components and sub-resources with proper tree structure
-		printResourceTree(&sb, res.CostComponents, res.SubResources, " ")
-		sb.WriteString("\n")
+			resBaseline, resUsage := calculateResourceCosts(res)
+			summary.baselineCost += resBaseline
+			summary.usageCost += resUsage
+
+			// Print Resource Name
+			sb.WriteString(fmt.Sprintf(" %s\n", resName))
+
+			// Print cost components and sub-resources with proper tree structure
+			printResourceTree(&sb, res.CostComponents, res.SubResources, " ", currency)
+			sb.WriteString("\n")
+		}
+
+		if len(projects) > 1 {
+			sb.WriteString(fmt.Sprintf(" Project total%87s\n", formatAmount(summary.baselineCost+summary.usageCost, currency)))
+			sb.WriteString("\n")
+		}
+
+		baselineCost += summary.baselineCost
+		usageCost += summary.usageCost
+		summaries = append(summaries, summary)
 	}
 
 	totalCost := baselineCost + usageCost
 
-	sb.WriteString(fmt.Sprintf(" OVERALL TOTAL%87s\n", formatAmount(totalCost)))
+	sb.WriteString(fmt.Sprintf(" OVERALL TOTAL%87s\n", formatAmount(totalCost, currency)))
 	sb.WriteString("\n")
 	sb.WriteString("*Usage costs can be estimated by providing usage data in the plancost_estimate resource.\n")
+	if hasPriceSheetPrices(projects) {
+		sb.WriteString("†Priced at the negotiated price of the provider's price sheet.\n")
+	}
 	sb.WriteString("\n")
 	sb.WriteString("──────────────────────────────────\n")
 
@@ -92,18 +129,31 @@ func GenerateConsoleOutput(displayName string, resources []*tfschema.Resource, r
 	sb.WriteString("┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┳━━━━━━━━━━━━━━━┳━━━━━━━━━━━━━┳━━━━━━━━━━━━┓\n")
 	sb.WriteString("┃ Project                                            ┃ Baseline cost ┃ Usage cost* ┃ Total cost ┃\n")
 	sb.WriteString("┣━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━╋━━━━━━━━━━━━┫\n")
-	sb.WriteString(fmt.Sprintf("┃ %-50s ┃ %13s ┃ %11s ┃ %10s ┃\n",
-		truncateString(displayName, 50),
-		formatAmount(baselineCost),
-		formatAmount(usageCost),
-		formatAmount(totalCost)))
+	for _, summary := range summaries {
+		sb.WriteString(fmt.Sprintf("┃ %-50s ┃ %13s ┃ %11s ┃ %10s ┃\n",
+			truncateString(summary.name, 50),
+			formatAmount(summary.baselineCost, currency),
+			formatAmount(summary.usageCost, currency),
+			formatAmount(summary.baselineCost+summary.usageCost, currency)))
+	}
+	if len(summaries) > 1 {
+		sb.WriteString("┣━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━╋━━━━━━━━━━━━┫\n")
+		sb.WriteString(fmt.Sprintf("┃ %-50s ┃ %13s ┃ %11s ┃ %10s ┃\n",
+			"TOTAL",
+			formatAmount(baselineCost, currency),
+			formatAmount(usageCost, currency),
+			formatAmount(totalCost, currency)))
+	}
 	sb.WriteString("┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━┻━━━━━━━━━━━━┛\n")
 
+	printConsoleResourceDiffs(&sb, diffs, currency)
+	printConsoleTagCosts(&sb, tagCosts, currency)
+
 	// Optimization Opportunities
-	// Filter recommendations, only keep the Reservation or Teaser
+	// Filter recommendations, only keep the Reservation, SavingsPlan, Advisory, Custom or Teaser
 	var filteredRecs []optimization.OptimizationRecommendation
 	for _, rec := range recommendations {
-		if rec.Type == "Reservation" || rec.Type == "Teaser" {
+		if rec.Type == "Reservation" || rec.Type == "SavingsPlan" || rec.Type == "Advisory" || rec.Type == RecommendationRuleType || rec.Type == "Teaser" {
 			filteredRecs = append(filteredRecs, rec)
 		}
 	}
@@ -114,43 +164,77 @@ func GenerateConsoleOutput(displayName string, resources []*tfschema.Resource, r
 		sb.WriteString("💡 Optimization Recommendations\n")
 		sb.WriteString("\n")
 
-		if paidTier {
-			grouped, keys := optimization.GroupOptimizations(recommendations)
-			for _, resAddr := range keys {
-				opts := grouped[resAddr]
-				sb.WriteString(fmt.Sprintf(" %s\n", resAddr))
-				for i, opt := range opts {
-					isLast := i == len(opts)-1
-					prefix := " ├─"
-					if isLast {
-						prefix = " └─"
-					}
-					// Format: "{Term} {Type}: Save ${RoundedAmount}/mo ({Pct}%)"
-					// Term might be "1 yr" -> "1-Year"
-					term := strings.Replace(opt.Term, " yr", "-Year", 1)
-					term = strings.Replace(term, " Yr", "-Year", 1) // just in case
+		// The free tier only gets a teaser of the reservations, and the recommendations of its own recommendation rules
+		var teasers, details []optimization.OptimizationRecommendation
+		for _, rec := range recommendations {
+			if rec.Type == "Teaser" {
+				teasers = append(teasers, rec)
+			} else {
+				details = append(details, rec)
+			}
+		}
+
+		grouped, keys := optimization.GroupOptimizations(details)
+		for _, resAddr := range keys {
+			opts := grouped[resAddr]
+			sb.WriteString(fmt.Sprintf(" %s\n", resAddr))
+			for i, opt := range opts {
+				isLast := i == len(opts)-1
+				prefix := " ├─"
+				if isLast {
+					prefix = " └─"
+				}
+				if opt.Type == "SavingsPlan" {
+					// Format: "{Term} Savings Plan ({Commitment}/hour): Save ${RoundedAmount}/mo ({Pct}%)"
+					sb.WriteString(fmt.Sprintf("%s %s\n", prefix, formatSavingsPlan(opt, currency)))
+					continue
+				}
+				if opt.Type != "Reservation" {
+					// Format: "{Description}: Save ${RoundedAmount}/mo ({Pct}%)", without savings if they couldn't be priced
+					sb.WriteString(fmt.Sprintf("%s %s\n", prefix, formatAdvisory(opt, currency)))
+					continue
+				}
 
-					// Round amount
-					amount := math.Round(opt.SavingsAmount)
+				// Format: "{Term} {Type}: Save ${RoundedAmount}/mo ({Pct}%)"
+				// Term might be "1 yr" -> "1-Year"
+				term := strings.Replace(opt.Term, " yr", "-Year", 1)
+				term = strings.Replace(term, " Yr", "-Year", 1) // just in case
 
-					// Pct is 0.0 - 1.0, display as 58%
-					pct := math.Round(opt.SavingsPercentage * 100)
+				// Round amount
+				amount := math.Round(opt.SavingsAmount)
 
-					sb.WriteString(fmt.Sprintf("%s %s Reservation: Save $%s/mo (%.0f%%)\n", prefix, term, addCommas(fmt.Sprintf("%.0f", amount)), pct))
-				}
-				sb.WriteString("\n")
-			}
-		} else {
-			if len(recommendations) > 0 {
-				sb.WriteString(" ")
-				sb.WriteString(recommendations[0].Description)
+				// Pct is 0.0 - 1.0, display as 58%
+				pct := math.Round(opt.SavingsPercentage * 100)
+
+				sb.WriteString(fmt.Sprintf("%s %s Reservation: Save %s%s/mo (%.0f%%)\n", prefix, term, prices.CurrencySymbol(currency), addCommas(fmt.Sprintf("%.0f", amount)), pct))
 			}
+			sb.WriteString("\n")
+		}
+		if len(teasers) > 0 {
+			sb.WriteString(" ")
+			sb.WriteString(teasers[0].Description)
 		}
 	}
 
 	return sb.String()
 }
 
+// formatAdvisory formats an advisory or custom recommendation of the view, e.g.
+// "Consider using Cool or Archive access tier for infrequently accessed data: Save $12/mo (45%)".
+func formatAdvisory(opt optimization.OptimizationRecommendation, currency string) string {
+	description := strings.TrimSuffix(opt.Description, ".")
+	if opt.SavingsAmount <= 0 {
+		return description
+	}
+	return fmt.Sprintf("%s: Save %s%s/mo (%.0f%%)", description, prices.CurrencySymbol(currency), addCommas(fmt.Sprintf("%.0f", math.Round(opt.SavingsAmount))), math.Round(opt.SavingsPercentage*100))
+}
+
+// formatSavingsPlan formats a savings plan recommendation of the view, e.g. "1-Year Savings Plan ($1.23/hour): Save $369/mo (28%)".
+func formatSavingsPlan(opt optimization.OptimizationRecommendation, currency string) string {
+	term := strings.Replace(opt.Term, " yr", "-Year", 1)
+	return fmt.Sprintf("%s Savings Plan (%s/hour): Save %s%s/mo (%.0f%%)", term, formatCost(opt.HourlyCommitment, currency), prices.CurrencySymbol(currency), addCommas(fmt.Sprintf("%.0f", math.Round(opt.SavingsAmount))), math.Round(opt.SavingsPercentage*100))
+}
+
 func truncateString(str string, num int) string {
 	if utf8.RuneCountInString(str) > num {
 		runes := []rune(str)
@@ -180,7 +264,7 @@ func calculateResourceCosts(res *tfschema.Resource) (baseline float64, usage flo
 	return
 }
 
-func printResourceTree(sb *strings.Builder, costComponents []*tfschema.CostComponent, subResources []*tfschema.Resource, baseIndent string) {
+func printResourceTree(sb *strings.Builder, costComponents []*tfschema.CostComponent, subResources []*tfschema.Resource, baseIndent string, currency string) {
 	// Collect all items (cost components and sub-resources)
 	type item struct {
 		name          string
@@ -221,7 +305,7 @@ func printResourceTree(sb *strings.Builder, costComponents []*tfschema.CostCompo
 			}
 
 			// Recursively print sub-resource contents
-			printResourceTree(sb, item.subRes.CostComponents, item.subRes.SubResources, childIndent)
+			printResourceTree(sb, item.subRes.CostComponents, item.subRes.SubResources, childIndent, currency)
 		} else {
 			// Calculate available width for name based on prefix length
 			nameWidth := 58 - utf8.RuneCountInString(prefix)
@@ -246,13 +330,15 @@ func printResourceTree(sb *strings.Builder, costComponents []*tfschema.CostCompo
 					space = ""
 				}
 
-				fmt.Fprintf(sb, "%s %-*s%s Monthly cost depends on usage: $%s per %s\n",
+				fmt.Fprintf(sb, "%s %-*s%s Monthly cost depends on usage: %s%s per %s%s\n",
 					prefix,
 					nameWidth,
 					truncatedName,
 					space,
+					prices.CurrencySymbol(currency),
 					priceStr,
-					item.cc.Unit)
+					item.cc.Unit,
+					priceSheetMarker(item.cc, " "))
 			} else {
 				// Print cost component
 				cost := 0.0
@@ -269,6 +355,12 @@ func printResourceTree(sb *strings.Builder, costComponents []*tfschema.CostCompo
 				if item.cc.UsageBased {
 					usageMarker = "  *"
 				}
+				if marker := priceSheetMarker(item.cc, "  "); marker != "" {
+					if usageMarker != "" {
+						marker = "†"
+					}
+					usageMarker += marker
+				}
 
 				truncatedName := truncateString(item.name, nameWidth+1)
 				space := " "
@@ -284,17 +376,50 @@ func printResourceTree(sb *strings.Builder, costComponents []*tfschema.CostCompo
 					space,
 					monthlyQuantity,
 					item.cc.Unit,
-					formatAmount(cost),
+					formatAmount(cost, currency),
 					usageMarker)
 			}
 		}
 	}
 }
 
-func formatAmount(amount float64) string {
+// priceSheetMarker returns the marker of components priced from the price sheet, after the separator, or an empty
+// string for other components.
+func priceSheetMarker(cc *tfschema.CostComponent, separator string) string {
+	if cc.PriceSource != tfschema.PriceSourcePriceSheet {
+		return ""
+	}
+	return separator + "†"
+}
+
+// hasPriceSheetPrices reports whether any cost component of the projects is priced from the price sheet.
+func hasPriceSheetPrices(projects []ProjectResources) bool {
+	for _, project := range projects {
+		for _, res := range project.Resources {
+			costComponents := append([]*tfschema.CostComponent{}, res.CostComponents...)
+			for _, subRes := range res.FlattenedSubResources() {
+				costComponents = append(costComponents, subRes.CostComponents...)
+			}
+			for _, cc := range costComponents {
+				if cc.PriceSource == tfschema.PriceSourcePriceSheet {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// formatAmount formats an amount with thousands separators, e.g. "€1,234.50".
+func formatAmount(amount float64, currency string) string {
 	s := fmt.Sprintf("%.2f", amount)
 	parts := strings.Split(s, ".")
-	return "$" + addCommas(parts[0]) + "." + parts[1]
+	return prices.CurrencySymbol(currency) + addCommas(parts[0]) + "." + parts[1]
+}
+
+// formatCost formats an amount without thousands separators, e.g. "€1234.50", as used in markdown and messages.
+func formatCost(amount float64, currency string) string {
+	return fmt.Sprintf("%s%.2f", prices.CurrencySymbol(currency), amount)
 }
 
 func formatQuantity(q float64) string {
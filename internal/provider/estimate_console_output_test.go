@@ -126,6 +126,55 @@ func TestGenerateConsoleOutput(t *testing.T) {
 ┣━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━╋━━━━━━━━━━━━┫
 ┃ main                                               ┃         $5.00 ┃       $0.00 ┃      $5.00 ┃
 ┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━┻━━━━━━━━━━━━┛
+`,
+		},
+		{
+			name:        "Price sheet prices",
+			displayName: "main",
+			resources: []*tfschema.Resource{
+				{
+					Name: "azurerm_linux_virtual_machine.app",
+					CostComponents: []*tfschema.CostComponent{
+						{
+							Name:            "Instance usage (Linux, pay as you go, Standard_D2s_v5)",
+							Unit:            "hours",
+							MonthlyQuantity: d(730.0),
+							MonthlyCost:     d(56.21),
+							UnitMultiplier:  decimal.NewFromInt(1),
+							PriceSource:     tfschema.PriceSourcePriceSheet,
+						},
+						{
+							Name:           "Outbound data transfer",
+							Unit:           "GB",
+							UsageBased:     true,
+							UnitMultiplier: decimal.NewFromInt(1),
+							PriceSource:    tfschema.PriceSourcePriceSheet,
+						},
+					},
+				},
+			},
+			expected: `Project: main
+
+ Name                                                        Monthly Qty  Unit           Monthly Cost
+
+ azurerm_linux_virtual_machine.app
+ ├─ Instance usage (Linux, pay as you go, Standard_D2s_v5)           730  hours                $56.21  †
+ └─ Outbound data transfer                                   Monthly cost depends on usage: $0.00 per GB †
+
+ OVERALL TOTAL                                                                                 $56.21
+
+*Usage costs can be estimated by providing usage data in the plancost_estimate resource.
+†Priced at the negotiated price of the provider's price sheet.
+
+──────────────────────────────────
+1 cloud resources were detected:
+∙ 1 were estimated
+
+┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┳━━━━━━━━━━━━━━━┳━━━━━━━━━━━━━┳━━━━━━━━━━━━┓
+┃ Project                                            ┃ Baseline cost ┃ Usage cost* ┃ Total cost ┃
+┣━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━╋━━━━━━━━━━━━┫
+┃ main                                               ┃        $56.21 ┃       $0.00 ┃     $56.21 ┃
+┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━┻━━━━━━━━━━━━┛
 `,
 		},
 		{
@@ -570,6 +619,15 @@ func TestGenerateConsoleOutput(t *testing.T) {
 					SavingsAmount:     369.48,
 					SavingsPercentage: 0.58,
 				},
+				{
+					ResourceAddress:   optimization.SavingsPlanAddress,
+					Description:       "Commit to $0.37/hour with a 1-year Savings Plan for Compute to save $291/mo (46%) on 1 compute resources, compared to $369/mo with 1-year Reservations",
+					Type:              "SavingsPlan",
+					Term:              "1 yr",
+					SavingsAmount:     291.27,
+					SavingsPercentage: 0.46,
+					HourlyCommitment:  0.369,
+				},
 			},
 			paidTier: true,
 			expected: `Project: main
@@ -595,10 +653,74 @@ func TestGenerateConsoleOutput(t *testing.T) {
 
 💡 Optimization Recommendations
 
+ Savings Plan for Compute
+ └─ 1-Year Savings Plan ($0.37/hour): Save $291/mo (46%)
+
  azurerm_linux_virtual_machine.vm
  └─ 1-Year Reservation: Save $369/mo (58%)
 
 `},
+		{
+			name:        "Optimization Recommendations (Advisory)",
+			displayName: "main",
+			resources: []*tfschema.Resource{
+				{
+					Name: "azurerm_managed_disk.data",
+					CostComponents: []*tfschema.CostComponent{
+						ccWithPrice("Storage", "months", 0.5),
+					},
+				},
+			},
+			recommendations: []optimization.OptimizationRecommendation{
+				{
+					ResourceAddress:   "azurerm_managed_disk.data",
+					Description:       "Consider updating Premium SSD disk to Premium SSD v2 for better performance and cost",
+					Type:              "Advisory",
+					SavingsAmount:     9.24,
+					SavingsPercentage: 0.47,
+				},
+				{
+					ResourceAddress: "azurerm_managed_disk.data",
+					Description:     "Check that the disk is still in use.",
+					Type:            "Custom",
+				},
+				{
+					Description: "We found reservations that could save you ~$369/year (0%).\n [ Upgrade to Pro to see details ]\n",
+					Type:        "Teaser",
+				},
+			},
+			paidTier: false,
+			expected: `Project: main
+
+ Name                                                        Monthly Qty  Unit           Monthly Cost
+
+ azurerm_managed_disk.data
+ └─ Storage                                                  Monthly cost depends on usage: $0.50 per months
+
+ OVERALL TOTAL                                                                                  $0.00
+
+*Usage costs can be estimated by providing usage data in the plancost_estimate resource.
+
+──────────────────────────────────
+1 cloud resources were detected:
+∙ 1 were estimated
+
+┏━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┳━━━━━━━━━━━━━━━┳━━━━━━━━━━━━━┳━━━━━━━━━━━━┓
+┃ Project                                            ┃ Baseline cost ┃ Usage cost* ┃ Total cost ┃
+┣━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━━━╋━━━━━━━━━━━━━╋━━━━━━━━━━━━┫
+┃ main                                               ┃         $0.00 ┃       $0.00 ┃      $0.00 ┃
+┗━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━━━┻━━━━━━━━━━━━━┻━━━━━━━━━━━━┛
+
+💡 Optimization Recommendations
+
+ azurerm_managed_disk.data
+ ├─ Consider updating Premium SSD disk to Premium SSD v2 for better performance and cost: Save $9/mo (47%)
+ └─ Check that the disk is still in use
+
+ We found reservations that could save you ~$369/year (0%).
+ [ Upgrade to Pro to see details ]
+`,
+		},
 		{
 			name:        "Optimization Recommendations (Free)",
 			displayName: "main",
@@ -648,7 +770,7 @@ func TestGenerateConsoleOutput(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			output := GenerateConsoleOutput(tt.displayName, tt.resources, tt.recommendations, tt.paidTier)
+			output := GenerateConsoleOutput(tt.displayName, tt.resources, tt.recommendations, tt.paidTier, "USD")
 			assert.Equal(t, tt.expected, output)
 		})
 	}
@@ -668,10 +790,16 @@ func TestFormatAmount(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		assert.Equal(t, tt.expected, formatAmount(tt.input))
+		assert.Equal(t, tt.expected, formatAmount(tt.input, "USD"))
 	}
 }
 
+func TestFormatAmount_Currency(t *testing.T) {
+	assert.Equal(t, "€1,234.56", formatAmount(1234.56, "EUR"))
+	assert.Equal(t, "£0.50", formatAmount(0.5, "GBP"))
+	assert.Equal(t, "CHF 10.00", formatAmount(10, "CHF"))
+}
+
 func TestFormatQuantity(t *testing.T) {
 	tests := []struct {
 		input    float64
@@ -705,3 +833,37 @@ func TestTruncateString(t *testing.T) {
 		assert.Equal(t, tt.expected, truncateString(tt.input, tt.num))
 	}
 }
+
+func TestGenerateProjectsConsoleOutput(t *testing.T) {
+	ipResource := func(name string) *tfschema.Resource {
+		quantity := decimal.NewFromInt(730)
+		cost := decimal.NewFromFloat(3.65)
+		return &tfschema.Resource{
+			Name: name,
+			CostComponents: []*tfschema.CostComponent{
+				{
+					Name:            "IP address (static, regional)",
+					Unit:            "hours",
+					MonthlyQuantity: &quantity,
+					MonthlyCost:     &cost,
+					UnitMultiplier:  decimal.NewFromInt(1),
+				},
+			},
+		}
+	}
+
+	output := GenerateProjectsConsoleOutput([]ProjectResources{
+		{Name: "dev", Resources: []*tfschema.Resource{ipResource("azurerm_public_ip.example[0]")}},
+		{Name: "prod", Resources: []*tfschema.Resource{ipResource("azurerm_public_ip.example[0]"), ipResource("azurerm_public_ip.example[1]")}},
+	}, nil, nil, nil, false, "USD")
+
+	assert.Contains(t, output, "Project: dev\n")
+	assert.Contains(t, output, "Project: prod\n")
+	assert.Contains(t, output, " Project total                                                                                  $3.65\n")
+	assert.Contains(t, output, " Project total                                                                                  $7.30\n")
+	assert.Contains(t, output, " OVERALL TOTAL                                                                                 $10.95\n")
+	assert.Contains(t, output, "3 cloud resources were detected:\n")
+	assert.Contains(t, output, "┃ dev                                                ┃         $3.65 ┃       $0.00 ┃      $3.65 ┃\n")
+	assert.Contains(t, output, "┃ prod                                               ┃         $7.30 ┃       $0.00 ┃      $7.30 ┃\n")
+	assert.Contains(t, output, "┃ TOTAL                                              ┃        $10.95 ┃       $0.00 ┃     $10.95 ┃\n")
+}
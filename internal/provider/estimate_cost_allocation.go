@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+// UntaggedTagValue is the tag value of the bucket of resources that don't have an allocation tag.
+const UntaggedTagValue = "untagged"
+
+type CostAllocationModel struct {
+	TagKeys []types.String `tfsdk:"tag_keys"`
+}
+
+// TagCostModel is the monthly cost of the resources with a single value of a cost allocation tag.
+type TagCostModel struct {
+	Key           string  `json:"key"`
+	Value         string  `json:"value"`
+	ResourceCount int     `json:"resource_count"`
+	MonthlyCost   float64 `json:"monthly_cost"`
+}
+
+// costAllocationTagKeys returns the tag keys of the cost_allocation blocks, without duplicates.
+func costAllocationTagKeys(allocations []CostAllocationModel) []string {
+	keys := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, allocation := range allocations {
+		for _, key := range allocation.TagKeys {
+			if k := key.ValueString(); k != "" && !seen[k] {
+				keys = append(keys, k)
+				seen[k] = true
+			}
+		}
+	}
+	return keys
+}
+
+// CostByTag breaks the monthly cost of the resources down by the values of each tag key. Resources without the tag are
+// counted in the UntaggedTagValue bucket, so the buckets of each key add up to the total cost. The buckets are grouped
+// by key in the given order, and sorted by cost with the untagged bucket last.
+func CostByTag(tagKeys []string, resources []*tfschema.Resource) []TagCostModel {
+	tagCosts := make([]TagCostModel, 0)
+	for _, key := range tagKeys {
+		buckets := make(map[string]*TagCostModel)
+		for _, res := range resources {
+			if res.IsSkipped || res.MonthlyCost == nil {
+				continue
+			}
+
+			value := UntaggedTagValue
+			if res.Tags != nil {
+				if v, ok := (*res.Tags)[key]; ok && v != "" {
+					value = v
+				}
+			}
+
+			bucket, ok := buckets[value]
+			if !ok {
+				bucket = &TagCostModel{Key: key, Value: value}
+				buckets[value] = bucket
+			}
+			bucket.ResourceCount++
+			bucket.MonthlyCost += res.MonthlyCost.InexactFloat64()
+		}
+
+		keyCosts := make([]TagCostModel, 0, len(buckets))
+		for _, bucket := range buckets {
+			bucket.MonthlyCost = roundCost(bucket.MonthlyCost)
+			keyCosts = append(keyCosts, *bucket)
+		}
+		sort.Slice(keyCosts, func(i, j int) bool {
+			if (keyCosts[i].Value == UntaggedTagValue) != (keyCosts[j].Value == UntaggedTagValue) {
+				return keyCosts[j].Value == UntaggedTagValue
+			}
+			if keyCosts[i].MonthlyCost != keyCosts[j].MonthlyCost {
+				return keyCosts[i].MonthlyCost > keyCosts[j].MonthlyCost
+			}
+			return keyCosts[i].Value < keyCosts[j].Value
+		})
+		tagCosts = append(tagCosts, keyCosts...)
+	}
+	return tagCosts
+}
+
+// printConsoleTagCosts renders the cost allocation table of the view.
+func printConsoleTagCosts(sb *strings.Builder, tagCosts []TagCostModel, currency string) {
+	if len(tagCosts) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString("Cost allocation by tag\n")
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(" %-20s %-37s %10s %12s\n", "Tag", "Value", "Resources", "Monthly Cost"))
+	for _, tc := range tagCosts {
+		sb.WriteString(fmt.Sprintf(" %-20s %-37s %10d %12s\n", truncateString(tc.Key, 20), truncateString(tc.Value, 37), tc.ResourceCount, formatAmount(tc.MonthlyCost, currency)))
+	}
+}
+
+// printMarkdownTagCosts renders the cost allocation table of the markdown report.
+func printMarkdownTagCosts(sb *strings.Builder, tagCosts []TagCostModel, currency string) {
+	if len(tagCosts) == 0 {
+		return
+	}
+
+	sb.WriteString("\n#### Cost allocation by tag\n\n")
+	sb.WriteString("| Tag | Value | Resources | Monthly Cost |\n")
+	sb.WriteString("|:--- |:--- |:--- |:--- |\n")
+	for _, tc := range tagCosts {
+		value := tc.Value
+		if value == UntaggedTagValue {
+			value = "_" + value + "_"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %s |\n", tc.Key, value, tc.ResourceCount, formatCost(tc.MonthlyCost, currency)))
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostByTag(t *testing.T) {
+	newResource := func(name string, cost float64, tags map[string]string) *tfschema.Resource {
+		monthlyCost := decimal.NewFromFloat(cost)
+		res := &tfschema.Resource{Name: name, MonthlyCost: &monthlyCost}
+		if tags != nil {
+			res.Tags = &tags
+		}
+		return res
+	}
+
+	resources := []*tfschema.Resource{
+		newResource("azurerm_kubernetes_cluster.payments", 140.16, map[string]string{"team": "payments", "env": "prod"}),
+		newResource("azurerm_public_ip.payments", 3.65, map[string]string{"team": "payments", "env": "prod"}),
+		newResource("azurerm_linux_virtual_machine.platform", 70.08, map[string]string{"team": "platform", "env": "dev"}),
+		newResource("azurerm_public_ip.untagged", 3.65, nil),
+		{Name: "azurerm_resource_group.skipped", IsSkipped: true, NoPrice: true},
+	}
+
+	keys := costAllocationTagKeys([]CostAllocationModel{
+		{TagKeys: []types.String{types.StringValue("team"), types.StringValue("env"), types.StringValue("team")}},
+	})
+	assert.Equal(t, []string{"team", "env"}, keys)
+
+	assert.Equal(t, []TagCostModel{
+		{Key: "team", Value: "payments", ResourceCount: 2, MonthlyCost: 143.81},
+		{Key: "team", Value: "platform", ResourceCount: 1, MonthlyCost: 70.08},
+		{Key: "team", Value: UntaggedTagValue, ResourceCount: 1, MonthlyCost: 3.65},
+		{Key: "env", Value: "prod", ResourceCount: 2, MonthlyCost: 143.81},
+		{Key: "env", Value: "dev", ResourceCount: 1, MonthlyCost: 70.08},
+		{Key: "env", Value: UntaggedTagValue, ResourceCount: 1, MonthlyCost: 3.65},
+	}, CostByTag(keys, resources))
+}
+
+func TestGenerateMarkdownOutput_TagCosts(t *testing.T) {
+	newResources := []CostResourceModel{
+		{Name: "azurerm_public_ip.example", CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}}},
+	}
+	tagCosts := []TagCostModel{
+		{Key: "team", Value: "payments", ResourceCount: 1, MonthlyCost: 3.65},
+		{Key: "team", Value: UntaggedTagValue, ResourceCount: 0, MonthlyCost: 0},
+	}
+
+	markdown := GenerateMarkdownOutput(nil, newResources, tagCosts, nil, "USD")
+	assert.Contains(t, markdown, "#### Cost allocation by tag\n\n| Tag | Value | Resources | Monthly Cost |\n|:--- |:--- |:--- |:--- |\n| team | payments | 1 | $3.65 |\n| team | _untagged_ | 0 | $0.00 |\n")
+}
+
+func TestGenerateConsoleOutput_TagCosts(t *testing.T) {
+	tagCosts := []TagCostModel{
+		{Key: "cost_center", Value: "cc-1234", ResourceCount: 2, MonthlyCost: 1234.5},
+	}
+
+	output := GenerateProjectsConsoleOutput([]ProjectResources{{Name: "main"}}, nil, tagCosts, nil, true, "EUR")
+	assert.Contains(t, output, "Cost allocation by tag\n\n Tag                  Value                                  Resources Monthly Cost\n cost_center          cc-1234                                        2    €1,234.50\n")
+}
@@ -0,0 +1,288 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/numbervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/plancost/terraform-provider-plancost/internal/dynamic"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	"github.com/plancost/terraform-provider-plancost/internal/provider/myvalidator"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+var _ datasource.DataSource = &EstimateDataSource{}
+
+func NewEstimateDataSource() datasource.DataSource {
+	return &EstimateDataSource{}
+}
+
+// EstimateDataSource defines the data source implementation.
+type EstimateDataSource struct {
+	priceFetcher *prices.PriceFetcher
+}
+
+// EstimateDataSourceModel describes the data source data model.
+type EstimateDataSourceModel struct {
+	WorkingDirectory types.String `tfsdk:"working_directory"`
+	PlanJSONFile     types.String `tfsdk:"plan_json_file"`
+	ProjectName      types.String `tfsdk:"project_name"`
+
+	UsageFile types.String  `tfsdk:"usage_file"`
+	Usage     types.Dynamic `tfsdk:"usage"`
+	VarFile   types.String  `tfsdk:"var_file"`
+
+	PricingProfile types.String `tfsdk:"pricing_profile"`
+
+	Resources   types.Dynamic   `tfsdk:"resources"`
+	MonthlyCost types.Number    `tfsdk:"monthly_cost"`
+	Currency    types.String    `tfsdk:"currency"`
+	View        types.String    `tfsdk:"view"`
+	Discount    []DiscountModel `tfsdk:"discount"`
+}
+
+func (d *EstimateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_estimate"
+}
+
+func (d *EstimateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `plancost_estimate` data source estimates the cost of cloud resources within a Terraform module without creating a project in PlanCost. It is useful for read-only cost lookups, e.g. feeding the cost of a child module into outputs or `check` blocks.",
+
+		Attributes: map[string]schema.Attribute{
+			"working_directory": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to the Terraform module directory (e.g., `abspath(path.module)`). Exactly one of `working_directory` or `plan_json_file` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("plan_json_file")),
+				},
+			},
+
+			"plan_json_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to a Terraform plan in JSON format, as produced by `terraform show -json tfplan`. When set, resources are read from the plan's planned values instead of parsing the module.",
+				Optional:            true,
+			},
+
+			"project_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the project shown in the `view` output. Defaults to `main`.",
+				Optional:            true,
+			},
+
+			"usage_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to the usage file (e.g., `abspath(\"${path.module}/usage.yml\")`)",
+				Optional:            true,
+			},
+
+			"usage": schema.DynamicAttribute{
+				MarkdownDescription: "Usage data for resources. More details can be found in the [Usage Guide](../guides/usage.md).",
+				Optional:            true,
+			},
+
+			"var_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to the variables file (e.g., `abspath(\"${path.module}/variables.tfvars\")`). Variables are loaded from the same sources as the `plancost_estimate` resource.",
+				Optional:            true,
+			},
+
+			"pricing_profile": schema.StringAttribute{
+				MarkdownDescription: "The rates that resources are priced at. Valid values: `pay_as_you_go`, `dev_test`, `hybrid_benefit_windows` and `hybrid_benefit_sql`. See the `plancost_estimate` resource for details. Defaults to `pay_as_you_go`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tfschema.PricingProfiles...),
+				},
+			},
+
+			"resources": schema.DynamicAttribute{
+				MarkdownDescription: "Detailed cost breakdown per resource.",
+				Computed:            true,
+			},
+
+			"monthly_cost": schema.NumberAttribute{
+				MarkdownDescription: "The estimated monthly cost (numeric value)",
+				Computed:            true,
+			},
+
+			"currency": schema.StringAttribute{
+				MarkdownDescription: "The ISO 4217 code of the currency of `monthly_cost` and all other amounts, as configured on the provider.",
+				Computed:            true,
+			},
+
+			"view": schema.StringAttribute{
+				MarkdownDescription: "The pretty printed output of the estimate",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"discount": schema.ListNestedBlock{
+				MarkdownDescription: "List of discounts to apply. Discounts with a scope stack in the order they are declared, each applying to the cost left by the previous ones. " +
+					"Discounts without a scope only apply to the cost components that no scoped discount matched. The applied discounts are listed per cost component in `resources`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the discount in `resources`. Defaults to `discount[<index>]`.",
+							Optional:            true,
+						},
+
+						"percentage": schema.NumberAttribute{
+							MarkdownDescription: "The discount percentage (0.0 to 1.0). Exactly one of `percentage` or `amount` must be set.",
+							Optional:            true,
+							Validators: []validator.Number{
+								myvalidator.NumberBetween(0.0, 1.0),
+								numbervalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("amount")),
+							},
+						},
+
+						"amount": schema.NumberAttribute{
+							MarkdownDescription: "A monthly credit for each matching resource, in the currency of the estimate. The credit is split across the matching cost components of the resource in proportion to their cost, and never makes a cost negative.",
+							Optional:            true,
+							Validators: []validator.Number{
+								myvalidator.NumberBetween(0.0, math.MaxFloat64),
+							},
+						},
+
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "The resource type to apply the discount to (e.g., 'azurerm_virtual_machine'). If not specified, applies to all resources.",
+							Optional:            true,
+						},
+
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Only apply the discount to resources whose address matches this glob, e.g. `module.prod_*`. `*` matches any characters.",
+							Optional:            true,
+						},
+
+						"tags": schema.MapAttribute{
+							MarkdownDescription: "Only apply the discount to resources that have all of these tag values, e.g. `{ environment = \"prod\" }`.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+
+						"cost_component": schema.StringAttribute{
+							MarkdownDescription: "Only apply the discount to cost components whose name matches this case-insensitive glob, e.g. `Compute*` or `Instance usage*`.",
+							Optional:            true,
+						},
+
+						"region": schema.StringAttribute{
+							MarkdownDescription: "Only apply the discount to cost components priced in this region, e.g. `eastus`.",
+							Optional:            true,
+						},
+
+						"start_date": schema.StringAttribute{
+							MarkdownDescription: "The first day the discount is valid, in the `YYYY-MM-DD` format. The discount isn't applied to estimates made before this day (UTC).",
+							Optional:            true,
+							Validators: []validator.String{
+								myvalidator.ValidDate(),
+							},
+						},
+
+						"end_date": schema.StringAttribute{
+							MarkdownDescription: "The last day the discount is valid, in the `YYYY-MM-DD` format, e.g. the end of an agreement. The discount isn't applied to estimates made after this day (UTC).",
+							Optional:            true,
+							Validators: []validator.String{
+								myvalidator.ValidDate(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EstimateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PlanCostProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PlanCostProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	if data.PriceFetcher == nil {
+		resp.Diagnostics.AddError(
+			"Price Fetcher Not Configured",
+			"The price fetcher was not properly configured. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.priceFetcher = data.PriceFetcher
+}
+
+func (d *EstimateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EstimateDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &data)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Load usage data if usage file path is provided
+	usageMap, err := expandUsageMap(data.UsageFile, data.Usage)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Usage Data Initialization Error",
+			fmt.Sprintf("Failed to initialize usage data: %s", err.Error()),
+		)
+		return
+	}
+
+	// Parse the module or the plan JSON file
+	workingDir := data.WorkingDirectory.ValueString()
+	allParsedResources, _, _, diags := parseEstimateProject(estimateProject{
+		WorkingDirectory: workingDir,
+		PlanJSONFile:     data.PlanJSONFile.ValueString(),
+		VarFiles:         optionalVarFiles(data.VarFile.ValueString()),
+		PricingProfile:   data.PricingProfile.ValueString(),
+	}, usageMap)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	allCostResources, totalCost, err := PriceResources(d.priceFetcher, allParsedResources, data.Discount)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Pricing Data Population Error",
+			fmt.Sprintf("Failed to populate pricing data: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(MissingPriceDiagnostics("", allCostResources, d.priceFetcher.Currency())...)
+	resp.Diagnostics.Append(StalePriceDiagnostics(allCostResources)...)
+
+	if v, err := dynamic.ToDynamic(flattenResources(allCostResources)); err != nil {
+		resp.Diagnostics.AddError(
+			"Resource Flattening Error",
+			fmt.Sprintf("Failed to convert flattened resources to dynamic: %s", err.Error()),
+		)
+		return
+	} else {
+		data.Resources = v
+	}
+
+	data.MonthlyCost = types.NumberValue(decimal.NewFromFloat(totalCost).Round(2).BigFloat())
+	data.Currency = types.StringValue(d.priceFetcher.Currency())
+	data.View = types.StringValue(GenerateConsoleOutput(data.ProjectName.ValueString(), allParsedResources, nil, false, d.priceFetcher.Currency()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,35 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/plancost/terraform-provider-plancost/internal/testcase"
+)
+
+func TestAccEstimateDataSource_Basic(t *testing.T) {
+	wd, _ := os.Getwd()
+	testcase.Test(t, testcase.TestCase{
+		SkipInit: true,
+		Steps: []testcase.TestStep{
+			{
+				ConfigDirectory: path.Join(wd, "testdata", "data_source_basic"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownOutputValue("monthly_cost", testcase.Float64Exact(3.65)),
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,254 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ResourceDiffActionAdded   = "added"
+	ResourceDiffActionRemoved = "removed"
+	ResourceDiffActionChanged = "changed"
+)
+
+// The causes of a resource cost change, in the order they are reported.
+const (
+	ResourceDiffCauseConfig   = "config change"
+	ResourceDiffCauseQuantity = "quantity/usage change"
+	ResourceDiffCausePrice    = "price change"
+)
+
+// ResourceDiffModel describes how the monthly cost of a single resource changed between the prior and the new estimate.
+type ResourceDiffModel struct {
+	Project             string   `json:"project,omitempty"`
+	Name                string   `json:"name"`
+	Action              string   `json:"action"`
+	PreviousMonthlyCost float64  `json:"previous_monthly_cost"`
+	MonthlyCost         float64  `json:"monthly_cost"`
+	MonthlyCostChange   float64  `json:"monthly_cost_change"`
+	Causes              []string `json:"causes"`
+}
+
+// DiffResources compares the prior and new resources by project and address and returns the resources that were added,
+// removed or whose monthly cost changed, sorted by project and address. Resources with an unchanged cost are omitted.
+// Every change is attributed to its causes, see diffCauses.
+func DiffResources(priorResources, newResources []CostResourceModel) []ResourceDiffModel {
+	type resourceKey struct {
+		project string
+		name    string
+	}
+
+	priorMap := make(map[resourceKey]CostResourceModel)
+	for _, r := range priorResources {
+		priorMap[resourceKey{r.Project, r.Name}] = r
+	}
+
+	newMap := make(map[resourceKey]CostResourceModel)
+	for _, r := range newResources {
+		newMap[resourceKey{r.Project, r.Name}] = r
+	}
+
+	diffs := make([]ResourceDiffModel, 0)
+	for key, newRes := range newMap {
+		newCost := roundCost(calculateResourceCost(newRes))
+		prior, hasPrior := priorMap[key]
+		if !hasPrior {
+			diffs = append(diffs, newResourceDiff(key.project, key.name, ResourceDiffActionAdded, 0, newCost, []string{ResourceDiffCauseConfig}))
+			continue
+		}
+		if priorCost := roundCost(calculateResourceCost(prior)); priorCost != newCost {
+			diffs = append(diffs, newResourceDiff(key.project, key.name, ResourceDiffActionChanged, priorCost, newCost, diffCauses(prior, newRes)))
+		}
+	}
+	for key, prior := range priorMap {
+		if _, hasNew := newMap[key]; !hasNew {
+			diffs = append(diffs, newResourceDiff(key.project, key.name, ResourceDiffActionRemoved, roundCost(calculateResourceCost(prior)), 0, []string{ResourceDiffCauseConfig}))
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Project != diffs[j].Project {
+			return diffs[i].Project < diffs[j].Project
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// stableResourceDiffs returns the diff that is kept in state. Against the prior estimate, the diff of an applied
+// estimate would be empty in the next plan, so the estimate would never converge. Instead, when the costs are unchanged
+// since the prior estimate, the diff of the prior estimate is kept, i.e. the diff is against the estimate that the costs
+// last changed from. Without a prior estimate nothing has changed, so the diff is empty.
+func stableResourceDiffs(diffs []ResourceDiffModel, priorResources []CostResourceModel, priorDiffs []ResourceDiffModel) []ResourceDiffModel {
+	if len(priorResources) == 0 {
+		return make([]ResourceDiffModel, 0)
+	}
+	if len(diffs) == 0 && priorDiffs != nil {
+		return priorDiffs
+	}
+	return diffs
+}
+
+func newResourceDiff(project, name, action string, previousCost, cost float64, causes []string) ResourceDiffModel {
+	return ResourceDiffModel{
+		Project:             project,
+		Name:                name,
+		Action:              action,
+		PreviousMonthlyCost: previousCost,
+		MonthlyCost:         cost,
+		MonthlyCostChange:   roundCost(cost - previousCost),
+		Causes:              causes,
+	}
+}
+
+// diffCauses attributes the cost change of a resource to the cost components whose monthly cost changed:
+//   - a component or sub-resource that was added or removed, or whose product, unit or discount changed, is a config change.
+//   - a component whose monthly quantity changed is a quantity/usage change.
+//   - a component whose product is unchanged but whose unit price or price source changed is a price change.
+//
+// The causes are returned in the order above. Components of estimates made before unit prices were stored in state
+// can only be attributed to quantity changes.
+func diffCauses(prior, newRes CostResourceModel) []string {
+	found := make(map[string]bool)
+	collectDiffCauses(found, prior, newRes)
+
+	causes := make([]string, 0, len(found))
+	for _, cause := range []string{ResourceDiffCauseConfig, ResourceDiffCauseQuantity, ResourceDiffCausePrice} {
+		if found[cause] {
+			causes = append(causes, cause)
+		}
+	}
+	return causes
+}
+
+func collectDiffCauses(found map[string]bool, prior, newRes CostResourceModel) {
+	priorComponents := make(map[string]CostComponentModel)
+	for _, c := range prior.CostComponents {
+		priorComponents[c.Name] = c
+	}
+	newComponents := make(map[string]CostComponentModel)
+	for _, c := range newRes.CostComponents {
+		newComponents[c.Name] = c
+		p, ok := priorComponents[c.Name]
+		switch {
+		case !ok:
+			if c.MonthlyCost != 0 {
+				found[ResourceDiffCauseConfig] = true
+			}
+		case roundCost(p.MonthlyCost) != roundCost(c.MonthlyCost):
+			componentDiffCauses(found, p, c)
+		}
+	}
+	for _, c := range prior.CostComponents {
+		if _, ok := newComponents[c.Name]; !ok && c.MonthlyCost != 0 {
+			found[ResourceDiffCauseConfig] = true
+		}
+	}
+
+	priorSubResources := make(map[string]CostResourceModel)
+	for _, r := range prior.SubResources {
+		priorSubResources[r.Name] = r
+	}
+	newSubResources := make(map[string]CostResourceModel)
+	for _, r := range newRes.SubResources {
+		newSubResources[r.Name] = r
+		if p, ok := priorSubResources[r.Name]; ok {
+			collectDiffCauses(found, p, r)
+		} else if calculateResourceCost(r) != 0 {
+			found[ResourceDiffCauseConfig] = true
+		}
+	}
+	for _, r := range prior.SubResources {
+		if _, ok := newSubResources[r.Name]; !ok && calculateResourceCost(r) != 0 {
+			found[ResourceDiffCauseConfig] = true
+		}
+	}
+}
+
+func componentDiffCauses(found map[string]bool, prior, newComponent CostComponentModel) {
+	if prior.MonthlyQuantity != newComponent.MonthlyQuantity {
+		found[ResourceDiffCauseQuantity] = true
+	}
+	// Prior estimates without a unit price don't record the product and price either
+	if prior.Price == "" || newComponent.Price == "" {
+		return
+	}
+	if prior.Unit != newComponent.Unit || prior.Service != newComponent.Service || prior.Region != newComponent.Region ||
+		prior.SKU != newComponent.SKU || prior.Meter != newComponent.Meter || prior.DiscountPercentage != newComponent.DiscountPercentage {
+		found[ResourceDiffCauseConfig] = true
+		return
+	}
+	if !priceEqual(prior.Price, newComponent.Price) || prior.PriceSource != newComponent.PriceSource {
+		found[ResourceDiffCausePrice] = true
+	}
+}
+
+// priceEqual compares two unit prices by value, so that e.g. "0.10" and "0.1" are equal.
+func priceEqual(a, b string) bool {
+	da, errA := decimal.NewFromString(a)
+	db, errB := decimal.NewFromString(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return da.Equal(db)
+}
+
+// address returns the resource address, prefixed with its project for multi-project estimates.
+func (d ResourceDiffModel) address() string {
+	if d.Project == "" {
+		return d.Name
+	}
+	return d.Project + ":" + d.Name
+}
+
+// roundCost rounds a monthly cost to cents so that floating point noise isn't reported as a change.
+func roundCost(cost float64) float64 {
+	return decimal.NewFromFloat(cost).Round(2).InexactFloat64()
+}
+
+// formatCostChange formats a signed cost change, e.g. "+$10.00" or "-$3.65".
+func formatCostChange(change float64, currency string) string {
+	if change < 0 {
+		return "-" + formatAmount(-change, currency)
+	}
+	return "+" + formatAmount(change, currency)
+}
+
+// printConsoleResourceDiffs renders the per-resource cost changes of the view with their causes.
+func printConsoleResourceDiffs(sb *strings.Builder, diffs []ResourceDiffModel, currency string) {
+	if len(diffs) == 0 {
+		return
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString("Cost changes\n")
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf(" %-58s %12s  %s\n", "Name", "Monthly Cost", "Cause"))
+	for _, d := range diffs {
+		sb.WriteString(fmt.Sprintf(" %-58s %12s  %s\n", truncateString(d.address(), 58), formatCostChange(d.MonthlyCostChange, currency), strings.Join(d.Causes, ", ")))
+	}
+}
+
+// printMarkdownResourceDiffs renders the per-resource cost changes of the markdown report with their causes.
+func printMarkdownResourceDiffs(sb *strings.Builder, diffs []ResourceDiffModel, currency string) {
+	if len(diffs) == 0 {
+		return
+	}
+
+	sb.WriteString("\n#### Cost changes\n\n")
+	sb.WriteString("| Resource | Monthly Cost | Cause |\n")
+	sb.WriteString("|:--- |:--- |:--- |\n")
+	for _, d := range diffs {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", d.address(), formatMarkdownCostChange(d.PreviousMonthlyCost, d.MonthlyCost, currency), strings.Join(d.Causes, ", ")))
+	}
+}
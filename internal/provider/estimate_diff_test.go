@@ -0,0 +1,197 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffResources(t *testing.T) {
+	priorResources := []CostResourceModel{
+		{
+			Name:           "azurerm_linux_virtual_machine.resized",
+			CostComponents: []CostComponentModel{{Name: "Instance usage", MonthlyQuantity: "730", Price: "0.096", SKU: "Standard_D2s_v5", MonthlyCost: 70.08}},
+			SubResources: []CostResourceModel{
+				{Name: "os_disk", CostComponents: []CostComponentModel{{Name: "Storage", MonthlyCost: 5.89}}},
+			},
+		},
+		{
+			Name:           "azurerm_public_ip.unchanged",
+			CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}},
+		},
+		{
+			Name:           "azurerm_public_ip.removed",
+			CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}},
+		},
+	}
+
+	newResources := []CostResourceModel{
+		{
+			Name:           "azurerm_linux_virtual_machine.resized",
+			CostComponents: []CostComponentModel{{Name: "Instance usage", MonthlyQuantity: "730", Price: "0.192", SKU: "Standard_D4s_v5", MonthlyCost: 140.16}},
+			SubResources: []CostResourceModel{
+				{Name: "os_disk", CostComponents: []CostComponentModel{{Name: "Storage", MonthlyCost: 5.89}}},
+			},
+		},
+		{
+			Name:           "azurerm_public_ip.unchanged",
+			CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}},
+		},
+		{
+			Name:           "azurerm_public_ip.added",
+			CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}},
+		},
+	}
+
+	assert.Equal(t, []ResourceDiffModel{
+		{Name: "azurerm_linux_virtual_machine.resized", Action: ResourceDiffActionChanged, PreviousMonthlyCost: 75.97, MonthlyCost: 146.05, MonthlyCostChange: 70.08, Causes: []string{ResourceDiffCauseConfig}},
+		{Name: "azurerm_public_ip.added", Action: ResourceDiffActionAdded, PreviousMonthlyCost: 0, MonthlyCost: 3.65, MonthlyCostChange: 3.65, Causes: []string{ResourceDiffCauseConfig}},
+		{Name: "azurerm_public_ip.removed", Action: ResourceDiffActionRemoved, PreviousMonthlyCost: 3.65, MonthlyCost: 0, MonthlyCostChange: -3.65, Causes: []string{ResourceDiffCauseConfig}},
+	}, DiffResources(priorResources, newResources))
+}
+
+func TestDiffResources_NoChanges(t *testing.T) {
+	resources := []CostResourceModel{
+		{Name: "azurerm_public_ip.example", CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}}},
+	}
+
+	assert.Empty(t, DiffResources(resources, resources))
+}
+
+func TestStableResourceDiffs(t *testing.T) {
+	prior := []CostResourceModel{
+		{Name: "azurerm_public_ip.example", CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}}},
+	}
+	added := []ResourceDiffModel{
+		{Name: "azurerm_public_ip.added", Action: ResourceDiffActionAdded, MonthlyCost: 3.65, MonthlyCostChange: 3.65, Causes: []string{ResourceDiffCauseConfig}},
+	}
+	changed := []ResourceDiffModel{
+		{Name: "azurerm_public_ip.example", Action: ResourceDiffActionChanged, PreviousMonthlyCost: 3.65, MonthlyCost: 7.3, MonthlyCostChange: 3.65, Causes: []string{ResourceDiffCauseQuantity}},
+	}
+
+	// The first estimate has nothing to compare with
+	assert.Empty(t, stableResourceDiffs(added, nil, nil))
+	// A cost change replaces the diff of the prior estimate
+	assert.Equal(t, changed, stableResourceDiffs(changed, prior, added))
+	// Without cost changes the diff of the prior estimate is kept, so an applied estimate converges
+	assert.Equal(t, added, stableResourceDiffs([]ResourceDiffModel{}, prior, added))
+	assert.Empty(t, stableResourceDiffs([]ResourceDiffModel{}, prior, nil))
+}
+
+func TestDiffResources_Causes(t *testing.T) {
+	vm := func(price, hours, diskPrice string, instanceCost, diskCost float64) CostResourceModel {
+		return CostResourceModel{
+			Name: "azurerm_linux_virtual_machine.app",
+			CostComponents: []CostComponentModel{
+				{Name: "Instance usage", MonthlyQuantity: hours, Price: price, SKU: "Standard_D2s_v5", PriceHash: "hash-" + price, MonthlyCost: instanceCost},
+				{Name: "Outbound data transfer", MonthlyQuantity: "0", Price: "0.087", UsageBased: true},
+			},
+			SubResources: []CostResourceModel{
+				{Name: "os_disk", CostComponents: []CostComponentModel{{Name: "Storage", MonthlyQuantity: "1", Price: diskPrice, SKU: "P10 LRS", MonthlyCost: diskCost}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		prior    CostResourceModel
+		new      CostResourceModel
+		expected []string
+	}{
+		{
+			name:     "price change",
+			prior:    vm("0.096", "730", "19.71", 70.08, 19.71),
+			new:      vm("0.1", "730", "19.71", 73, 19.71),
+			expected: []string{ResourceDiffCausePrice},
+		},
+		{
+			name:     "quantity change",
+			prior:    vm("0.096", "730", "19.71", 70.08, 19.71),
+			new:      vm("0.096", "365", "19.71", 35.04, 19.71),
+			expected: []string{ResourceDiffCauseQuantity},
+		},
+		{
+			name:     "quantity and sub-resource price change",
+			prior:    vm("0.096", "730", "19.71", 70.08, 19.71),
+			new:      vm("0.096", "365", "21.68", 35.04, 21.68),
+			expected: []string{ResourceDiffCauseQuantity, ResourceDiffCausePrice},
+		},
+		{
+			name:  "price sheet",
+			prior: vm("0.096", "730", "19.71", 70.08, 19.71),
+			new: func() CostResourceModel {
+				r := vm("0.08", "730", "19.71", 58.4, 19.71)
+				r.CostComponents[0].PriceSource = "price_sheet"
+				return r
+			}(),
+			expected: []string{ResourceDiffCausePrice},
+		},
+		{
+			name:  "discount",
+			prior: vm("0.096", "730", "19.71", 70.08, 19.71),
+			new: func() CostResourceModel {
+				r := vm("0.096", "730", "19.71", 63.07, 19.71)
+				r.CostComponents[0].DiscountPercentage = 0.1
+				return r
+			}(),
+			expected: []string{ResourceDiffCauseConfig},
+		},
+		{
+			name:  "added component",
+			prior: vm("0.096", "730", "19.71", 70.08, 19.71),
+			new: func() CostResourceModel {
+				r := vm("0.096", "730", "19.71", 70.08, 19.71)
+				r.CostComponents = append(r.CostComponents, CostComponentModel{Name: "Ultra SSD reservation", MonthlyQuantity: "730", Price: "0.005", MonthlyCost: 3.65})
+				return r
+			}(),
+			expected: []string{ResourceDiffCauseConfig},
+		},
+		{
+			name: "prior estimate without prices",
+			prior: CostResourceModel{
+				Name:           "azurerm_linux_virtual_machine.app",
+				CostComponents: []CostComponentModel{{Name: "Instance usage", MonthlyQuantity: "730", MonthlyCost: 70.08}},
+			},
+			new: CostResourceModel{
+				Name:           "azurerm_linux_virtual_machine.app",
+				CostComponents: []CostComponentModel{{Name: "Instance usage", MonthlyQuantity: "730", Price: "0.1", SKU: "Standard_D2s_v5", MonthlyCost: 73}},
+			},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := DiffResources([]CostResourceModel{tt.prior}, []CostResourceModel{tt.new})
+			if assert.Len(t, diffs, 1) {
+				assert.Equal(t, tt.expected, diffs[0].Causes)
+			}
+		})
+	}
+}
+
+func TestPrintResourceDiffs(t *testing.T) {
+	diffs := []ResourceDiffModel{
+		{Name: "azurerm_linux_virtual_machine.app", Action: ResourceDiffActionChanged, PreviousMonthlyCost: 70.08, MonthlyCost: 73, MonthlyCostChange: 2.92, Causes: []string{ResourceDiffCausePrice}},
+		{Project: "prod", Name: "azurerm_public_ip.removed", Action: ResourceDiffActionRemoved, PreviousMonthlyCost: 3.65, MonthlyCostChange: -3.65, Causes: []string{ResourceDiffCauseConfig}},
+	}
+
+	output := GenerateProjectsConsoleOutput([]ProjectResources{{Name: "main"}}, diffs, nil, nil, true, "USD")
+	assert.Contains(t, output, "Cost changes\n\n Name                                                       Monthly Cost  Cause\n"+
+		" azurerm_linux_virtual_machine.app                                +$2.92  price change\n"+
+		" prod:azurerm_public_ip.removed                                   -$3.65  config change\n")
+
+	var sb strings.Builder
+	printMarkdownResourceDiffs(&sb, diffs, "USD")
+	assert.Equal(t, "\n#### Cost changes\n\n| Resource | Monthly Cost | Cause |\n|:--- |:--- |:--- |\n"+
+		"| azurerm_linux_virtual_machine.app | $70.08 -> $73.00 (+$2.92, 4%) | price change |\n"+
+		"| prod:azurerm_public_ip.removed | $3.65 -> $0.00 (-$3.65, 100%) | config change |\n", sb.String())
+}
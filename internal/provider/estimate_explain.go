@@ -0,0 +1,200 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+// ExplainResourceModel explains how the cost components of a resource were priced.
+type ExplainResourceModel struct {
+	Project        string                  `json:"project,omitempty"`
+	Name           string                  `json:"name"`
+	ResourceType   string                  `json:"resource_type"`
+	Attributes     map[string]interface{}  `json:"attributes"`
+	CostComponents []ExplainComponentModel `json:"cost_components"`
+}
+
+// ExplainComponentModel explains how a cost component was priced: the filters sent to the pricing API, the products it
+// returned, the price that was chosen and why, and how the quantity and the monthly cost were calculated.
+type ExplainComponentModel struct {
+	Name            string                  `json:"name"`
+	SubResource     string                  `json:"sub_resource,omitempty"`
+	ProductFilter   *tfschema.ProductFilter `json:"product_filter,omitempty"`
+	PriceFilter     *tfschema.PriceFilter   `json:"price_filter,omitempty"`
+	Products        []ExplainProductModel   `json:"products"`
+	Price           string                  `json:"price"`
+	PriceHash       string                  `json:"price_hash,omitempty"`
+	PriceReason     string                  `json:"price_reason"`
+	QuantityFormula string                  `json:"quantity_formula"`
+	CostFormula     string                  `json:"cost_formula"`
+}
+
+// ExplainProductModel is a product that the pricing API returned for a cost component, with its prices sorted with the
+// smallest non-zero price first.
+type ExplainProductModel struct {
+	Prices []ExplainPriceModel `json:"prices"`
+}
+
+type ExplainPriceModel struct {
+	PriceHash string `json:"price_hash"`
+	Price     string `json:"price"`
+}
+
+// priceReasonNotLookedUp is the price reason of cost components whose price wasn't looked up, e.g. without a product filter.
+const priceReasonNotLookedUp = "not looked up"
+
+// ExplainResources explains the pricing of the priced resources of a project. The Terraform attributes of a resource are
+// the attributes that its resource function read, taken from the partial resource with the same address.
+func ExplainResources(project string, partialResources []*tfschema.PartialResource, costResources []*tfschema.Resource) []ExplainResourceModel {
+	resourceData := make(map[string]*tfschema.ResourceData, len(partialResources))
+	for _, partial := range partialResources {
+		if partial.ResourceData != nil {
+			resourceData[partial.Address] = partial.ResourceData
+		}
+	}
+
+	explained := make([]ExplainResourceModel, 0, len(costResources))
+	for _, res := range costResources {
+		explained = append(explained, ExplainResourceModel{
+			Project:        project,
+			Name:           res.Name,
+			ResourceType:   res.ResourceType,
+			Attributes:     explainAttributes(resourceData[res.Name]),
+			CostComponents: explainCostComponents(res, ""),
+		})
+	}
+	sort.SliceStable(explained, func(i, j int) bool {
+		return explained[i].Name < explained[j].Name
+	})
+	return explained
+}
+
+// WriteExplainFile writes the explained resources to path as indented JSON.
+func WriteExplainFile(explained []ExplainResourceModel, path string) error {
+	b, err := json.MarshalIndent(explained, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// explainAttributes returns the values of the attributes that were read from the resource data.
+func explainAttributes(d *tfschema.ResourceData) map[string]interface{} {
+	attributes := make(map[string]interface{})
+	if d == nil {
+		return attributes
+	}
+	for _, key := range d.ReadAttributes() {
+		value := d.RawValues.Get(key)
+		if !value.Exists() {
+			// Some attributes are derived, e.g. the region of Azure resources from their location
+			value = d.Get(key)
+		}
+		attributes[key] = value.Value()
+	}
+	return attributes
+}
+
+func explainCostComponents(res *tfschema.Resource, subResource string) []ExplainComponentModel {
+	components := make([]ExplainComponentModel, 0, len(res.CostComponents))
+	for _, c := range res.CostComponents {
+		component := ExplainComponentModel{
+			Name:            c.Name,
+			SubResource:     subResource,
+			ProductFilter:   c.ProductFilter,
+			PriceFilter:     c.PriceFilter,
+			Products:        make([]ExplainProductModel, 0),
+			Price:           c.Price().String(),
+			PriceHash:       c.PriceHash(),
+			PriceReason:     priceReasonNotLookedUp,
+			QuantityFormula: quantityFormula(c),
+			CostFormula:     costFormula(c),
+		}
+		if c.PriceLookup != nil {
+			component.PriceReason = c.PriceLookup.Reason
+			for _, prices := range c.PriceLookup.Products {
+				product := ExplainProductModel{Prices: make([]ExplainPriceModel, 0, len(prices))}
+				for _, p := range prices {
+					product.Prices = append(product.Prices, ExplainPriceModel{PriceHash: p.Hash, Price: p.Price.String()})
+				}
+				component.Products = append(component.Products, product)
+			}
+		}
+		components = append(components, component)
+	}
+
+	for _, sub := range res.SubResources {
+		path := sub.Name
+		if subResource != "" {
+			path = subResource + "." + sub.Name
+		}
+		components = append(components, explainCostComponents(sub, path)...)
+	}
+	return components
+}
+
+// quantityFormula describes how the monthly quantity of a cost component was calculated, e.g.
+// "monthly_quantity = hourly_quantity 1 × 730 hours = 730".
+func quantityFormula(c *tfschema.CostComponent) string {
+	if c.MonthlyQuantity == nil {
+		if c.UsageBased {
+			return "no monthly_quantity, the usage is not set in the usage data"
+		}
+		return "no monthly_quantity"
+	}
+
+	var formula string
+	if c.MonthlyQuantityFromHourly() && c.HourlyQuantity != nil {
+		formula = fmt.Sprintf("monthly_quantity = hourly_quantity %s × %s hours = %s", c.HourlyQuantity.String(), tfschema.HourToMonthUnitMultiplier.String(), c.MonthlyQuantity.String())
+	} else {
+		formula = fmt.Sprintf("monthly_quantity = %s", c.MonthlyQuantity.String())
+	}
+	if c.UsageBased {
+		formula += ", from the usage data"
+	}
+	if !c.UnitMultiplier.IsZero() && !c.UnitMultiplier.Equal(decimal.NewFromInt(1)) {
+		if q := c.UnitMultiplierMonthlyQuantity(); q != nil {
+			formula += fmt.Sprintf("; shown as %s %s = monthly_quantity / %s", q.String(), c.Unit, c.UnitMultiplier.String())
+		}
+	}
+	return formula
+}
+
+// costFormula describes how the monthly cost of a cost component was calculated, e.g.
+// "monthly_cost = price 0.096 × monthly_quantity 730 × (1 - discount 0.1) = 63.072".
+func costFormula(c *tfschema.CostComponent) string {
+	if c.MonthlyQuantity == nil || c.MonthlyCost == nil {
+		return ""
+	}
+
+	terms := []string{
+		fmt.Sprintf("price %s", c.Price().String()),
+		fmt.Sprintf("monthly_quantity %s", c.MonthlyQuantity.String()),
+	}
+	if c.MonthlyDiscountPerc != 0 {
+		terms = append(terms, fmt.Sprintf("(1 - discount %s)", decimal.NewFromFloat(c.MonthlyDiscountPerc).Round(4).String()))
+	}
+	formula := fmt.Sprintf("monthly_cost = %s = %s", strings.Join(terms, " × "), c.MonthlyCost.Round(4).String())
+	if len(c.AppliedDiscounts) > 0 {
+		names := make([]string, 0, len(c.AppliedDiscounts))
+		for _, d := range c.AppliedDiscounts {
+			names = append(names, d.Name)
+		}
+		formula += fmt.Sprintf(", discounts: %s", strings.Join(names, ", "))
+	}
+	return formula
+}
@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform"
+)
+
+func TestExplainResources(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	d := tfschema.NewResourceData("azurerm_linux_virtual_machine", "azurerm", "azurerm_linux_virtual_machine.app", nil,
+		gjson.Parse(`{"size":"Standard_D2s_v5","location":"East US","os_disk":[{"storage_account_type":"Premium_LRS"}],"tags":{"team":"payments"}}`))
+	// The resource function reads the attributes it is priced from
+	d.Get("size")
+	d.Get("region")
+	d.GetStringOrDefault("os_disk.0.storage_account_type", "Standard_LRS")
+
+	hours := decimal.NewFromInt(1)
+	instance := &tfschema.CostComponent{
+		Name:           "Instance usage (Linux, pay as you go, Standard_D2s_v5)",
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: &hours,
+		ProductFilter: &tfschema.ProductFilter{
+			VendorName: strPtr("azure"),
+			Region:     strPtr("eastus"),
+			Service:    strPtr("Virtual Machines"),
+		},
+		PriceFilter: &tfschema.PriceFilter{PurchaseOption: strPtr("Consumption")},
+		PriceLookup: &tfschema.PriceLookup{
+			Products: [][]tfschema.LookupPrice{
+				{{Hash: "a", Price: decimal.RequireFromString("0.096")}},
+				{{Hash: "b", Price: decimal.RequireFromString("0.12")}},
+			},
+			Reason: tfschema.PriceReasonSmallestNonZero,
+		},
+	}
+	instance.SetPrice(decimal.RequireFromString("0.096"))
+	instance.SetPriceHash("a")
+
+	gb := decimal.NewFromInt(2048)
+	egress := &tfschema.CostComponent{
+		Name:            "Outbound data transfer",
+		Unit:            "TB",
+		UnitMultiplier:  decimal.NewFromInt(1024),
+		MonthlyQuantity: &gb,
+		UsageBased:      true,
+		PriceLookup:     &tfschema.PriceLookup{Reason: tfschema.PriceReasonPriceSheet},
+	}
+	egress.SetPrice(decimal.RequireFromString("0.05"))
+
+	disk := &tfschema.CostComponent{Name: "Storage (P10, LRS)", Unit: "months", UnitMultiplier: decimal.NewFromInt(1)}
+
+	res := &tfschema.Resource{
+		Name:           "azurerm_linux_virtual_machine.app",
+		ResourceType:   "azurerm_linux_virtual_machine",
+		CostComponents: []*tfschema.CostComponent{instance, egress},
+		SubResources:   []*tfschema.Resource{{Name: "os_disk", CostComponents: []*tfschema.CostComponent{disk}}},
+	}
+	res.CalculateCosts()
+	instance.MonthlyDiscountPerc = 0.1
+	instance.AppliedDiscounts = []tfschema.AppliedDiscount{{Name: "EA compute"}}
+	instance.CalculateCosts()
+
+	partial := tfschema.NewPartialResource(d, res, nil, nil)
+	explained := ExplainResources("prod", []*tfschema.PartialResource{partial}, []*tfschema.Resource{res})
+
+	assert.Equal(t, []ExplainResourceModel{
+		{
+			Project:      "prod",
+			Name:         "azurerm_linux_virtual_machine.app",
+			ResourceType: "azurerm_linux_virtual_machine",
+			Attributes: map[string]interface{}{
+				"os_disk.0.storage_account_type": "Premium_LRS",
+				"region":                         "eastus",
+				"size":                           "Standard_D2s_v5",
+			},
+			CostComponents: []ExplainComponentModel{
+				{
+					Name:          "Instance usage (Linux, pay as you go, Standard_D2s_v5)",
+					ProductFilter: instance.ProductFilter,
+					PriceFilter:   instance.PriceFilter,
+					Products: []ExplainProductModel{
+						{Prices: []ExplainPriceModel{{PriceHash: "a", Price: "0.096"}}},
+						{Prices: []ExplainPriceModel{{PriceHash: "b", Price: "0.12"}}},
+					},
+					Price:           "0.096",
+					PriceHash:       "a",
+					PriceReason:     tfschema.PriceReasonSmallestNonZero,
+					QuantityFormula: "monthly_quantity = hourly_quantity 1 × 730 hours = 730",
+					CostFormula:     "monthly_cost = price 0.096 × monthly_quantity 730 × (1 - discount 0.1) = 63.072, discounts: EA compute",
+				},
+				{
+					Name:            "Outbound data transfer",
+					Products:        []ExplainProductModel{},
+					Price:           "0.05",
+					PriceReason:     tfschema.PriceReasonPriceSheet,
+					QuantityFormula: "monthly_quantity = 2048, from the usage data; shown as 2 TB = monthly_quantity / 1024",
+					CostFormula:     "monthly_cost = price 0.05 × monthly_quantity 2048 = 102.4",
+				},
+				{
+					Name:            "Storage (P10, LRS)",
+					SubResource:     "os_disk",
+					Products:        []ExplainProductModel{},
+					Price:           "0",
+					PriceReason:     priceReasonNotLookedUp,
+					QuantityFormula: "no monthly_quantity",
+				},
+			},
+		},
+	}, explained)
+
+	path := filepath.Join(t.TempDir(), "explain.json")
+	require.NoError(t, WriteExplainFile(explained, path))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, gjson.ValidBytes(b))
+	assert.Equal(t, "smallest non-zero price", gjson.GetBytes(b, "0.cost_components.0.price_reason").String())
+	assert.Equal(t, "Virtual Machines", gjson.GetBytes(b, "0.cost_components.0.product_filter.service").String())
+}
+
+func TestExplainResources_ReferencedRegion(t *testing.T) {
+	// The runtime has no location, its region is looked up from the data factory it references
+	d := tfschema.NewResourceData("azurerm_data_factory_integration_runtime_self_hosted", "azurerm", "azurerm_data_factory_integration_runtime_self_hosted.shir", nil,
+		gjson.Parse(`{"name":"shir"}`))
+	d.Region = "westeurope"
+
+	registryItem, ok := (*terraform.ResourceRegistryMap)[d.Type]
+	require.True(t, ok)
+	res := registryItem.CoreRFunc(d).BuildResource()
+	res.ResourceType = d.Type
+
+	partial := tfschema.NewPartialResource(d, res, nil, nil)
+	explained := ExplainResources("", []*tfschema.PartialResource{partial}, []*tfschema.Resource{res})
+
+	require.Len(t, explained, 1)
+	assert.Equal(t, "westeurope", explained[0].Attributes["region"])
+}
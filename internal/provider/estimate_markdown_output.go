@@ -9,11 +9,123 @@ package provider
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+
+	"github.com/plancost/terraform-provider-plancost/internal/optimization"
 )
 
-func GenerateMarkdownOutput(priorResources, newResources []CostResourceModel) string {
+func GenerateMarkdownOutput(priorResources, newResources []CostResourceModel, tagCosts []TagCostModel, recommendations []optimization.OptimizationRecommendation, currency string) string {
+	totalPriorCost := 0.0
+	for _, r := range priorResources {
+		totalPriorCost += calculateResourceCost(r)
+	}
+
+	totalNewCost := 0.0
+	for _, r := range newResources {
+		totalNewCost += calculateResourceCost(r)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### PlanCost Report\n\n")
+
+	diff := totalNewCost - totalPriorCost
+	pct := 0.0
+	if totalPriorCost > 0 {
+		pct = (diff / totalPriorCost) * 100
+	} else if totalNewCost > 0 {
+		pct = 100
+	}
+
+	if diff > 0 {
+		sb.WriteString(fmt.Sprintf("💰 Monthly cost will increase by %s (%.0f%%).\n\n", formatCost(diff, currency), pct))
+	} else if diff < 0 {
+		sb.WriteString(fmt.Sprintf("💰 Monthly cost will decrease by %s (%.0f%%).\n\n", formatCost(-diff, currency), -pct))
+	} else {
+		sb.WriteString("💰 Monthly cost will remain unchanged.\n\n")
+	}
+
+	// Without a prior estimate every resource is added, so the cost changes are only listed against a prior estimate
+	diffs := make([]ResourceDiffModel, 0)
+	if len(priorResources) > 0 {
+		diffs = DiffResources(priorResources, newResources)
+	}
+
+	projectNames := markdownProjectNames(priorResources, newResources)
+	if len(projectNames) == 1 && projectNames[0] == "" {
+		printMarkdownTable(&sb, priorResources, newResources, "Total", currency)
+		printMarkdownResourceDiffs(&sb, diffs, currency)
+		printMarkdownTagCosts(&sb, tagCosts, currency)
+		printMarkdownRecommendations(&sb, recommendations, currency)
+		return sb.String()
+	}
+
+	// Multi-project estimates get a table per project followed by the overall total
+	for _, project := range projectNames {
+		displayName := project
+		if displayName == "" {
+			displayName = "main"
+		}
+		sb.WriteString(fmt.Sprintf("#### Project: %s\n\n", displayName))
+		printMarkdownTable(&sb, filterProjectResources(priorResources, project), filterProjectResources(newResources, project), "Project total", currency)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("**Overall total: %s**\n", formatMarkdownCostChange(totalPriorCost, totalNewCost, currency)))
+	printMarkdownResourceDiffs(&sb, diffs, currency)
+	printMarkdownTagCosts(&sb, tagCosts, currency)
+	printMarkdownRecommendations(&sb, recommendations, currency)
+
+	return sb.String()
+}
+
+// printMarkdownRecommendations renders the optimization recommendations of the markdown report, grouped by resource.
+func printMarkdownRecommendations(sb *strings.Builder, recommendations []optimization.OptimizationRecommendation, currency string) {
+	var teasers, details []optimization.OptimizationRecommendation
+	for _, rec := range recommendations {
+		switch rec.Type {
+		case "Teaser":
+			teasers = append(teasers, rec)
+		case "Reservation", "SavingsPlan", "Advisory", RecommendationRuleType:
+			details = append(details, rec)
+		}
+	}
+	if len(teasers) == 0 && len(details) == 0 {
+		return
+	}
+
+	sb.WriteString("\n#### Optimization recommendations\n\n")
+	if len(details) > 0 {
+		sb.WriteString("| Resource | Recommendation | Monthly Savings |\n")
+		sb.WriteString("|:--- |:--- |:--- |\n")
+		grouped, keys := optimization.GroupOptimizations(details)
+		for _, resAddr := range keys {
+			for _, opt := range grouped[resAddr] {
+				recommendation := strings.TrimSuffix(opt.Description, ".")
+				switch opt.Type {
+				case "Reservation":
+					recommendation = strings.Replace(opt.Term, " yr", "-Year", 1) + " Reservation"
+				case "SavingsPlan":
+					recommendation = fmt.Sprintf("%s Savings Plan (%s/hour commitment)", strings.Replace(opt.Term, " yr", "-Year", 1), formatCost(opt.HourlyCommitment, currency))
+				}
+
+				savings := "-"
+				if opt.SavingsAmount > 0 {
+					savings = fmt.Sprintf("%s (%.0f%%)", formatCost(opt.SavingsAmount, currency), math.Round(opt.SavingsPercentage*100))
+				}
+				sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", resAddr, strings.ReplaceAll(recommendation, "|", "\\|"), savings))
+			}
+		}
+	}
+	if len(teasers) > 0 {
+		if len(details) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(strings.ReplaceAll(strings.TrimSpace(teasers[0].Description), "\n", " ") + "\n")
+	}
+}
+
+func printMarkdownTable(sb *strings.Builder, priorResources, newResources []CostResourceModel, totalLabel string, currency string) {
 	priorMap := make(map[string]CostResourceModel)
 	totalPriorCost := 0.0
 	for _, r := range priorResources {
@@ -43,25 +155,6 @@ func GenerateMarkdownOutput(priorResources, newResources []CostResourceModel) st
 	}
 	sort.Strings(sortedNames)
 
-	var sb strings.Builder
-	sb.WriteString("### PlanCost Report\n\n")
-
-	diff := totalNewCost - totalPriorCost
-	pct := 0.0
-	if totalPriorCost > 0 {
-		pct = (diff / totalPriorCost) * 100
-	} else if totalNewCost > 0 {
-		pct = 100
-	}
-
-	if diff > 0 {
-		sb.WriteString(fmt.Sprintf("💰 Monthly cost will increase by $%.2f (%.0f%%).\n\n", diff, pct))
-	} else if diff < 0 {
-		sb.WriteString(fmt.Sprintf("💰 Monthly cost will decrease by $%.2f (%.0f%%).\n\n", -diff, -pct))
-	} else {
-		sb.WriteString("💰 Monthly cost will remain unchanged.\n\n")
-	}
-
 	sb.WriteString("| Name | Monthly Qty | Unit | Monthly Cost |\n")
 	sb.WriteString("|:--- |:--- |:--- |:--- |\n")
 
@@ -72,11 +165,11 @@ func GenerateMarkdownOutput(priorResources, newResources []CostResourceModel) st
 		if !hasPrior && hasNew {
 			// Added resource
 			sb.WriteString(fmt.Sprintf("| + **%s** | | | |\n", name))
-			printMarkdownDiffTree(&sb, nil, nil, newRes.CostComponents, newRes.SubResources, 0)
+			printMarkdownDiffTree(sb, nil, nil, newRes.CostComponents, newRes.SubResources, 0, currency)
 		} else if hasPrior && !hasNew {
 			// Deleted resource
 			sb.WriteString(fmt.Sprintf("| - ~~%s~~ | | | |\n", name))
-			printMarkdownDiffTree(&sb, prior.CostComponents, prior.SubResources, nil, nil, 0)
+			printMarkdownDiffTree(sb, prior.CostComponents, prior.SubResources, nil, nil, 0, currency)
 		} else {
 			// Modified or Unchanged
 			priorCost := calculateResourceCost(prior)
@@ -86,23 +179,65 @@ func GenerateMarkdownOutput(priorResources, newResources []CostResourceModel) st
 				icon = "~ "
 			}
 			sb.WriteString(fmt.Sprintf("| %s**%s** | | | |\n", icon, name))
-			printMarkdownDiffTree(&sb, prior.CostComponents, prior.SubResources, newRes.CostComponents, newRes.SubResources, 0)
+			printMarkdownDiffTree(sb, prior.CostComponents, prior.SubResources, newRes.CostComponents, newRes.SubResources, 0, currency)
 		}
 	}
 
+	sb.WriteString(fmt.Sprintf("| **%s** | | | **%s** |\n", totalLabel, formatMarkdownCostChange(totalPriorCost, totalNewCost, currency)))
+}
+
+// formatMarkdownCostChange formats a cost change as e.g. "$10.00 -> $15.00 (+$5.00, 50%)".
+func formatMarkdownCostChange(priorCost, newCost float64, currency string) string {
+	diff := newCost - priorCost
+	pct := 0.0
+	if priorCost > 0 {
+		pct = (diff / priorCost) * 100
+	} else if newCost > 0 {
+		pct = 100
+	}
+
 	diffStr := ""
 	if diff > 0 {
-		diffStr = fmt.Sprintf(" (+$%.2f, %.0f%%)", diff, pct)
+		diffStr = fmt.Sprintf(" (+%s, %.0f%%)", formatCost(diff, currency), pct)
 	} else if diff < 0 {
-		diffStr = fmt.Sprintf(" (-$%.2f, %.0f%%)", -diff, -pct)
+		diffStr = fmt.Sprintf(" (-%s, %.0f%%)", formatCost(-diff, currency), -pct)
 	}
 
-	sb.WriteString(fmt.Sprintf("| **Total** | | | **$%.2f -> $%.2f%s** |\n", totalPriorCost, totalNewCost, diffStr))
+	return fmt.Sprintf("%s -> %s%s", formatCost(priorCost, currency), formatCost(newCost, currency), diffStr)
+}
 
-	return sb.String()
+// markdownProjectNames returns the sorted names of the projects the resources belong to.
+func markdownProjectNames(priorResources, newResources []CostResourceModel) []string {
+	names := make(map[string]bool)
+	for _, r := range priorResources {
+		names[r.Project] = true
+	}
+	for _, r := range newResources {
+		names[r.Project] = true
+	}
+	if len(names) == 0 {
+		return []string{""}
+	}
+
+	sortedNames := make([]string, 0, len(names))
+	for k := range names {
+		sortedNames = append(sortedNames, k)
+	}
+	sort.Strings(sortedNames)
+	return sortedNames
+}
+
+func filterProjectResources(resources []CostResourceModel, project string) []CostResourceModel {
+	filtered := make([]CostResourceModel, 0)
+	for _, r := range resources {
+		if r.Project == project {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
 }
 
-func printMarkdownDiffTree(sb *strings.Builder, priorComponents []CostComponentModel, priorSubResources []CostResourceModel, newComponents []CostComponentModel, newSubResources []CostResourceModel, level int) {
+func printMarkdownDiffTree(sb *strings.Builder, priorComponents []CostComponentModel, priorSubResources []CostResourceModel, newComponents []CostComponentModel, newSubResources []CostResourceModel, level int, currency string) {
 	prefix := strings.Repeat("&nbsp;&nbsp;&nbsp;&nbsp;", level+1)
 
 	// Map components by name
@@ -135,10 +270,10 @@ func printMarkdownDiffTree(sb *strings.Builder, priorComponents []CostComponentM
 
 		if !hasPrior && hasNew {
 			// Added component
-			fmt.Fprintf(sb, "| %s+ %s | %s | %s | $%.2f |\n", prefix, name, newCC.MonthlyQuantity, newCC.Unit, newCC.MonthlyCost)
+			fmt.Fprintf(sb, "| %s+ %s | %s | %s | %s |\n", prefix, name, newCC.MonthlyQuantity, newCC.Unit, formatCost(newCC.MonthlyCost, currency))
 		} else if hasPrior && !hasNew {
 			// Deleted component
-			fmt.Fprintf(sb, "| %s- ~~%s~~ | | | ~~$%.2f~~ |\n", prefix, name, prior.MonthlyCost)
+			fmt.Fprintf(sb, "| %s- ~~%s~~ | | | ~~%s~~ |\n", prefix, name, formatCost(prior.MonthlyCost, currency))
 		} else {
 			// Modified or Unchanged
 			qtyDiff := ""
@@ -158,9 +293,9 @@ func printMarkdownDiffTree(sb *strings.Builder, priorComponents []CostComponentM
 					sign = "-"
 					diff = -diff
 				}
-				costDiff = fmt.Sprintf("$%.2f -> $%.2f (%s$%.2f)", prior.MonthlyCost, newCC.MonthlyCost, sign, diff)
+				costDiff = fmt.Sprintf("%s -> %s (%s%s)", formatCost(prior.MonthlyCost, currency), formatCost(newCC.MonthlyCost, currency), sign, formatCost(diff, currency))
 			} else {
-				costDiff = fmt.Sprintf("$%.2f", newCC.MonthlyCost)
+				costDiff = formatCost(newCC.MonthlyCost, currency)
 			}
 
 			if changed {
@@ -200,10 +335,10 @@ func printMarkdownDiffTree(sb *strings.Builder, priorComponents []CostComponentM
 
 		if !hasPrior && hasNew {
 			fmt.Fprintf(sb, "| %s+ %s | | | |\n", prefix, name)
-			printMarkdownDiffTree(sb, nil, nil, newSub.CostComponents, newSub.SubResources, level+1)
+			printMarkdownDiffTree(sb, nil, nil, newSub.CostComponents, newSub.SubResources, level+1, currency)
 		} else if hasPrior && !hasNew {
 			fmt.Fprintf(sb, "| %s- ~~%s~~ | | | |\n", prefix, name)
-			printMarkdownDiffTree(sb, prior.CostComponents, prior.SubResources, nil, nil, level+1)
+			printMarkdownDiffTree(sb, prior.CostComponents, prior.SubResources, nil, nil, level+1, currency)
 		} else {
 			priorCost := calculateResourceCost(prior)
 			newCost := calculateResourceCost(newSub)
@@ -212,7 +347,7 @@ func printMarkdownDiffTree(sb *strings.Builder, priorComponents []CostComponentM
 				icon = "~ "
 			}
 			fmt.Fprintf(sb, "| %s%s%s | | | |\n", prefix, icon, name)
-			printMarkdownDiffTree(sb, prior.CostComponents, prior.SubResources, newSub.CostComponents, newSub.SubResources, level+1)
+			printMarkdownDiffTree(sb, prior.CostComponents, prior.SubResources, newSub.CostComponents, newSub.SubResources, level+1, currency)
 		}
 	}
 }
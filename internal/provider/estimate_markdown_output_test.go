@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/plancost/terraform-provider-plancost/internal/optimization"
 )
 
 func TestGenerateMarkdownDiff(t *testing.T) {
@@ -67,7 +69,7 @@ func TestGenerateMarkdownDiff(t *testing.T) {
 	}
 
 	// Call the function
-	markdown := GenerateMarkdownOutput(priorResources, newResources)
+	markdown := GenerateMarkdownOutput(priorResources, newResources, nil, nil, "USD")
 
 	// Verify output
 	expectedStrings := []string{
@@ -238,7 +240,7 @@ func TestGenerateMarkdownDiff_Complex(t *testing.T) {
 	}
 
 	// Call the function
-	markdown := GenerateMarkdownOutput(priorResources, newResources)
+	markdown := GenerateMarkdownOutput(priorResources, newResources, nil, nil, "USD")
 
 	// Verify output
 	expectedStrings := []string{
@@ -282,3 +284,155 @@ func TestGenerateMarkdownDiff_Complex(t *testing.T) {
 		fmt.Println(s)
 	}
 }
+
+func TestGenerateMarkdownDiff_Projects(t *testing.T) {
+	priorResources := []CostResourceModel{
+		{
+			Project: "dev",
+			Name:    "azurerm_public_ip.example[0]",
+			CostComponents: []CostComponentModel{
+				{Name: "IP address", MonthlyQuantity: "730", Unit: "hours", MonthlyCost: 3.65},
+			},
+		},
+	}
+
+	newResources := []CostResourceModel{
+		{
+			Project: "dev",
+			Name:    "azurerm_public_ip.example[0]",
+			CostComponents: []CostComponentModel{
+				{Name: "IP address", MonthlyQuantity: "730", Unit: "hours", MonthlyCost: 3.65},
+			},
+		},
+		{
+			Project: "prod",
+			Name:    "azurerm_public_ip.example[0]",
+			CostComponents: []CostComponentModel{
+				{Name: "IP address", MonthlyQuantity: "730", Unit: "hours", MonthlyCost: 3.65},
+			},
+		},
+	}
+
+	markdown := GenerateMarkdownOutput(priorResources, newResources, nil, nil, "USD")
+
+	expectedStrings := []string{
+		"💰 Monthly cost will increase by $3.65 (100%).",
+		"#### Project: dev\n",
+		"| **Project total** | | | **$3.65 -> $3.65** |",
+		"#### Project: prod\n",
+		"| + **azurerm_public_ip.example[0]** | | | |",
+		"| **Project total** | | | **$0.00 -> $3.65 (+$3.65, 100%)** |",
+		"**Overall total: $3.65 -> $7.30 (+$3.65, 100%)**",
+	}
+
+	for _, s := range expectedStrings {
+		if !strings.Contains(markdown, s) {
+			t.Errorf("Markdown output missing expected string: %q\nGot:\n%s", s, markdown)
+		}
+	}
+
+	if strings.Index(markdown, "#### Project: dev") > strings.Index(markdown, "#### Project: prod") {
+		t.Errorf("Projects are not sorted by name:\n%s", markdown)
+	}
+}
+
+func TestGenerateMarkdownDiff_Currency(t *testing.T) {
+	priorResources := []CostResourceModel{
+		{
+			Name: "azurerm_public_ip.example",
+			CostComponents: []CostComponentModel{
+				{Name: "IP address", MonthlyQuantity: "730", Unit: "hours", MonthlyCost: 3.40},
+			},
+		},
+	}
+
+	newResources := []CostResourceModel{
+		{
+			Name: "azurerm_public_ip.example",
+			CostComponents: []CostComponentModel{
+				{Name: "IP address", MonthlyQuantity: "1460", Unit: "hours", MonthlyCost: 6.80},
+			},
+		},
+	}
+
+	markdown := GenerateMarkdownOutput(priorResources, newResources, nil, nil, "GBP")
+
+	expectedStrings := []string{
+		"💰 Monthly cost will increase by £3.40 (100%).",
+		"| &nbsp;&nbsp;&nbsp;&nbsp;~ IP address | 730 -> 1460 | hours | £3.40 -> £6.80 (+£3.40) |",
+		"| **Total** | | | **£3.40 -> £6.80 (+£3.40, 100%)** |",
+	}
+
+	for _, s := range expectedStrings {
+		if !strings.Contains(markdown, s) {
+			t.Errorf("Markdown output missing expected string: %q\nGot:\n%s", s, markdown)
+		}
+	}
+
+	if strings.Contains(markdown, "$") {
+		t.Errorf("Markdown output contains a dollar sign:\n%s", markdown)
+	}
+}
+
+func TestGenerateMarkdownDiff_Recommendations(t *testing.T) {
+	resources := []CostResourceModel{
+		{
+			Name: "azurerm_managed_disk.data",
+			CostComponents: []CostComponentModel{
+				{Name: "Storage (P10, LRS)", MonthlyQuantity: "1", Unit: "months", MonthlyCost: 19.71},
+			},
+		},
+	}
+	recommendations := []optimization.OptimizationRecommendation{
+		{
+			ResourceAddress:   "azurerm_managed_disk.data",
+			Description:       "Consider updating Premium SSD disk to Premium SSD v2 for better performance and cost",
+			Type:              "Advisory",
+			SavingsAmount:     9.24,
+			SavingsPercentage: 0.47,
+		},
+		{
+			ResourceAddress: "azurerm_managed_disk.data",
+			Description:     "Check that the disk is still in use.",
+			Type:            "Custom",
+		},
+		{
+			ResourceAddress:   "azurerm_linux_virtual_machine.vm",
+			Type:              "Reservation",
+			Term:              "1 yr",
+			SavingsAmount:     369.48,
+			SavingsPercentage: 0.58,
+		},
+		{
+			ResourceAddress:   optimization.SavingsPlanAddress,
+			Type:              "SavingsPlan",
+			Term:              "3 yr",
+			SavingsAmount:     131.33,
+			SavingsPercentage: 0.41,
+			HourlyCommitment:  0.2641,
+		},
+		{
+			ResourceAddress: "azurerm_linux_virtual_machine.vm",
+			Description:     "Consider using burstable VM sizes for dev/test",
+			Type:            "Info",
+		},
+	}
+
+	markdown := GenerateMarkdownOutput(nil, resources, nil, recommendations, "USD")
+
+	expected := `#### Optimization recommendations
+
+| Resource | Recommendation | Monthly Savings |
+|:--- |:--- |:--- |
+| Savings Plan for Compute | 3-Year Savings Plan ($0.26/hour commitment) | $131.33 (41%) |
+| azurerm_linux_virtual_machine.vm | 1-Year Reservation | $369.48 (58%) |
+| azurerm_managed_disk.data | Consider updating Premium SSD disk to Premium SSD v2 for better performance and cost | $9.24 (47%) |
+| azurerm_managed_disk.data | Check that the disk is still in use | - |
+`
+	if !strings.Contains(markdown, expected) {
+		t.Errorf("Markdown output missing recommendations:\n%s\nGot:\n%s", expected, markdown)
+	}
+	if strings.Contains(markdown, "burstable") {
+		t.Errorf("Markdown output contains an unsupported recommendation type:\n%s", markdown)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/plancost/terraform-provider-plancost/internal/hclparser/hcl"
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
+)
+
+type ProjectModel struct {
+	Name             types.String `tfsdk:"name"`
+	WorkingDirectory types.String `tfsdk:"working_directory"`
+	VarFile          types.String `tfsdk:"var_file"`
+	PricingProfile   types.String `tfsdk:"pricing_profile"`
+}
+
+// ProjectCostModel is the monthly cost subtotal of a single project of an estimate.
+type ProjectCostModel struct {
+	Name        string  `json:"name"`
+	MonthlyCost float64 `json:"monthly_cost"`
+}
+
+// estimateProject is a Terraform root module, or a plan JSON file, that is estimated as one project.
+type estimateProject struct {
+	Name             string
+	WorkingDirectory string
+	PlanJSONFile     string
+	VarFiles         []string
+	PricingProfile   string
+}
+
+// expandProjects returns the projects to estimate: the `projects` blocks if any are set, the projects discovered
+// below `working_directory` if `auto_detect_projects` is enabled, and otherwise the single configured module or plan.
+func expandProjects(config *EstimateResourceModel) []estimateProject {
+	pricingProfile := config.PricingProfile.ValueString()
+
+	if len(config.Projects) > 0 {
+		projects := make([]estimateProject, 0, len(config.Projects))
+		for _, p := range config.Projects {
+			workingDir := p.WorkingDirectory.ValueString()
+			name := p.Name.ValueString()
+			if name == "" {
+				name = filepath.Base(workingDir)
+			}
+			projectPricingProfile := pricingProfile
+			if p.PricingProfile.ValueString() != "" {
+				projectPricingProfile = p.PricingProfile.ValueString()
+			}
+			projects = append(projects, estimateProject{
+				Name:             name,
+				WorkingDirectory: workingDir,
+				VarFiles:         optionalVarFiles(p.VarFile.ValueString()),
+				PricingProfile:   projectPricingProfile,
+			})
+		}
+		return projects
+	}
+
+	if config.AutoDetectProjects.ValueBool() {
+		projects := discoverProjects(config.WorkingDirectory.ValueString())
+		for i := range projects {
+			projects[i].PricingProfile = pricingProfile
+		}
+		return projects
+	}
+
+	return []estimateProject{
+		{
+			Name:             config.ProjectName.ValueString(),
+			WorkingDirectory: config.WorkingDirectory.ValueString(),
+			PlanJSONFile:     config.PlanJSONFile.ValueString(),
+			VarFiles:         optionalVarFiles(config.VarFile.ValueString()),
+			PricingProfile:   pricingProfile,
+		},
+	}
+}
+
+// discoverProjects finds every Terraform root module below rootPath. A root module with environment specific var
+// files (e.g. dev.tfvars and prod.tfvars) is returned once per environment.
+func discoverProjects(rootPath string) []estimateProject {
+	locator := hcl.NewProjectLocator(logging.Logger, &hcl.ProjectLocatorConfig{})
+	rootPaths, _ := locator.FindRootModules(rootPath)
+
+	projects := make([]estimateProject, 0, len(rootPaths))
+	for _, rootPath := range rootPaths {
+		name := rootPath.RelPath()
+		if name == "" || name == "." {
+			name = filepath.Base(rootPath.DetectedPath)
+		}
+
+		// terraform.tfvars is always loaded from the working directory, so it's not repeated here.
+		baseVarFiles := make([]string, 0)
+		for _, f := range append(rootPath.GlobalFiles(), rootPath.AutoFiles()...) {
+			if f.FullPath == filepath.Join(rootPath.DetectedPath, "terraform.tfvars") {
+				continue
+			}
+			baseVarFiles = append(baseVarFiles, f.FullPath)
+		}
+
+		envs := rootPath.EnvGroupings()
+		if len(envs) == 0 {
+			projects = append(projects, estimateProject{
+				Name:             name,
+				WorkingDirectory: rootPath.DetectedPath,
+				VarFiles:         baseVarFiles,
+			})
+			continue
+		}
+
+		for _, env := range envs {
+			varFiles := append([]string{}, baseVarFiles...)
+			for _, f := range env.TerraformVarFiles {
+				varFiles = append(varFiles, f.FullPath)
+			}
+			projects = append(projects, estimateProject{
+				Name:             name + "-" + env.Name,
+				WorkingDirectory: rootPath.DetectedPath,
+				VarFiles:         varFiles,
+			})
+		}
+	}
+	return projects
+}
+
+func optionalVarFiles(varFile string) []string {
+	if varFile == "" {
+		return nil
+	}
+	return []string{varFile}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverProjects(t *testing.T) {
+	wd, _ := os.Getwd()
+	root := path.Join(wd, "testdata", "multi_project")
+
+	// The shared module and the root directory without a provider block are not projects.
+	assert.Equal(t, []estimateProject{
+		{Name: "envs/dev", WorkingDirectory: path.Join(root, "envs", "dev"), VarFiles: []string{}},
+		{Name: "envs/prod", WorkingDirectory: path.Join(root, "envs", "prod"), VarFiles: []string{}},
+	}, discoverProjects(root))
+}
+
+func TestDiscoverProjects_EnvVarFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "main.tf"), "provider \"azurerm\" {\n  features {}\n}\n")
+	writeFile(t, filepath.Join(root, "dev.tfvars"), "ip_count = 1\n")
+	writeFile(t, filepath.Join(root, "prod.tfvars"), "ip_count = 2\n")
+
+	name := filepath.Base(root)
+	assert.Equal(t, []estimateProject{
+		{Name: name + "-dev", WorkingDirectory: root, VarFiles: []string{filepath.Join(root, "dev.tfvars")}},
+		{Name: name + "-prod", WorkingDirectory: root, VarFiles: []string{filepath.Join(root, "prod.tfvars")}},
+	}, discoverProjects(root))
+}
+
+func TestExpandProjects(t *testing.T) {
+	projects := expandProjects(&EstimateResourceModel{
+		Projects: []ProjectModel{
+			{WorkingDirectory: types.StringValue("/infra/envs/dev"), VarFile: types.StringValue("/infra/dev.tfvars")},
+			{Name: types.StringValue("production"), WorkingDirectory: types.StringValue("/infra/envs/prod")},
+		},
+	})
+
+	assert.Equal(t, []estimateProject{
+		{Name: "dev", WorkingDirectory: "/infra/envs/dev", VarFiles: []string{"/infra/dev.tfvars"}},
+		{Name: "production", WorkingDirectory: "/infra/envs/prod"},
+	}, projects)
+}
+
+func TestExpandProjects_PricingProfile(t *testing.T) {
+	projects := expandProjects(&EstimateResourceModel{
+		PricingProfile: types.StringValue("hybrid_benefit_windows"),
+		Projects: []ProjectModel{
+			{WorkingDirectory: types.StringValue("/infra/envs/dev"), PricingProfile: types.StringValue("dev_test")},
+			{WorkingDirectory: types.StringValue("/infra/envs/prod")},
+		},
+	})
+
+	assert.Equal(t, []estimateProject{
+		{Name: "dev", WorkingDirectory: "/infra/envs/dev", PricingProfile: "dev_test"},
+		{Name: "prod", WorkingDirectory: "/infra/envs/prod", PricingProfile: "hybrid_benefit_windows"},
+	}, projects)
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
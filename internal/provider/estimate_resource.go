@@ -10,13 +10,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/numbervalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -27,6 +33,7 @@ import (
 	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
 	"github.com/plancost/terraform-provider-plancost/internal/dynamic"
 	"github.com/plancost/terraform-provider-plancost/internal/hclparser/hcl"
+	"github.com/plancost/terraform-provider-plancost/internal/optimization"
 	"github.com/plancost/terraform-provider-plancost/internal/prices"
 	"github.com/plancost/terraform-provider-plancost/internal/provider/myvalidator"
 	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
@@ -36,6 +43,7 @@ import (
 
 var _ resource.Resource = &EstimateResource{}
 var _ resource.ResourceWithModifyPlan = &EstimateResource{}
+var _ resource.ResourceWithValidateConfig = &EstimateResource{}
 
 func NewEstimateResource() resource.Resource {
 	return &EstimateResource{}
@@ -50,25 +58,40 @@ type EstimateResource struct {
 // EstimateResourceModel describes the resource data model.
 type EstimateResourceModel struct {
 	WorkingDirectory types.String `tfsdk:"working_directory"`
+	PlanJSONFile     types.String `tfsdk:"plan_json_file"`
 	ProjectName      types.String `tfsdk:"project_name"`
 
 	UsageFile types.String  `tfsdk:"usage_file"`
 	Usage     types.Dynamic `tfsdk:"usage"`
 	VarFile   types.String  `tfsdk:"var_file"`
 
+	PricingProfile types.String `tfsdk:"pricing_profile"`
+
+	Projects           []ProjectModel `tfsdk:"projects"`
+	AutoDetectProjects types.Bool     `tfsdk:"auto_detect_projects"`
+
 	Resources     types.Dynamic        `tfsdk:"resources"`
+	ProjectCosts  types.Dynamic        `tfsdk:"project_costs"`
+	CostByTag     types.Dynamic        `tfsdk:"cost_by_tag"`
+	Diff          types.Dynamic        `tfsdk:"diff"`
 	MonthlyCost   types.Number         `tfsdk:"monthly_cost"`
+	Currency      types.String         `tfsdk:"currency"`
 	View          types.String         `tfsdk:"view"`
 	Id            types.String         `tfsdk:"id"`
 	Guardrail     []GuardrailModel     `tfsdk:"guardrail"`
 	Discount      []DiscountModel      `tfsdk:"discount"`
 	TaggingPolicy []TaggingPolicyModel `tfsdk:"tagging_policy"`
 
+	CostAllocation     []CostAllocationModel     `tfsdk:"cost_allocation"`
+	RecommendationRule []RecommendationRuleModel `tfsdk:"recommendation_rule"`
+
 	RecommendationsEnabled types.Bool `tfsdk:"recommendations_enabled"`
 	Recommendations        types.List `tfsdk:"recommendations"`
 
-	ExportMarkdownFile types.String `tfsdk:"export_markdown_file"`
-	ExportUsageFile    types.String `tfsdk:"export_usage_file"`
+	ExportMarkdownFile        types.String `tfsdk:"export_markdown_file"`
+	ExportPricingSnapshotFile types.String `tfsdk:"export_pricing_snapshot_file"`
+	ExportUsageFile           types.String `tfsdk:"export_usage_file"`
+	ExportExplainFile         types.String `tfsdk:"export_explain_file"`
 }
 
 type TaggingPolicyModel struct {
@@ -80,27 +103,62 @@ type TaggingPolicyModel struct {
 }
 
 type DiscountModel struct {
-	Percentage   types.Number `tfsdk:"percentage"`
-	ResourceType types.String `tfsdk:"resource_type"`
+	Name          types.String            `tfsdk:"name"`
+	Percentage    types.Number            `tfsdk:"percentage"`
+	Amount        types.Number            `tfsdk:"amount"`
+	ResourceType  types.String            `tfsdk:"resource_type"`
+	Address       types.String            `tfsdk:"address"`
+	Tags          map[string]types.String `tfsdk:"tags"`
+	CostComponent types.String            `tfsdk:"cost_component"`
+	Region        types.String            `tfsdk:"region"`
+	StartDate     types.String            `tfsdk:"start_date"`
+	EndDate       types.String            `tfsdk:"end_date"`
 }
 
 type GuardrailModel struct {
-	Condition types.String `tfsdk:"condition"`
-	Threshold types.Number `tfsdk:"threshold"`
-	Action    types.String `tfsdk:"action"`
+	Condition    types.String            `tfsdk:"condition"`
+	Threshold    types.Number            `tfsdk:"threshold"`
+	Action       types.String            `tfsdk:"action"`
+	ResourceType types.String            `tfsdk:"resource_type"`
+	Address      types.String            `tfsdk:"address"`
+	Tags         map[string]types.String `tfsdk:"tags"`
+
+	AllowedUnestimatedResourceTypes []types.String `tfsdk:"allowed_unestimated_resource_types"`
 }
 
 type CostResourceModel struct {
-	Name           string               `json:"name"`
-	CostComponents []CostComponentModel `json:"cost_components"`
-	SubResources   []CostResourceModel  `json:"sub_resources"`
+	Project             string               `json:"project,omitempty"`
+	Name                string               `json:"name"`
+	ResourceType        string               `json:"resource_type,omitempty"`
+	Tags                map[string]string    `json:"tags,omitempty"`
+	BaselineMonthlyCost float64              `json:"baseline_monthly_cost"`
+	UsageMonthlyCost    float64              `json:"usage_monthly_cost"`
+	CostComponents      []CostComponentModel `json:"cost_components"`
+	SubResources        []CostResourceModel  `json:"sub_resources"`
 }
 
 type CostComponentModel struct {
-	Name            string  `json:"name"`
-	MonthlyQuantity string  `json:"monthly_quantity"`
-	Unit            string  `json:"unit"`
-	MonthlyCost     float64 `json:"monthly_cost"`
+	Name               string                 `json:"name"`
+	MonthlyQuantity    string                 `json:"monthly_quantity"`
+	Unit               string                 `json:"unit"`
+	Price              string                 `json:"price"`
+	HourlyCost         float64                `json:"hourly_cost"`
+	MonthlyCost        float64                `json:"monthly_cost"`
+	UsageBased         bool                   `json:"usage_based"`
+	DiscountPercentage float64                `json:"discount_percentage"`
+	Service            string                 `json:"service"`
+	Region             string                 `json:"region"`
+	SKU                string                 `json:"sku"`
+	Meter              string                 `json:"meter"`
+	PriceHash          string                 `json:"price_hash"`
+	PriceSource        string                 `json:"price_source"`
+	Discounts          []AppliedDiscountModel `json:"discounts,omitempty"`
+}
+
+// AppliedDiscountModel is a discount that reduced the monthly cost of a cost component.
+type AppliedDiscountModel struct {
+	Name           string  `json:"name"`
+	MonthlySavings float64 `json:"monthly_savings"`
 }
 
 func (r *EstimateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -109,12 +167,23 @@ func (r *EstimateResource) Metadata(ctx context.Context, req resource.MetadataRe
 
 func (r *EstimateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "The `plancost_estimate` resource estimates the cost of cloud resources within a Terraform module. It integrates cost estimation, policy enforcement, and optimization recommendations directly into your Terraform workflow.\n\n> **Note:** Currently, `plancost` only supports the **Azure** provider (`azurerm`). Support for **AWS** and **GCP** is planned.",
+		MarkdownDescription: "The `plancost_estimate` resource estimates the cost of cloud resources within a Terraform module. It integrates cost estimation, policy enforcement, and optimization recommendations directly into your Terraform workflow.\n\n> **Note:** Currently, `plancost` supports the **Azure** provider (`azurerm`) and the core services of the **AWS** (`aws`) and **GCP** (`google`) providers.",
 
 		Attributes: map[string]schema.Attribute{
 			"working_directory": schema.StringAttribute{
-				MarkdownDescription: "Absolute path to the Terraform module directory (e.g., `abspath(path.module)`)",
-				Required:            true,
+				MarkdownDescription: "Absolute path to the Terraform module directory (e.g., `abspath(path.module)`). Exactly one of `working_directory`, `plan_json_file` or `projects` must be set.",
+				Optional:            true,
+				WriteOnly:           true,
+			},
+
+			"auto_detect_projects": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, `working_directory` is treated as a root path and every Terraform project (root module) below it is estimated. A project with environment var files, such as `dev.tfvars` and `prod.tfvars`, is estimated once per environment. Defaults to `false`.",
+				Optional:            true,
+			},
+
+			"plan_json_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to a Terraform plan in JSON format, as produced by `terraform show -json tfplan` (e.g., `abspath(\"${path.module}/tfplan.json\")`). When set, resources are read from the plan's planned values instead of parsing the module, so computed values, data sources and module outputs are exact. Variable files are not used in this mode.",
+				Optional:            true,
 				WriteOnly:           true,
 			},
 
@@ -147,15 +216,43 @@ func (r *EstimateResource) Schema(ctx context.Context, req resource.SchemaReques
 				WriteOnly: true,
 			},
 
+			"pricing_profile": schema.StringAttribute{
+				MarkdownDescription: "The rates that resources are priced at. Valid values: `pay_as_you_go`, `dev_test` (Dev/Test subscription rates, without Windows and SQL Server license costs), `hybrid_benefit_windows` (Azure Hybrid Benefit for Windows VMs and scale sets without a `license_type`) and `hybrid_benefit_sql` (Azure Hybrid Benefit for SQL databases and managed instances that include the license). Defaults to `pay_as_you_go`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tfschema.PricingProfiles...),
+				},
+			},
+
 			"resources": schema.DynamicAttribute{
 				Computed: true,
 			},
 
+			"project_costs": schema.DynamicAttribute{
+				MarkdownDescription: "The monthly cost subtotal of each project. Each entry has the project `name` and its `monthly_cost`.",
+				Computed:            true,
+			},
+
+			"cost_by_tag": schema.DynamicAttribute{
+				MarkdownDescription: "The monthly cost broken down by the values of the `cost_allocation` tag keys. Each entry has the tag `key`, its `value`, the `resource_count` and the `monthly_cost` of the resources with that value. Resources without the tag are counted in the `untagged` value, so the entries of each key add up to `monthly_cost`.",
+				Computed:            true,
+			},
+
+			"diff": schema.DynamicAttribute{
+				MarkdownDescription: "Per-resource cost changes compared to the prior state of the plan when `plan_json_file` is set, or otherwise to the prior estimate that the costs last changed from, so that an unchanged estimate keeps its diff and shows no changes after an apply. The diff is empty for the first estimate. Each entry has the resource `name`, an `action` (`added`, `removed` or `changed`), `previous_monthly_cost`, `monthly_cost`, `monthly_cost_change` and the `causes` of the change (`config change`, `quantity/usage change` or `price change`). Resources whose cost is unchanged are omitted.",
+				Computed:            true,
+			},
+
 			"monthly_cost": schema.NumberAttribute{
 				MarkdownDescription: "The estimated monthly cost (numeric value)",
 				Computed:            true,
 			},
 
+			"currency": schema.StringAttribute{
+				MarkdownDescription: "The ISO 4217 code of the currency of `monthly_cost` and all other amounts, as configured on the provider.",
+				Computed:            true,
+			},
+
 			"view": schema.StringAttribute{
 				MarkdownDescription: "The pretty printed output of the estimate",
 				Computed:            true,
@@ -183,6 +280,9 @@ func (r *EstimateResource) Schema(ctx context.Context, req resource.SchemaReques
 						"term":               types.StringType,
 						"savings_amount":     types.NumberType,
 						"savings_percentage": types.NumberType,
+						"severity":           types.StringType,
+						"replacement":        types.MapType{ElemType: types.StringType},
+						"hourly_commitment":  types.NumberType,
 					},
 				},
 				MarkdownDescription: "List of optimization recommendations.",
@@ -190,7 +290,13 @@ func (r *EstimateResource) Schema(ctx context.Context, req resource.SchemaReques
 			},
 
 			"export_markdown_file": schema.StringAttribute{
-				MarkdownDescription: "Absolute path to the output markdown file (e.g., `abspath(\"${path.module}/estimate.md\")`). If specified, the cost estimate report will be written to this file.",
+				MarkdownDescription: "Absolute path to the output markdown file (e.g., `abspath(\"${path.module}/estimate.md\")`). If specified, the cost estimate report will be written to this file, including the optimization recommendations.",
+				Optional:            true,
+				WriteOnly:           true,
+			},
+
+			"export_pricing_snapshot_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to the output price snapshot file (e.g., `abspath(\"${path.module}/prices.json.gz\")`). If specified, the prices of all cost components in the estimate will be written to this file, gzip compressed if the path ends with `.gz`. The snapshot can be used with the provider's `pricing_snapshot_file` setting to estimate the same infrastructure without access to the pricing API.",
 				Optional:            true,
 				WriteOnly:           true,
 			},
@@ -200,21 +306,64 @@ func (r *EstimateResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:            true,
 				WriteOnly:           true,
 			},
+
+			"export_explain_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to the output explain file (e.g., `abspath(\"${path.module}/explain.json\")`). If specified, the provider will write a JSON file that explains how each cost component was priced: the Terraform attributes read, the product and price filters sent to the pricing API, the products returned, the price chosen and why, and the formulas of the monthly quantity and cost. The attributes are those the resource reads from its own configuration, with `region` for the region it is priced in; attributes of referenced resources, e.g. the location of the server of a database, are not listed.",
+				Optional:            true,
+				WriteOnly:           true,
+			},
 		},
 
 		Blocks: map[string]schema.Block{
+			"projects": schema.ListNestedBlock{
+				MarkdownDescription: "List of Terraform projects to estimate together. Each project is parsed with its own variables, and the estimate reports per-project subtotals plus the grand total.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the project. Defaults to the directory name of `working_directory`.",
+							Optional:            true,
+						},
+
+						"working_directory": schema.StringAttribute{
+							MarkdownDescription: "Absolute path to the Terraform module directory of the project.",
+							Required:            true,
+							WriteOnly:           true,
+						},
+
+						"var_file": schema.StringAttribute{
+							MarkdownDescription: "Absolute path to the variables file of the project.",
+							Optional:            true,
+							WriteOnly:           true,
+						},
+
+						"pricing_profile": schema.StringAttribute{
+							MarkdownDescription: "The pricing profile of the project, e.g. `dev_test` for a project deployed to a Dev/Test subscription. Defaults to the `pricing_profile` of the estimate.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(tfschema.PricingProfiles...),
+							},
+						},
+					},
+				},
+			},
+
 			"guardrail": schema.ListNestedBlock{
-				MarkdownDescription: "List of guardrail policies to enforce cost limits. Note: This is a paid feature. Free tier users are limited to 1 guardrail and cannot use 'block' actions.",
+				MarkdownDescription: "List of guardrail policies to enforce cost limits. A guardrail with `resource_type`, `address` or `tags` is evaluated against the costs of the matching resources only. Note: This is a paid feature. Free tier users are limited to 1 guardrail and cannot use 'block' actions.",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"condition": schema.StringAttribute{
-							MarkdownDescription: "The condition to trigger the guardrail. Valid values: 'monthly_cost_increase_amount', 'monthly_cost_increase_percentage', 'monthly_cost_budget'.",
-							Required:            true,
+							MarkdownDescription: "The condition to trigger the guardrail. Valid values: 'monthly_cost_increase_amount', 'monthly_cost_increase_percentage', 'monthly_cost_budget', 'resource_monthly_cost_increase_amount', 'resource_monthly_cost_increase_percentage', 'estimate_coverage_percentage', 'unpriced_monthly_components'. The 'resource_' conditions are evaluated for every resource whose cost changes in the plan. " +
+								"'estimate_coverage_percentage' is breached when the percentage of resources that are supported and fully priced is below the threshold. It counts resources, not spend, so an unsupported resource lowers the coverage by the same amount whatever it costs; resources of types that are known to be free count as fully priced. 'unpriced_monthly_components' is breached when more cost components than the threshold have no price.",
+							Required: true,
 							Validators: []validator.String{
 								stringvalidator.OneOf(
 									"monthly_cost_increase_amount",
 									"monthly_cost_increase_percentage",
 									"monthly_cost_budget",
+									"resource_monthly_cost_increase_amount",
+									"resource_monthly_cost_increase_percentage",
+									"estimate_coverage_percentage",
+									"unpriced_monthly_components",
 								),
 							},
 						},
@@ -231,19 +380,56 @@ func (r *EstimateResource) Schema(ctx context.Context, req resource.SchemaReques
 								stringvalidator.OneOf("warning", "block"),
 							},
 						},
+
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "Only evaluate the guardrail against resources of this type, e.g. `azurerm_kubernetes_cluster`.",
+							Optional:            true,
+						},
+
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Only evaluate the guardrail against resources whose address matches this glob, e.g. `module.aks.*`. `*` matches any characters.",
+							Optional:            true,
+						},
+
+						"tags": schema.MapAttribute{
+							MarkdownDescription: "Only evaluate the guardrail against resources that have all of these tag values, e.g. `{ team = \"payments\" }`.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+
+						"allowed_unestimated_resource_types": schema.ListAttribute{
+							MarkdownDescription: "Resource types that are accepted without an estimate, e.g. `azurerm_role_assignment` or `azurerm_monitor_*`. These resources are left out of the 'estimate_coverage_percentage' and 'unpriced_monthly_components' conditions. `*` matches any characters.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
 					},
 				},
 			},
 
 			"discount": schema.ListNestedBlock{
-				MarkdownDescription: "List of discounts to apply.",
+				MarkdownDescription: "List of discounts to apply. Discounts with a scope stack in the order they are declared, each applying to the cost left by the previous ones. " +
+					"Discounts without a scope only apply to the cost components that no scoped discount matched. The applied discounts are listed per cost component in `resources`.",
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the discount in `resources`. Defaults to `discount[<index>]`.",
+							Optional:            true,
+						},
+
 						"percentage": schema.NumberAttribute{
-							MarkdownDescription: "The discount percentage (0.0 to 1.0).",
-							Required:            true,
+							MarkdownDescription: "The discount percentage (0.0 to 1.0). Exactly one of `percentage` or `amount` must be set.",
+							Optional:            true,
 							Validators: []validator.Number{
 								myvalidator.NumberBetween(0.0, 1.0),
+								numbervalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("amount")),
+							},
+						},
+
+						"amount": schema.NumberAttribute{
+							MarkdownDescription: "A monthly credit for each matching resource, in the currency of the estimate. The credit is split across the matching cost components of the resource in proportion to their cost, and never makes a cost negative.",
+							Optional:            true,
+							Validators: []validator.Number{
+								myvalidator.NumberBetween(0.0, math.MaxFloat64),
 							},
 						},
 
@@ -251,6 +437,99 @@ func (r *EstimateResource) Schema(ctx context.Context, req resource.SchemaReques
 							MarkdownDescription: "The resource type to apply the discount to (e.g., 'azurerm_virtual_machine'). If not specified, applies to all resources.",
 							Optional:            true,
 						},
+
+						"address": schema.StringAttribute{
+							MarkdownDescription: "Only apply the discount to resources whose address matches this glob, e.g. `module.prod_*`. `*` matches any characters.",
+							Optional:            true,
+						},
+
+						"tags": schema.MapAttribute{
+							MarkdownDescription: "Only apply the discount to resources that have all of these tag values, e.g. `{ environment = \"prod\" }`.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+
+						"cost_component": schema.StringAttribute{
+							MarkdownDescription: "Only apply the discount to cost components whose name matches this case-insensitive glob, e.g. `Compute*` or `Instance usage*`.",
+							Optional:            true,
+						},
+
+						"region": schema.StringAttribute{
+							MarkdownDescription: "Only apply the discount to cost components priced in this region, e.g. `eastus`.",
+							Optional:            true,
+						},
+
+						"start_date": schema.StringAttribute{
+							MarkdownDescription: "The first day the discount is valid, in the `YYYY-MM-DD` format. The discount isn't applied to estimates made before this day (UTC).",
+							Optional:            true,
+							Validators: []validator.String{
+								myvalidator.ValidDate(),
+							},
+						},
+
+						"end_date": schema.StringAttribute{
+							MarkdownDescription: "The last day the discount is valid, in the `YYYY-MM-DD` format, e.g. the end of an agreement. The discount isn't applied to estimates made after this day (UTC).",
+							Optional:            true,
+							Validators: []validator.String{
+								myvalidator.ValidDate(),
+							},
+						},
+					},
+				},
+			},
+
+			"cost_allocation": schema.ListNestedBlock{
+				MarkdownDescription: "Break the estimate down by tag values for chargeback. The breakdown is reported in `cost_by_tag`, the `view` and the markdown export.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"tag_keys": schema.ListAttribute{
+							MarkdownDescription: "The tag keys to break the cost down by, e.g. `[\"cost_center\", \"team\", \"env\"]`.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+
+			"recommendation_rule": schema.ListNestedBlock{
+				MarkdownDescription: "List of user-defined optimization rules. A rule matches the resources of a type whose attributes have the given values, and is reported in `recommendations`. When the rule has a `replacement`, the resource is priced again with the replaced attribute values, e.g. a cheaper SKU, and the difference is reported as the monthly savings.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "The resource type to match, e.g. `azurerm_managed_disk`.",
+							Required:            true,
+						},
+
+						"attributes": schema.MapAttribute{
+							MarkdownDescription: "Only match resources whose attributes have all of these values, e.g. `{ storage_account_type = \"Premium_LRS\" }`. Nested attributes are matched with dotted paths, e.g. `os_disk.0.storage_account_type`.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+
+						"message": schema.StringAttribute{
+							MarkdownDescription: "The recommendation reported for matching resources.",
+							Required:            true,
+						},
+
+						"severity": schema.StringAttribute{
+							MarkdownDescription: "The severity of the recommendation. Valid values: 'info', 'warning', 'error'. Defaults to 'info'. 'warning' and 'error' recommendations are also reported as diagnostics, and 'error' blocks the apply.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("info", "warning", "error"),
+							},
+						},
+
+						"replacement": schema.MapAttribute{
+							MarkdownDescription: "The suggested attribute values, e.g. `{ storage_account_type = \"StandardSSD_LRS\" }`. The savings of the replacement are reported in `savings_amount` and `savings_percentage`.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
 					},
 				},
 			},
@@ -327,6 +606,66 @@ func (r *EstimateResource) Configure(ctx context.Context, req resource.Configure
 	r.client = data.Client
 }
 
+func (r *EstimateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config EstimateResourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &config)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	sources := 0
+	if !config.WorkingDirectory.IsNull() {
+		sources++
+	}
+	if !config.PlanJSONFile.IsNull() {
+		sources++
+	}
+	if len(config.Projects) > 0 {
+		sources++
+	}
+	if sources != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Estimate Source",
+			"Exactly one of `working_directory`, `plan_json_file` or `projects` must be set.",
+		)
+	}
+
+	if config.AutoDetectProjects.ValueBool() && config.WorkingDirectory.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auto_detect_projects"),
+			"Invalid Project Detection",
+			"`auto_detect_projects` requires `working_directory` to be set to the root path to search.",
+		)
+	}
+
+	for i, d := range config.Discount {
+		if d.StartDate.IsNull() || d.StartDate.IsUnknown() || d.EndDate.IsNull() || d.EndDate.IsUnknown() {
+			continue
+		}
+		// Invalid dates are reported by the attribute validators
+		start, startErr := time.Parse(time.DateOnly, d.StartDate.ValueString())
+		end, endErr := time.Parse(time.DateOnly, d.EndDate.ValueString())
+		if startErr == nil && endErr == nil && end.Before(start) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("discount").AtListIndex(i).AtName("end_date"),
+				"Invalid Discount Validity",
+				fmt.Sprintf("The end_date %s of the discount is before its start_date %s.", d.EndDate.ValueString(), d.StartDate.ValueString()),
+			)
+		}
+	}
+
+	for i, rule := range config.RecommendationRule {
+		for k := range rule.Replacement {
+			if err := validateAttributePath(k); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("recommendation_rule").AtListIndex(i).AtName("replacement"),
+					"Invalid Replacement Attribute",
+					fmt.Sprintf("The replacement attribute %s", err),
+				)
+			}
+		}
+	}
+}
+
 func (r *EstimateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	var config *EstimateResourceModel
 	var state *EstimateResourceModel
@@ -382,96 +721,182 @@ func (r *EstimateResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		}
 	}
 
-	// Parse the module
-	workingDir := config.WorkingDirectory.ValueString()
-	options := expandVariableOptions(config.VarFile.ValueString(), workingDir)
-	allParsedResources, coreResources, err := ParseModule(workingDir, usageMap, options...)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Module Calculation Error",
-			fmt.Sprintf("Failed to calculate module %s: %s", "", err.Error()),
-		)
-	}
-	allCostResources := make([]*tfschema.Resource, 0)
-	for _, res := range allParsedResources {
-		if len(res.CostComponents) == 0 && len(res.SubResources) == 0 {
-			continue
-		}
-		allCostResources = append(allCostResources, res)
-	}
+	currency := r.priceFetcher.Currency()
 
-	// Populate prices for all cost components
-	if err = r.priceFetcher.PopulatePrices(allCostResources); err != nil {
+	// Parse and price every project, either modules or the plan JSON file
+	multiProject := len(config.Projects) > 0 || config.AutoDetectProjects.ValueBool()
+	projects := expandProjects(config)
+	if len(projects) == 0 {
 		resp.Diagnostics.AddError(
-			"Pricing Data Population Error",
-			fmt.Sprintf("Failed to populate pricing data: %s", err.Error()),
+			"No Projects Found",
+			fmt.Sprintf("No Terraform projects were found in %s.", config.WorkingDirectory.ValueString()),
 		)
 		return
 	}
 
-	// Apply discounts
-	if len(config.Discount) > 0 {
-		for _, costResource := range allCostResources {
-			ApplyDiscount(costResource, config.Discount)
+	allParsedResources := make([]*tfschema.Resource, 0)
+	pastParsedResources := make([]*tfschema.Resource, 0)
+	coreResources := make([]tfschema.CoreResource, 0)
+	ruleRecommendations := make([]optimization.OptimizationRecommendation, 0)
+	allCostResources := make([]*tfschema.Resource, 0)
+	guardrailResources := make([]GuardrailResource, 0)
+	flattenedResources := make([]CostResourceModel, 0)
+	projectResources := make([]ProjectResources, 0, len(projects))
+	projectCosts := make([]ProjectCostModel, 0, len(projects))
+	explained := make([]ExplainResourceModel, 0)
+	totalCost := 0.0
+	for _, project := range projects {
+		parsedResources, pastResources, partialResources, diags := parseEstimateProject(project, usageMap)
+		resp.Diagnostics.Append(diags...)
+		costResources, projectCost, err := PriceResources(r.priceFetcher, parsedResources, config.Discount)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Pricing Data Population Error",
+				fmt.Sprintf("Failed to populate pricing data: %s", err.Error()),
+			)
+			return
 		}
-	}
 
-	totalCost := 0.0
-	for _, costResource := range allCostResources {
-		// Calculate costs based on populated prices
-		costResource.CalculateCosts()
+		projectName := ""
+		if multiProject {
+			projectName = project.Name
+		}
+		resp.Diagnostics.Append(MissingPriceDiagnostics(projectName, costResources, currency)...)
+		resp.Diagnostics.Append(StalePriceDiagnostics(costResources)...)
+		projectFlattened := flattenResources(costResources)
+		for i := range projectFlattened {
+			projectFlattened[i].Project = projectName
+		}
 
-		// Add resource costs to total
-		if costResource.MonthlyCost != nil {
-			totalCost += costResource.MonthlyCost.InexactFloat64()
+		projectRecommendations, err := RecommendationRules(config.RecommendationRule, projectName, partialResources, costResources, r.priceFetcher, config.Discount)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Recommendation Rule Error",
+				fmt.Sprintf("Failed to apply the recommendation rules: %s", err.Error()),
+			)
+			return
 		}
+
+		allParsedResources = append(allParsedResources, parsedResources...)
+		pastParsedResources = append(pastParsedResources, pastResources...)
+		coreResources = append(coreResources, partialCoreResources(partialResources)...)
+		ruleRecommendations = append(ruleRecommendations, projectRecommendations...)
+		allCostResources = append(allCostResources, costResources...)
+		guardrailResources = append(guardrailResources, NewGuardrailResources(projectName, parsedResources)...)
+		flattenedResources = append(flattenedResources, projectFlattened...)
+		projectResources = append(projectResources, ProjectResources{Name: project.Name, Resources: parsedResources})
+		projectCosts = append(projectCosts, ProjectCostModel{Name: project.Name, MonthlyCost: roundCost(projectCost)})
+		explained = append(explained, ExplainResources(projectName, partialResources, costResources)...)
+		totalCost += projectCost
 	}
 
-	// Guardrail Logic
+	// The prior costs come from the plan's prior state when a plan JSON file is used, and from the last estimate otherwise
+	priorResources := make([]CostResourceModel, 0)
+	pastCostResources := make([]*tfschema.Resource, 0)
 	previousCost := 0.0
-	if state != nil && !state.MonthlyCost.IsNull() {
-		previousCost, _ = state.MonthlyCost.ValueBigFloat().Float64()
+	if config.PlanJSONFile.ValueString() != "" {
+		var pastCost float64
+		pastCostResources, pastCost, err = PriceResources(r.priceFetcher, pastParsedResources, config.Discount)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Pricing Data Population Error",
+				fmt.Sprintf("Failed to populate pricing data for the prior state: %s", err.Error()),
+			)
+			return
+		}
+		priorResources = flattenResources(pastCostResources)
+		previousCost = pastCost
+	} else if state != nil && !state.Currency.IsNull() && state.Currency.ValueString() != currency {
+		// Costs in different currencies can't be compared, so the new estimate is treated as the first one
+		resp.Diagnostics.AddWarning(
+			"Currency Changed",
+			fmt.Sprintf("The prior estimate is in %s and the new estimate in %s, so cost changes are not reported for this plan.", state.Currency.ValueString(), currency),
+		)
+	} else if state != nil {
+		if err := dynamic.Unmarshal(state.Resources, &priorResources); err != nil {
+			resp.Diagnostics.AddError("Failed to unmarshal prior resources", err.Error())
+			return
+		}
+		if !state.MonthlyCost.IsNull() {
+			previousCost, _ = state.MonthlyCost.ValueBigFloat().Float64()
+		}
+	}
+	diffs := DiffResources(priorResources, flattenedResources)
+
+	// The diff in state is against a stable baseline, so that the estimate converges after an apply: the prior state of
+	// the plan, or the estimate that the costs last changed from
+	stateDiffs := diffs
+	if config.PlanJSONFile.ValueString() == "" {
+		var priorDiffs []ResourceDiffModel
+		if state != nil && len(priorResources) > 0 {
+			if err := dynamic.Unmarshal(state.Diff, &priorDiffs); err != nil {
+				resp.Diagnostics.AddError("Failed to unmarshal prior resource diff", err.Error())
+				return
+			}
+		}
+		stateDiffs = stableResourceDiffs(diffs, priorResources, priorDiffs)
 	}
-	resp.Diagnostics.Append(Guardrails(paidTier, config.Guardrail, totalCost, previousCost)...)
+
+	// Guardrail Logic
+	resp.Diagnostics.Append(Guardrails(paidTier, config.Guardrail, totalCost, previousCost, guardrailResources, diffs, currency)...)
 
 	// Tagging Policy Logic
 	resp.Diagnostics.Append(TaggingPolicies(paidTier, config.TaggingPolicy, allParsedResources)...)
 
+	// Cost Allocation
+	tagCosts := CostByTag(costAllocationTagKeys(config.CostAllocation), allCostResources)
+
 	// Optimization Recommendations
 	recommendations := Optimization(paidTier, config.RecommendationsEnabled.ValueBool(), coreResources, allCostResources, r.priceFetcher)
+	recommendations = append(recommendations, ruleRecommendations...)
+	resp.Diagnostics.Append(RecommendationRuleDiagnostics(ruleRecommendations, currency)...)
 
 	// Convert structured recommendations to object list for schema compatibility
 	config.Recommendations = ConvertRecommendationsToAttrValue(recommendations)
 
-	flattenedResources := flattenResources(allCostResources)
-	if err != nil {
+	if v, err := dynamic.ToDynamic(flattenedResources); err != nil {
 		resp.Diagnostics.AddError(
 			"Resource Flattening Error",
-			fmt.Sprintf("Failed to flatten resources: %s", err.Error()),
+			fmt.Sprintf("Failed to convert flattened resources to dynamic: %s", err.Error()),
 		)
 		return
+	} else {
+		config.Resources = v
 	}
-	if v, err := dynamic.ToDynamic(flattenedResources); err != nil {
+
+	if v, err := dynamic.ToDynamic(projectCosts); err != nil {
 		resp.Diagnostics.AddError(
-			"Resource Flattening Error",
-			fmt.Sprintf("Failed to convert flattened resources to dynamic: %s", err.Error()),
+			"Project Costs Error",
+			fmt.Sprintf("Failed to convert project costs to dynamic: %s", err.Error()),
 		)
 		return
 	} else {
-		config.Resources = v
+		config.ProjectCosts = v
+	}
+
+	if v, err := dynamic.ToDynamic(tagCosts); err != nil {
+		resp.Diagnostics.AddError(
+			"Cost Allocation Error",
+			fmt.Sprintf("Failed to convert tag costs to dynamic: %s", err.Error()),
+		)
+		return
+	} else {
+		config.CostByTag = v
+	}
+
+	if v, err := dynamic.ToDynamic(stateDiffs); err != nil {
+		resp.Diagnostics.AddError(
+			"Resource Diff Error",
+			fmt.Sprintf("Failed to convert resource diff to dynamic: %s", err.Error()),
+		)
+		return
+	} else {
+		config.Diff = v
 	}
 
 	// Write markdown file if export_markdown_file is set
 	if !config.ExportMarkdownFile.IsNull() && config.ExportMarkdownFile.ValueString() != "" {
-		priorResources := make([]CostResourceModel, 0)
-		if state != nil {
-			if err := dynamic.Unmarshal(state.Resources, &priorResources); err != nil {
-				resp.Diagnostics.AddError("Failed to unmarshal prior resources", err.Error())
-				return
-			}
-		}
-
-		markdownContent := GenerateMarkdownOutput(priorResources, flattenedResources)
+		markdownContent := GenerateMarkdownOutput(priorResources, flattenedResources, tagCosts, recommendations, currency)
 		err = os.WriteFile(config.ExportMarkdownFile.ValueString(), []byte(markdownContent), 0644)
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to write markdown file", err.Error())
@@ -479,6 +904,15 @@ func (r *EstimateResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		}
 	}
 
+	// Write price snapshot file if export_pricing_snapshot_file is set
+	if !config.ExportPricingSnapshotFile.IsNull() && config.ExportPricingSnapshotFile.ValueString() != "" {
+		err = r.priceFetcher.ExportSnapshot(append(allCostResources, pastCostResources...), config.ExportPricingSnapshotFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to write price snapshot file", err.Error())
+			return
+		}
+	}
+
 	// Write usage file if export_usage_file is set
 	if !config.ExportUsageFile.IsNull() && config.ExportUsageFile.ValueString() != "" {
 		usageContent, err := GenerateUsageYAML(allParsedResources)
@@ -493,14 +927,30 @@ func (r *EstimateResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 		}
 	}
 
+	// Write explain file if export_explain_file is set
+	if !config.ExportExplainFile.IsNull() && config.ExportExplainFile.ValueString() != "" {
+		if err := WriteExplainFile(explained, config.ExportExplainFile.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Failed to write explain file", err.Error())
+			return
+		}
+	}
+
 	// Set the modified plan
 	config.WorkingDirectory = types.StringNull()
+	config.PlanJSONFile = types.StringNull()
 	config.UsageFile = types.StringNull()
 	config.VarFile = types.StringNull()
 	config.ExportMarkdownFile = types.StringNull()
+	config.ExportPricingSnapshotFile = types.StringNull()
 	config.ExportUsageFile = types.StringNull()
+	config.ExportExplainFile = types.StringNull()
+	for i := range config.Projects {
+		config.Projects[i].WorkingDirectory = types.StringNull()
+		config.Projects[i].VarFile = types.StringNull()
+	}
 	config.MonthlyCost = types.NumberValue(decimal.NewFromFloat(totalCost).Round(2).BigFloat())
-	config.View = types.StringValue(GenerateConsoleOutput(config.ProjectName.ValueString(), allParsedResources, recommendations, paidTier))
+	config.Currency = types.StringValue(currency)
+	config.View = types.StringValue(GenerateProjectsConsoleOutput(projectResources, stateDiffs, tagCosts, recommendations, paidTier, currency))
 	resp.Diagnostics.Append(resp.Plan.Set(ctx, &config)...)
 }
 
@@ -517,6 +967,9 @@ func (r *EstimateResource) Create(ctx context.Context, req resource.CreateReques
 		friendlyName := config.ProjectName.ValueString()
 		if friendlyName == "" {
 			workingDirectory := config.WorkingDirectory.ValueString()
+			if workingDirectory == "" && config.PlanJSONFile.ValueString() != "" {
+				workingDirectory = filepath.Dir(config.PlanJSONFile.ValueString())
+			}
 			friendlyName = filepath.Base(workingDirectory)
 		}
 		if friendlyName == "" || friendlyName == "." || friendlyName == "/" {
@@ -619,14 +1072,12 @@ func expandUsageMap(usageFilePath types.String, usageDyn types.Dynamic) (tfschem
 	return tfschema.NewUsageMapFromInterface(combinedMap), nil
 }
 
-func expandVariableOptions(varFile string, workingDirectory string) []hcl.Option {
+func expandVariableOptions(varFiles []string, workingDirectory string) []hcl.Option {
 	options := make([]hcl.Option, 0)
 	tfVarsPaths := make([]string, 0)
 
 	// 1. VarFile
-	if varFile != "" {
-		tfVarsPaths = append(tfVarsPaths, varFile)
-	}
+	tfVarsPaths = append(tfVarsPaths, varFiles...)
 
 	// 2. variable file path provided by environment variable: PLANCOST_VAR_FILE
 	if envVarPath := os.Getenv("PLANCOST_VAR_FILE"); envVarPath != "" {
@@ -673,11 +1124,19 @@ func flattenResources(resources []*tfschema.Resource) []CostResourceModel {
 		if len(costComps) == 0 && len(subRes) == 0 {
 			continue
 		}
-		costResources = append(costResources, CostResourceModel{
-			Name:           r.Name,
-			CostComponents: costComps,
-			SubResources:   subRes,
-		})
+		baseline, usage := calculateResourceCosts(r)
+		res := CostResourceModel{
+			Name:                r.Name,
+			ResourceType:        r.ResourceType,
+			BaselineMonthlyCost: roundCost(baseline),
+			UsageMonthlyCost:    roundCost(usage),
+			CostComponents:      costComps,
+			SubResources:        subRes,
+		}
+		if r.Tags != nil && len(*r.Tags) > 0 {
+			res.Tags = *r.Tags
+		}
+		costResources = append(costResources, res)
 	}
 	return costResources
 }
@@ -708,16 +1167,73 @@ func flattenCostComponents(components []*tfschema.CostComponent) []CostComponent
 		if monthlyCost == 0.0 && qty == "0" && unit != "usage_based" {
 			continue
 		}
-		result = append(result, CostComponentModel{
-			Name:            c.Name,
-			MonthlyQuantity: qty,
-			Unit:            unit,
-			MonthlyCost:     monthlyCost,
-		})
+		var discounts []AppliedDiscountModel
+		for _, d := range c.AppliedDiscounts {
+			discounts = append(discounts, AppliedDiscountModel{Name: d.Name, MonthlySavings: d.MonthlySavings.Round(2).InexactFloat64()})
+		}
+		hourlyCost := 0.0
+		if c.HourlyCost != nil {
+			hourlyCost = c.HourlyCost.Round(4).InexactFloat64()
+		}
+		component := CostComponentModel{
+			Name:               c.Name,
+			MonthlyQuantity:    qty,
+			Unit:               unit,
+			Price:              c.Price().String(),
+			HourlyCost:         hourlyCost,
+			MonthlyCost:        monthlyCost,
+			UsageBased:         c.UsageBased,
+			DiscountPercentage: decimal.NewFromFloat(c.MonthlyDiscountPerc).Round(4).InexactFloat64(),
+			PriceHash:          c.PriceHash(),
+			PriceSource:        c.PriceSource,
+			Discounts:          discounts,
+		}
+		if pf := c.ProductFilter; pf != nil {
+			component.Service = stringValue(pf.Service)
+			component.Region = stringValue(pf.Region)
+			component.SKU = stringValue(pf.Sku)
+			if component.SKU == "" {
+				component.SKU = attributeFilterValue(pf, "armSkuName", "skuName", "instanceType")
+			}
+			component.Meter = attributeFilterValue(pf, "meterName", "usagetype")
+		}
+		result = append(result, component)
 	}
 	return result
 }
 
+// simpleRegexValue matches a regex filter that only anchors a literal value, e.g. `/^Standard_D2s_v5$/i`.
+var simpleRegexValue = regexp.MustCompile(`^/\^([\w .-]+)\$/i?$`)
+
+// attributeFilterValue returns the value of the first attribute filter of the product filter with one of the keys.
+// Regex filters are returned as is, unless they only match a literal value.
+func attributeFilterValue(pf *tfschema.ProductFilter, keys ...string) string {
+	for _, key := range keys {
+		for _, f := range pf.AttributeFilters {
+			if f.Key != key {
+				continue
+			}
+			if f.Value != nil {
+				return *f.Value
+			}
+			if f.ValueRegex != nil {
+				if m := simpleRegexValue.FindStringSubmatch(*f.ValueRegex); m != nil {
+					return m[1]
+				}
+				return *f.ValueRegex
+			}
+		}
+	}
+	return ""
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // Helper to convert sub-resources to a native list
 func flattenSubResources(subResources []*tfschema.Resource) []CostResourceModel {
 	// Sort sub-resources by name to ensure deterministic order
@@ -732,10 +1248,13 @@ func flattenSubResources(subResources []*tfschema.Resource) []CostResourceModel
 		if len(costComps) == 0 && len(subRes) == 0 {
 			continue
 		}
+		baseline, usage := calculateResourceCosts(r)
 		result = append(result, CostResourceModel{
-			Name:           r.Name,
-			CostComponents: costComps,
-			SubResources:   subRes,
+			Name:                r.Name,
+			BaselineMonthlyCost: roundCost(baseline),
+			UsageMonthlyCost:    roundCost(usage),
+			CostComponents:      costComps,
+			SubResources:        subRes,
 		})
 	}
 	return result
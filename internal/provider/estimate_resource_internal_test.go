@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestFlattenResources(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	hours := decimal.NewFromInt(1)
+	months := decimal.NewFromInt(1)
+
+	instance := &tfschema.CostComponent{
+		Name:                "Instance usage (Linux, pay as you go, Standard_D2s_v5)",
+		Unit:                "hours",
+		UnitMultiplier:      decimal.NewFromInt(1),
+		HourlyQuantity:      &hours,
+		MonthlyDiscountPerc: 0.1,
+		ProductFilter: &tfschema.ProductFilter{
+			Service: strPtr("Virtual Machines"),
+			Region:  strPtr("eastus"),
+			AttributeFilters: []*tfschema.AttributeFilter{
+				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "armSkuName", ValueRegex: strPtr("/^Standard_D2s_v5$/i")},
+			},
+		},
+	}
+	instance.SetPrice(decimal.RequireFromString("0.096"))
+	instance.SetPriceHash("a1b2c3")
+
+	disk := &tfschema.CostComponent{
+		Name:            "Storage (S4, LRS)",
+		Unit:            "months",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: &months,
+		ProductFilter: &tfschema.ProductFilter{
+			Service:          strPtr("Storage"),
+			Region:           strPtr("eastus"),
+			AttributeFilters: []*tfschema.AttributeFilter{{Key: "skuName", Value: strPtr("S4 LRS")}, {Key: "meterName", Value: strPtr("S4 LRS Disk")}},
+		},
+		PriceSource: tfschema.PriceSourcePriceSheet,
+	}
+	disk.SetPrice(decimal.RequireFromString("1.54"))
+
+	egress := &tfschema.CostComponent{
+		Name:           "Outbound data transfer",
+		Unit:           "GB",
+		UnitMultiplier: decimal.NewFromInt(1),
+		UsageBased:     true,
+	}
+	egress.SetPrice(decimal.RequireFromString("0.087"))
+
+	tags := map[string]string{"team": "payments"}
+	res := &tfschema.Resource{
+		Name:           "azurerm_linux_virtual_machine.app",
+		ResourceType:   "azurerm_linux_virtual_machine",
+		Tags:           &tags,
+		CostComponents: []*tfschema.CostComponent{instance, egress},
+		SubResources: []*tfschema.Resource{
+			{Name: "os_disk", CostComponents: []*tfschema.CostComponent{disk}},
+		},
+	}
+	res.CalculateCosts()
+
+	assert.Equal(t, []CostResourceModel{
+		{
+			Name:                "azurerm_linux_virtual_machine.app",
+			ResourceType:        "azurerm_linux_virtual_machine",
+			Tags:                map[string]string{"team": "payments"},
+			BaselineMonthlyCost: 64.61,
+			UsageMonthlyCost:    0,
+			CostComponents: []CostComponentModel{
+				{
+					Name:               "Instance usage (Linux, pay as you go, Standard_D2s_v5)",
+					MonthlyQuantity:    "730",
+					Unit:               "hours",
+					Price:              "0.096",
+					HourlyCost:         0.096,
+					MonthlyCost:        63.07,
+					DiscountPercentage: 0.1,
+					Service:            "Virtual Machines",
+					Region:             "eastus",
+					SKU:                "Standard_D2s_v5",
+					PriceHash:          "a1b2c3",
+				},
+				{
+					Name:            "Outbound data transfer",
+					MonthlyQuantity: "0",
+					Unit:            "usage_based",
+					Price:           "0.087",
+					UsageBased:      true,
+				},
+			},
+			SubResources: []CostResourceModel{
+				{
+					Name:                "os_disk",
+					BaselineMonthlyCost: 1.54,
+					CostComponents: []CostComponentModel{
+						{
+							Name:            "Storage (S4, LRS)",
+							MonthlyQuantity: "1",
+							Unit:            "months",
+							Price:           "1.54",
+							HourlyCost:      0.0021,
+							MonthlyCost:     1.54,
+							Service:         "Storage",
+							Region:          "eastus",
+							SKU:             "S4 LRS",
+							Meter:           "S4 LRS Disk",
+							PriceSource:     tfschema.PriceSourcePriceSheet,
+						},
+					},
+					SubResources: []CostResourceModel{},
+				},
+			},
+		},
+	}, flattenResources([]*tfschema.Resource{res}))
+}
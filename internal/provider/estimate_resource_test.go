@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/plancost/terraform-provider-plancost/internal/testcase"
@@ -26,6 +27,7 @@ func TestAccEstimateResource_Basic(t *testing.T) {
 		Steps: []testcase.TestStep{
 			{
 				ConfigDirectory: path.Join(wd, "testdata", "basic"),
+				Apply:           true,
 				ConfigPlanChecks: resource.ConfigPlanChecks{
 					PreApply: []plancheck.PlanCheck{
 						plancheck.ExpectResourceAction("plancost_estimate.this", plancheck.ResourceActionCreate),
@@ -46,6 +48,12 @@ func TestAccEstimateResource_Basic(t *testing.T) {
 					},
 				},
 			},
+			{
+				// The applied estimate converges
+				ConfigDirectory:    path.Join(wd, "testdata", "basic"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
 		},
 	})
 }
@@ -321,3 +329,77 @@ func TestAccEstimateResource_MarkdownOutput(t *testing.T) {
 		},
 	})
 }
+
+func TestAccEstimateResource_PlanJSONFile(t *testing.T) {
+	wd, _ := os.Getwd()
+	testcase.Test(t, testcase.TestCase{
+		SkipInit: true,
+		Steps: []testcase.TestStep{
+			{
+				ConfigDirectory: path.Join(wd, "testdata", "plan_json"),
+				Apply:           true,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("plancost_estimate.this", plancheck.ResourceActionCreate),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("monthly_cost"), testcase.Float64Exact(7.3)),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("diff").AtSliceIndex(0).AtMapKey("action"), knownvalue.StringExact("added")),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("diff").AtSliceIndex(1).AtMapKey("name"), knownvalue.StringExact("azurerm_public_ip.example[1]")),
+						testcase.NewResourceCostCheck([]testcase.ResourceCost{
+							{
+								Name: "azurerm_public_ip.example[0]",
+								CostComponents: []testcase.CostComponent{
+									{
+										Name:            "IP address (static, regional)",
+										MonthlyCost:     "3.65",
+										MonthlyQuantity: "730",
+										Unit:            "hours",
+									},
+								},
+							},
+							{
+								Name: "azurerm_public_ip.example[1]",
+								CostComponents: []testcase.CostComponent{
+									{
+										Name:            "IP address (static, regional)",
+										MonthlyCost:     "3.65",
+										MonthlyQuantity: "730",
+										Unit:            "hours",
+									},
+								},
+							},
+						}),
+					},
+				},
+			},
+			{
+				// The applied estimate converges
+				ConfigDirectory:    path.Join(wd, "testdata", "plan_json"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccEstimateResource_Projects(t *testing.T) {
+	wd, _ := os.Getwd()
+	testcase.Test(t, testcase.TestCase{
+		SkipInit: true,
+		Steps: []testcase.TestStep{
+			{
+				ConfigDirectory: path.Join(wd, "testdata", "multi_project"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("plancost_estimate.this", plancheck.ResourceActionCreate),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("monthly_cost"), testcase.Float64Exact(10.95)),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("project_costs").AtSliceIndex(0).AtMapKey("name"), knownvalue.StringExact("dev")),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("project_costs").AtSliceIndex(0).AtMapKey("monthly_cost"), testcase.Float64Exact(3.65)),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("project_costs").AtSliceIndex(1).AtMapKey("name"), knownvalue.StringExact("prod")),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("project_costs").AtSliceIndex(1).AtMapKey("monthly_cost"), testcase.Float64Exact(7.3)),
+						plancheck.ExpectKnownValue("plancost_estimate.this", tfjsonpath.New("resources").AtSliceIndex(2).AtMapKey("project"), knownvalue.StringExact("prod")),
+					},
+				},
+			},
+		},
+	})
+}
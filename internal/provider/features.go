@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -122,16 +123,13 @@ func TaggingPolicies(paidTier bool, TaggingPolicy []TaggingPolicyModel, allParse
 	return resp
 }
 
-// Guardrails enforces cost guardrails based on the provided configurations. Free tier only allows 1 guardrail and does not enforce "block" actions.
-func Guardrails(paidTier bool, Guardrail []GuardrailModel, totalCost, previousCost float64) diag.Diagnostics {
+// Guardrails enforces cost guardrails based on the provided configurations. Project-wide conditions are evaluated against
+// the total cost, "resource_" conditions against every resource in diffs. Guardrails with a scope are evaluated against the
+// costs of the matching resources only. Amounts in messages are printed in the given currency.
+// Free tier only allows 1 guardrail and does not enforce "block" actions.
+func Guardrails(paidTier bool, Guardrail []GuardrailModel, totalCost, previousCost float64, resources []GuardrailResource, diffs []ResourceDiffModel, currency string) diag.Diagnostics {
 	var resp diag.Diagnostics
 
-	diffAmount := totalCost - previousCost
-	diffPercent := 0.0
-	if previousCost > 0 {
-		diffPercent = (diffAmount / previousCost) * 100
-	}
-
 	if !paidTier && len(Guardrail) > 1 {
 		// only allow 1 guardrail for free tier
 		resp.AddWarning("Guardrails Limited", "Guardrails enforcement is a paid feature. Please upgrade to the paid tier at https://plancost.io to enable this feature. Only the first guardrail will be evaluated.")
@@ -142,28 +140,17 @@ func Guardrails(paidTier bool, Guardrail []GuardrailModel, totalCost, previousCo
 		threshold, _ := guardrail.Threshold.ValueBigFloat().Float64()
 		action := guardrail.Action.ValueString()
 
-		triggered := false
-		var msg string
+		var msgs []string
 
-		switch condition {
-		case "monthly_cost_increase_amount":
-			if diffAmount > threshold {
-				triggered = true
-				msg = fmt.Sprintf("Monthly cost increase amount $%.2f exceeds threshold $%.2f.", diffAmount, threshold)
-			}
-		case "monthly_cost_increase_percentage":
-			if previousCost > 0 && diffPercent > threshold {
-				triggered = true
-				msg = fmt.Sprintf("Monthly cost increase percentage %.2f%% exceeds threshold %.2f%%.", diffPercent, threshold)
-			}
-		case "monthly_cost_budget":
-			if totalCost > threshold {
-				triggered = true
-				msg = fmt.Sprintf("Monthly cost $%.2f exceeds budget $%.2f.", totalCost, threshold)
-			}
+		if scope := newGuardrailScope(guardrail); isCoverageCondition(condition) {
+			msgs = coverageGuardrailViolations(scope, condition, threshold, allowedUnestimatedResourceTypes(guardrail), resources)
+		} else if !scope.isEmpty() {
+			msgs = scopedGuardrailViolations(scope, condition, threshold, resources, diffs, currency)
+		} else {
+			msgs = guardrailViolations(condition, threshold, totalCost, previousCost, diffs, currency)
 		}
 
-		if triggered {
+		for _, msg := range msgs {
 			if action == "block" {
 				if !paidTier {
 					msg += " Guardrails enforcement is a paid feature. Please upgrade to the paid tier at https://plancost.io to enable this feature."
@@ -185,6 +172,90 @@ func Guardrails(paidTier bool, Guardrail []GuardrailModel, totalCost, previousCo
 	return resp
 }
 
+// guardrailViolations evaluates a guardrail condition against the total cost and every resource in diffs.
+func guardrailViolations(condition string, threshold, totalCost, previousCost float64, diffs []ResourceDiffModel, currency string) []string {
+	var msgs []string
+
+	diffAmount := totalCost - previousCost
+	diffPercent := 0.0
+	if previousCost > 0 {
+		diffPercent = (diffAmount / previousCost) * 100
+	}
+
+	switch condition {
+	case "monthly_cost_increase_amount":
+		if diffAmount > threshold {
+			msgs = append(msgs, fmt.Sprintf("Monthly cost increase amount %s exceeds threshold %s.", formatCost(diffAmount, currency), formatCost(threshold, currency)))
+		}
+	case "monthly_cost_increase_percentage":
+		if previousCost > 0 && diffPercent > threshold {
+			msgs = append(msgs, fmt.Sprintf("Monthly cost increase percentage %.2f%% exceeds threshold %.2f%%.", diffPercent, threshold))
+		}
+	case "monthly_cost_budget":
+		if totalCost > threshold {
+			msgs = append(msgs, fmt.Sprintf("Monthly cost %s exceeds budget %s.", formatCost(totalCost, currency), formatCost(threshold, currency)))
+		}
+	case "resource_monthly_cost_increase_amount":
+		for _, d := range diffs {
+			if d.MonthlyCostChange > threshold {
+				msgs = append(msgs, fmt.Sprintf("Resource %s (%s) monthly cost increase amount %s (%s -> %s) exceeds threshold %s.", d.address(), d.Action, formatCost(d.MonthlyCostChange, currency), formatCost(d.PreviousMonthlyCost, currency), formatCost(d.MonthlyCost, currency), formatCost(threshold, currency)))
+			}
+		}
+	case "resource_monthly_cost_increase_percentage":
+		for _, d := range diffs {
+			if d.PreviousMonthlyCost <= 0 {
+				continue
+			}
+			if pct := (d.MonthlyCostChange / d.PreviousMonthlyCost) * 100; pct > threshold {
+				msgs = append(msgs, fmt.Sprintf("Resource %s monthly cost increase percentage %.2f%% (%s -> %s) exceeds threshold %.2f%%.", d.address(), pct, formatCost(d.PreviousMonthlyCost, currency), formatCost(d.MonthlyCost, currency), threshold))
+			}
+		}
+	}
+
+	return msgs
+}
+
+// scopedGuardrailViolations evaluates a guardrail condition against the resources in the scope. Violations of the
+// project-wide conditions name the resources that contribute most to the scope's cost or cost increase.
+func scopedGuardrailViolations(scope guardrailScope, condition string, threshold float64, resources []GuardrailResource, diffs []ResourceDiffModel, currency string) []string {
+	var msgs []string
+
+	scopeResources := scope.filterResources(pricedGuardrailResources(resources))
+	scopeDiffs := scope.filterDiffs(diffs, resources)
+
+	scopeCost := 0.0
+	for _, res := range scopeResources {
+		scopeCost += res.MonthlyCost
+	}
+	scopeChange := 0.0
+	for _, d := range scopeDiffs {
+		scopeChange += d.MonthlyCostChange
+	}
+	scopeCost, scopeChange = roundCost(scopeCost), roundCost(scopeChange)
+	previousScopeCost := roundCost(scopeCost - scopeChange)
+
+	switch condition {
+	case "monthly_cost_increase_amount":
+		if scopeChange > threshold {
+			msgs = append(msgs, fmt.Sprintf("Monthly cost increase amount %s of resources matching %s exceeds threshold %s. Increases: %s.", formatCost(scopeChange, currency), scope, formatCost(threshold, currency), describeCostIncreases(scopeDiffs, currency)))
+		}
+	case "monthly_cost_increase_percentage":
+		if previousScopeCost > 0 {
+			if pct := (scopeChange / previousScopeCost) * 100; pct > threshold {
+				msgs = append(msgs, fmt.Sprintf("Monthly cost increase percentage %.2f%% (%s -> %s) of resources matching %s exceeds threshold %.2f%%. Increases: %s.", pct, formatCost(previousScopeCost, currency), formatCost(scopeCost, currency), scope, threshold, describeCostIncreases(scopeDiffs, currency)))
+			}
+		}
+	case "monthly_cost_budget":
+		if scopeCost > threshold {
+			msgs = append(msgs, fmt.Sprintf("Monthly cost %s of resources matching %s exceeds budget %s. Resources: %s.", formatCost(scopeCost, currency), scope, formatCost(threshold, currency), describeResourceCosts(scopeResources, currency)))
+		}
+	default:
+		msgs = guardrailViolations(condition, threshold, 0, 0, scopeDiffs, currency)
+	}
+
+	return msgs
+}
+
 // Optimization provides optimization recommendations based on the provided core resources.
 func Optimization(paidTier bool, featureEnabled bool, coreResources []tfschema.CoreResource, allCostResources []*tfschema.Resource, priceFetcher *prices.PriceFetcher) []optimization.OptimizationRecommendation {
 	recommendations := make([]optimization.OptimizationRecommendation, 0)
@@ -193,9 +264,8 @@ func Optimization(paidTier bool, featureEnabled bool, coreResources []tfschema.C
 	}
 
 	// Savings Plans (New Structured)
-	if savingPlans := optimization.GetSavingPlans(allCostResources, priceFetcher); len(savingPlans) > 0 {
-		recommendations = append(recommendations, savingPlans...)
-	}
+	reservations := optimization.GetSavingPlans(allCostResources, priceFetcher)
+	recommendations = append(recommendations, reservations...)
 
 	if !paidTier && len(recommendations) > 0 {
 		// Teaser logic
@@ -228,7 +298,7 @@ func Optimization(paidTier bool, featureEnabled bool, coreResources []tfschema.C
 			savingsPct = ((totalSavings * 12) / yearlyCost) * 100
 		}
 
-		msg := fmt.Sprintf("We found reservations that could save you ~$%s/year (%.0f%%).\n [ Upgrade to Pro to see details ]\n", addCommas(fmt.Sprintf("%.0f", totalSavings*12)), savingsPct)
+		msg := fmt.Sprintf("We found reservations that could save you ~%s%s/year (%.0f%%).\n [ Upgrade to Pro to see details ]\n", prices.CurrencySymbol(priceFetcher.Currency()), addCommas(fmt.Sprintf("%.0f", totalSavings*12)), savingsPct)
 
 		return []optimization.OptimizationRecommendation{{
 			Description: msg,
@@ -237,19 +307,11 @@ func Optimization(paidTier bool, featureEnabled bool, coreResources []tfschema.C
 	}
 
 	if paidTier {
-		// Run optimization rules
-		rules := optimization.GetRules()
-		for _, cr := range coreResources {
-			for _, rule := range rules {
-				if rec := rule.Apply(&cr); rec != nil {
-					recommendations = append(recommendations, optimization.OptimizationRecommendation{
-						ResourceAddress: cr.BuildResource().Name,
-						Description:     *rec,
-						Type:            "Advisory",
-					})
-				}
-			}
-		}
+		// Savings Plans for Compute, aggregated over all the compute resources and compared to their reservations
+		recommendations = append(recommendations, optimization.GetComputeSavingsPlans(allCostResources, reservations, priceFetcher)...)
+
+		// Run optimization rules, with the savings of their alternative configurations
+		recommendations = append(recommendations, optimization.GetAdvisories(coreResources, priceFetcher)...)
 	}
 
 	return recommendations
@@ -265,10 +327,25 @@ func ConvertRecommendationsToAttrValue(opportunities []optimization.Optimization
 			"term":               types.StringType,
 			"savings_amount":     types.NumberType,
 			"savings_percentage": types.NumberType,
+			"severity":           types.StringType,
+			"replacement":        types.MapType{ElemType: types.StringType},
+			"hourly_commitment":  types.NumberType,
 		},
 	}
 
 	for _, opt := range opportunities {
+		replacement := types.MapNull(types.StringType)
+		if len(opt.Replacement) > 0 {
+			elements := make(map[string]attr.Value, len(opt.Replacement))
+			for k, v := range opt.Replacement {
+				elements[k] = types.StringValue(v)
+			}
+			replacement = types.MapValueMust(types.StringType, elements)
+		}
+		hourlyCommitment := types.NumberNull()
+		if opt.HourlyCommitment > 0 {
+			hourlyCommitment = types.NumberValue(decimal.NewFromFloat(opt.HourlyCommitment).BigFloat())
+		}
 		obj, _ := types.ObjectValue(
 			recType.AttrTypes,
 			map[string]attr.Value{
@@ -278,6 +355,9 @@ func ConvertRecommendationsToAttrValue(opportunities []optimization.Optimization
 				"term":               types.StringValue(opt.Term),
 				"savings_amount":     types.NumberValue(decimal.NewFromFloat(opt.SavingsAmount).BigFloat()),
 				"savings_percentage": types.NumberValue(decimal.NewFromFloat(opt.SavingsPercentage).BigFloat()),
+				"severity":           types.StringValue(opt.Severity),
+				"replacement":        replacement,
+				"hourly_commitment":  hourlyCommitment,
 			},
 		)
 		recList = append(recList, obj)
@@ -285,41 +365,54 @@ func ConvertRecommendationsToAttrValue(opportunities []optimization.Optimization
 	return types.ListValueMust(recType, recList)
 }
 
-// ApplyDiscount applies discounts to the resource and its sub-resources based on the provided discount configurations. Available in all tiers.
+// ApplyDiscount applies discounts to the components of the resource and its sub-resources. Discounts with a scope
+// stack in the order they are declared, and discounts without a scope only apply to the components that no scoped
+// discount matched. Discounts outside their validity window are skipped. The discounts are recorded per component.
+// Available in all tiers.
 func ApplyDiscount(resource *tfschema.Resource, discounts []DiscountModel) {
-	var applicableDiscount float64
-	foundSpecific := false
-
-	// First pass: look for specific resource type discount
-	for _, d := range discounts {
-		if !d.ResourceType.IsNull() && !d.ResourceType.IsUnknown() {
-			if d.ResourceType.ValueString() == resource.ResourceType {
-				perc, _ := d.Percentage.ValueBigFloat().Float64()
-				applicableDiscount = perc
-				foundSpecific = true
-				break
-			}
-		}
+	applyDiscountAt(resource, discounts, time.Now().UTC())
+}
+
+// applyDiscountAt applies the discounts that are valid at the time.
+func applyDiscountAt(resource *tfschema.Resource, discounts []DiscountModel, at time.Time) {
+	components := resourceCostComponents(resource)
+	for _, c := range components {
+		c.MonthlyDiscountPerc = 0
+		c.AppliedDiscounts = nil
 	}
+	resource.CalculateCosts()
 
-	// Second pass: if no specific found, look for global discount
-	if !foundSpecific {
-		for _, d := range discounts {
-			if d.ResourceType.IsNull() || d.ResourceType.IsUnknown() {
-				perc, _ := d.Percentage.ValueBigFloat().Float64()
-				applicableDiscount = perc
-				break
-			}
+	monthlyCosts := make(map[*tfschema.CostComponent]decimal.Decimal, len(components))
+	for _, c := range components {
+		if c.MonthlyCost != nil && c.MonthlyCost.IsPositive() {
+			monthlyCosts[c] = *c.MonthlyCost
 		}
 	}
 
-	if applicableDiscount > 0 {
-		for _, component := range resource.CostComponents {
-			component.MonthlyDiscountPerc = applicableDiscount
+	rules := newDiscountRules(discounts)
+	discounted := make(map[*tfschema.CostComponent]bool)
+	for _, scoped := range []bool{true, false} {
+		for _, rule := range rules {
+			if rule.isScoped() != scoped || !rule.isValidAt(at) || !rule.matchesResource(resource) {
+				continue
+			}
+			matched := make([]*tfschema.CostComponent, 0)
+			for _, c := range components {
+				if _, ok := monthlyCosts[c]; !ok || !rule.matchesComponent(c) || (!scoped && discounted[c]) {
+					continue
+				}
+				matched = append(matched, c)
+				if scoped {
+					discounted[c] = true
+				}
+			}
+			rule.apply(matched, monthlyCosts)
 		}
 	}
 
-	for _, sub := range resource.SubResources {
-		ApplyDiscount(sub, discounts)
+	for c, cost := range monthlyCosts {
+		if len(c.AppliedDiscounts) > 0 {
+			c.MonthlyDiscountPerc = decimal.NewFromInt(1).Sub(cost.Div(*c.MonthlyCost)).InexactFloat64()
+		}
 	}
 }
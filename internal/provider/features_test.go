@@ -0,0 +1,256 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"math/big"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestTaggingPoliciesGoogleLabels(t *testing.T) {
+	wd, _ := os.Getwd()
+	resources, _, err := ParseModule(path.Join(wd, "testdata", "tagging_policy_google"), tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	assert.NoError(t, err)
+
+	diags := TaggingPolicies(true, []TaggingPolicyModel{
+		{
+			Key:           types.StringValue("environment"),
+			AllowedValues: []types.String{types.StringValue("prod")},
+			Action:        types.StringValue("block"),
+		},
+	}, resources)
+
+	var messages []string
+	for _, d := range diags.Errors() {
+		messages = append(messages, d.Detail())
+	}
+
+	assert.ElementsMatch(t, []string{
+		"Resource google_storage_bucket.unlabelled (google_storage_bucket) missing required tag 'environment'",
+		"Resource google_sql_database_instance.user_labelled (google_sql_database_instance) tag 'environment' has invalid value 'dev'. Allowed: [prod]",
+	}, messages)
+}
+
+func TestGuardrailsPerResource(t *testing.T) {
+	diffs := []ResourceDiffModel{
+		{Name: "azurerm_linux_virtual_machine.resized", Action: ResourceDiffActionChanged, PreviousMonthlyCost: 70.08, MonthlyCost: 140.16, MonthlyCostChange: 70.08},
+		{Name: "azurerm_public_ip.added", Action: ResourceDiffActionAdded, PreviousMonthlyCost: 0, MonthlyCost: 3.65, MonthlyCostChange: 3.65},
+		{Name: "azurerm_public_ip.removed", Action: ResourceDiffActionRemoved, PreviousMonthlyCost: 3.65, MonthlyCost: 0, MonthlyCostChange: -3.65},
+	}
+
+	diags := Guardrails(true, []GuardrailModel{
+		{
+			Condition: types.StringValue("resource_monthly_cost_increase_amount"),
+			Threshold: types.NumberValue(big.NewFloat(50)),
+			Action:    types.StringValue("block"),
+		},
+		{
+			Condition: types.StringValue("resource_monthly_cost_increase_percentage"),
+			Threshold: types.NumberValue(big.NewFloat(10)),
+			Action:    types.StringValue("warning"),
+		},
+	}, 143.81, 73.73, nil, diffs, "USD")
+
+	if assert.Len(t, diags.Errors(), 1) {
+		assert.Equal(t, "Resource azurerm_linux_virtual_machine.resized (changed) monthly cost increase amount $70.08 ($70.08 -> $140.16) exceeds threshold $50.00.", diags.Errors()[0].Detail())
+	}
+	if assert.Len(t, diags.Warnings(), 1) {
+		assert.Equal(t, "Resource azurerm_linux_virtual_machine.resized monthly cost increase percentage 100.00% ($70.08 -> $140.16) exceeds threshold 10.00%.", diags.Warnings()[0].Detail())
+	}
+}
+
+func TestGuardrailsCurrency(t *testing.T) {
+	diags := Guardrails(true, []GuardrailModel{
+		{
+			Condition: types.StringValue("monthly_cost_budget"),
+			Threshold: types.NumberValue(big.NewFloat(100)),
+			Action:    types.StringValue("warning"),
+		},
+	}, 120.5, 0, nil, nil, "EUR")
+
+	if assert.Len(t, diags.Warnings(), 1) {
+		assert.Equal(t, "Monthly cost €120.50 exceeds budget €100.00.", diags.Warnings()[0].Detail())
+	}
+}
+
+func TestGuardrailsScoped(t *testing.T) {
+	resources := []GuardrailResource{
+		{Name: "module.aks.azurerm_kubernetes_cluster.main", ResourceType: "azurerm_kubernetes_cluster", Tags: map[string]string{"team": "payments"}, MonthlyCost: 140.16},
+		{Name: "module.aks.azurerm_public_ip.ingress", ResourceType: "azurerm_public_ip", Tags: map[string]string{"team": "payments"}, MonthlyCost: 3.65},
+		{Name: "azurerm_linux_virtual_machine.jumpbox", ResourceType: "azurerm_linux_virtual_machine", Tags: map[string]string{"team": "platform"}, MonthlyCost: 70.08},
+	}
+	diffs := []ResourceDiffModel{
+		{Name: "module.aks.azurerm_kubernetes_cluster.main", Action: ResourceDiffActionChanged, PreviousMonthlyCost: 70.08, MonthlyCost: 140.16, MonthlyCostChange: 70.08},
+		{Name: "azurerm_linux_virtual_machine.jumpbox", Action: ResourceDiffActionAdded, PreviousMonthlyCost: 0, MonthlyCost: 70.08, MonthlyCostChange: 70.08},
+		{Name: "module.aks.azurerm_public_ip.egress", Action: ResourceDiffActionRemoved, PreviousMonthlyCost: 3.65, MonthlyCost: 0, MonthlyCostChange: -3.65},
+	}
+
+	diags := Guardrails(true, []GuardrailModel{
+		{
+			Condition: types.StringValue("monthly_cost_budget"),
+			Threshold: types.NumberValue(big.NewFloat(100)),
+			Action:    types.StringValue("block"),
+			Tags:      map[string]types.String{"team": types.StringValue("payments")},
+		},
+		{
+			Condition: types.StringValue("monthly_cost_increase_amount"),
+			Threshold: types.NumberValue(big.NewFloat(50)),
+			Action:    types.StringValue("warning"),
+			Address:   types.StringValue("module.aks.*"),
+		},
+		{
+			Condition:    types.StringValue("monthly_cost_budget"),
+			Threshold:    types.NumberValue(big.NewFloat(100)),
+			Action:       types.StringValue("block"),
+			ResourceType: types.StringValue("azurerm_linux_virtual_machine"),
+		},
+		{
+			Condition:    types.StringValue("resource_monthly_cost_increase_amount"),
+			Threshold:    types.NumberValue(big.NewFloat(50)),
+			Action:       types.StringValue("warning"),
+			ResourceType: types.StringValue("azurerm_linux_virtual_machine"),
+		},
+	}, 213.89, 73.73, resources, diffs, "USD")
+
+	if assert.Len(t, diags.Errors(), 1) {
+		assert.Equal(t, "Monthly cost $143.81 of resources matching tag team=payments exceeds budget $100.00. Resources: module.aks.azurerm_kubernetes_cluster.main ($140.16), module.aks.azurerm_public_ip.ingress ($3.65).", diags.Errors()[0].Detail())
+	}
+	if assert.Len(t, diags.Warnings(), 2) {
+		assert.Equal(t, `Monthly cost increase amount $66.43 of resources matching address "module.aks.*" exceeds threshold $50.00. Increases: module.aks.azurerm_kubernetes_cluster.main (+$70.08).`, diags.Warnings()[0].Detail())
+		assert.Equal(t, "Resource azurerm_linux_virtual_machine.jumpbox (added) monthly cost increase amount $70.08 ($0.00 -> $70.08) exceeds threshold $50.00.", diags.Warnings()[1].Detail())
+	}
+}
+
+func TestApplyDiscount(t *testing.T) {
+	component := func(name, region string, price, quantity int64) *tfschema.CostComponent {
+		monthlyQuantity := decimal.NewFromInt(quantity)
+		c := &tfschema.CostComponent{
+			Name:            name,
+			ProductFilter:   &tfschema.ProductFilter{Region: &region},
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &monthlyQuantity,
+		}
+		c.SetPrice(decimal.NewFromInt(price).Div(decimal.NewFromInt(10)))
+		return c
+	}
+	tags := map[string]string{"environment": "prod"}
+	resources := []*tfschema.Resource{
+		{
+			Name:         "module.prod_app.azurerm_linux_virtual_machine.app",
+			ResourceType: "azurerm_linux_virtual_machine",
+			Tags:         &tags,
+			CostComponents: []*tfschema.CostComponent{
+				component("Instance usage (Linux, pay as you go, Standard_D2s_v5)", "eastus", 1, 730),
+				component("Storage (P10, LRS)", "eastus", 200, 1),
+			},
+		},
+		{
+			Name:           "azurerm_public_ip.dev",
+			ResourceType:   "azurerm_public_ip",
+			CostComponents: []*tfschema.CostComponent{component("IP address (static, regional)", "westeurope", 50, 1)},
+		},
+	}
+
+	discounts := []DiscountModel{
+		{
+			Name:          types.StringValue("EA compute"),
+			Percentage:    types.NumberValue(big.NewFloat(0.2)),
+			Address:       types.StringValue("module.prod_*"),
+			CostComponent: types.StringValue("instance usage*"),
+			Region:        types.StringValue("East US"),
+		},
+		{
+			Amount: types.NumberValue(big.NewFloat(10)),
+			Tags:   map[string]types.String{"environment": types.StringValue("prod")},
+		},
+		{
+			Percentage: types.NumberValue(big.NewFloat(0.1)),
+		},
+	}
+	for _, res := range resources {
+		ApplyDiscount(res, discounts)
+		res.CalculateCosts()
+	}
+
+	type discountedComponent struct {
+		Name        string
+		MonthlyCost float64
+		Discounts   []AppliedDiscountModel
+	}
+	var components []discountedComponent
+	for _, r := range flattenResources(resources) {
+		for _, c := range r.CostComponents {
+			components = append(components, discountedComponent{Name: c.Name, MonthlyCost: c.MonthlyCost, Discounts: c.Discounts})
+		}
+	}
+	assert.Equal(t, []discountedComponent{
+		{Name: "IP address (static, regional)", MonthlyCost: 4.5, Discounts: []AppliedDiscountModel{{Name: "discount[2]", MonthlySavings: 0.5}}},
+		{Name: "Instance usage (Linux, pay as you go, Standard_D2s_v5)", MonthlyCost: 50.95, Discounts: []AppliedDiscountModel{
+			{Name: "EA compute", MonthlySavings: 14.6},
+			{Name: "discount[1]", MonthlySavings: 7.45},
+		}},
+		{Name: "Storage (P10, LRS)", MonthlyCost: 17.45, Discounts: []AppliedDiscountModel{{Name: "discount[1]", MonthlySavings: 2.55}}},
+	}, components)
+}
+
+func TestApplyDiscount_Validity(t *testing.T) {
+	discounts := []DiscountModel{
+		{
+			Name:       types.StringValue("expired"),
+			Percentage: types.NumberValue(big.NewFloat(0.5)),
+			EndDate:    types.StringValue("2025-12-31"),
+		},
+		{
+			Name:       types.StringValue("current"),
+			Percentage: types.NumberValue(big.NewFloat(0.2)),
+			StartDate:  types.StringValue("2026-01-01"),
+			EndDate:    types.StringValue("2026-06-30"),
+		},
+		{
+			Name:       types.StringValue("future"),
+			Percentage: types.NumberValue(big.NewFloat(0.1)),
+			StartDate:  types.StringValue("2026-07-01"),
+		},
+	}
+
+	appliedAt := func(at string) []string {
+		monthlyQuantity := decimal.NewFromInt(730)
+		c := &tfschema.CostComponent{
+			Name:            "Instance usage (Linux, pay as you go, Standard_D2s_v5)",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &monthlyQuantity,
+		}
+		c.SetPrice(decimal.NewFromFloat(0.1))
+		res := &tfschema.Resource{Name: "azurerm_linux_virtual_machine.app", CostComponents: []*tfschema.CostComponent{c}}
+
+		date, err := time.Parse(time.RFC3339, at)
+		assert.NoError(t, err)
+		applyDiscountAt(res, discounts, date)
+
+		names := make([]string, 0)
+		for _, d := range c.AppliedDiscounts {
+			names = append(names, d.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"expired"}, appliedAt("2025-12-31T23:59:59Z"))
+	assert.Equal(t, []string{"current"}, appliedAt("2026-01-01T00:00:00Z"))
+	// The end date is the last day of the discount
+	assert.Equal(t, []string{"current"}, appliedAt("2026-06-30T23:59:59Z"))
+	assert.Equal(t, []string{"future"}, appliedAt("2026-07-01T00:00:00Z"))
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+)
+
+var _ function.Function = &FormatCostFunction{}
+
+// FormatCostFunction formats a cost the same way as the estimate view.
+type FormatCostFunction struct{}
+
+func NewFormatCostFunction() function.Function {
+	return &FormatCostFunction{}
+}
+
+func (f *FormatCostFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_cost"
+}
+
+func (f *FormatCostFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Format a cost with its currency symbol",
+		MarkdownDescription: "Returns `amount` rounded to cents, with thousands separators and the symbol of `currency`, the same way costs are shown in the `view` of `plancost_estimate`, e.g. `€1,234.50`.",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:                "amount",
+				MarkdownDescription: "The cost to format.",
+			},
+			function.StringParameter{
+				Name:                "currency",
+				MarkdownDescription: "The ISO 4217 code of the currency, e.g. `EUR`. Currencies without a known symbol are prefixed with their code.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FormatCostFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var amount float64
+	var currency string
+	if resp.Error = req.Arguments.Get(ctx, &amount, &currency); resp.Error != nil {
+		return
+	}
+
+	currency = strings.ToUpper(currency)
+	if !prices.CurrencyCodeRegex.MatchString(currency) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("The currency %q must be an ISO 4217 currency code, e.g. EUR.", currency))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, formatAmount(amount, currency))
+}
@@ -0,0 +1,26 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatCostFunction(t *testing.T) {
+	result, funcErr := runFunction(t, NewFormatCostFunction(), types.StringUnknown(), types.Float64Value(1234.5), types.StringValue("eur"))
+	require.Nil(t, funcErr)
+	assert.Equal(t, types.StringValue("€1,234.50"), result)
+
+	_, funcErr = runFunction(t, NewFormatCostFunction(), types.StringUnknown(), types.Float64Value(1234.5), types.StringValue("euro"))
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, "ISO 4217")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// conditionEstimateCoverage is breached when the percentage of resources that are supported and fully priced
+	// is below the threshold. It counts resources, not spend: an unsupported resource lowers the coverage the same
+	// whether it costs nothing or most of the bill. Resources of known free types count as fully priced.
+	conditionEstimateCoverage = "estimate_coverage_percentage"
+	// conditionUnpricedComponents is breached when more cost components than the threshold have no price.
+	conditionUnpricedComponents = "unpriced_monthly_components"
+)
+
+func isCoverageCondition(condition string) bool {
+	return condition == conditionEstimateCoverage || condition == conditionUnpricedComponents
+}
+
+// allowedUnestimatedResourceTypes returns the globs of the resource types that the guardrail accepts without an estimate.
+func allowedUnestimatedResourceTypes(guardrail GuardrailModel) []*regexp.Regexp {
+	allowed := make([]*regexp.Regexp, 0, len(guardrail.AllowedUnestimatedResourceTypes))
+	for _, t := range guardrail.AllowedUnestimatedResourceTypes {
+		if t.ValueString() != "" {
+			allowed = append(allowed, globRegex(t.ValueString()))
+		}
+	}
+	return allowed
+}
+
+// coverageGuardrailViolations evaluates a coverage condition against the resources in the scope, leaving out the
+// resources of the allowed resource types. Violations name the resources that are missing from the estimate.
+func coverageGuardrailViolations(scope guardrailScope, condition string, threshold float64, allowed []*regexp.Regexp, resources []GuardrailResource) []string {
+	var msgs []string
+
+	considered := make([]GuardrailResource, 0)
+	for _, res := range scope.filterResources(resources) {
+		if !isAllowedUnestimated(res.ResourceType, allowed) {
+			considered = append(considered, res)
+		}
+	}
+
+	subject := ""
+	if !scope.isEmpty() {
+		subject = fmt.Sprintf(" of resources matching %s", scope)
+	}
+
+	switch condition {
+	case conditionEstimateCoverage:
+		if len(considered) == 0 {
+			return msgs
+		}
+		var unestimated []string
+		for _, res := range considered {
+			if res.Unsupported {
+				unestimated = append(unestimated, fmt.Sprintf("%s (not supported)", res.address()))
+			} else if len(res.UnpricedComponents) > 0 {
+				unestimated = append(unestimated, fmt.Sprintf("%s (%d unpriced cost components)", res.address(), len(res.UnpricedComponents)))
+			}
+		}
+		coverage := roundCost(float64(len(considered)-len(unestimated)) / float64(len(considered)) * 100)
+		if coverage < threshold {
+			msgs = append(msgs, fmt.Sprintf("Estimate coverage %.2f%%%s is below threshold %.2f%%. %d of %d resources are not fully estimated: %s.", coverage, subject, threshold, len(unestimated), len(considered), limitDescriptions(unestimated)))
+		}
+	case conditionUnpricedComponents:
+		var unpriced []string
+		for _, res := range considered {
+			unpriced = append(unpriced, res.UnpricedComponents...)
+		}
+		if float64(len(unpriced)) > threshold {
+			msgs = append(msgs, fmt.Sprintf("%d cost components%s have no price, which exceeds threshold %s. Components: %s.", len(unpriced), subject, formatThreshold(threshold), limitDescriptions(unpriced)))
+		}
+	}
+
+	return msgs
+}
+
+func isAllowedUnestimated(resourceType string, allowed []*regexp.Regexp) bool {
+	for _, re := range allowed {
+		if re.MatchString(resourceType) {
+			return true
+		}
+	}
+	return false
+}
+
+// limitDescriptions joins the first descriptions of a guardrail violation, e.g. `a, b and 3 more`.
+func limitDescriptions(descriptions []string) string {
+	if len(descriptions) > maxGuardrailResources {
+		descriptions = append(descriptions[:maxGuardrailResources:maxGuardrailResources], fmt.Sprintf("and %d more", len(descriptions)-maxGuardrailResources))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// formatThreshold formats a count threshold without decimals when it is a whole number.
+func formatThreshold(threshold float64) string {
+	if threshold == float64(int64(threshold)) {
+		return fmt.Sprintf("%d", int64(threshold))
+	}
+	return fmt.Sprintf("%.2f", threshold)
+}
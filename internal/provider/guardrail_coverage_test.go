@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestNewGuardrailResources_Coverage(t *testing.T) {
+	cost := decimal.NewFromFloat(3.65)
+	unpriced := &tfschema.CostComponent{Name: "Data processed"}
+	unpriced.SetPriceNotFound()
+
+	resources := NewGuardrailResources("prod", []*tfschema.Resource{
+		{Name: "azurerm_public_ip.web", ResourceType: "azurerm_public_ip", MonthlyCost: &cost, CostComponents: []*tfschema.CostComponent{{Name: "IP address"}}},
+		{Name: "azurerm_lb.web", ResourceType: "azurerm_lb", CostComponents: []*tfschema.CostComponent{unpriced}},
+		{Name: "azurerm_resource_group.main", ResourceType: "azurerm_resource_group", IsSkipped: true, NoPrice: true},
+		{Name: "azurerm_lb_rule.web", ResourceType: "azurerm_lb_rule", IsSkipped: true, NoPrice: true},
+		{Name: "azurerm_spring_cloud_service.app", ResourceType: "azurerm_spring_cloud_service", IsSkipped: true},
+		{Name: "azurerm_virtual_network.main", ResourceType: "azurerm_virtual_network"},
+	})
+
+	assert.Equal(t, []GuardrailResource{
+		{Project: "prod", Name: "azurerm_public_ip.web", ResourceType: "azurerm_public_ip", MonthlyCost: 3.65},
+		{Project: "prod", Name: "azurerm_lb.web", ResourceType: "azurerm_lb", UnpricedComponents: []string{"prod:azurerm_lb.web (Data processed)"}},
+		{Project: "prod", Name: "azurerm_resource_group.main", ResourceType: "azurerm_resource_group", Free: true},
+		{Project: "prod", Name: "azurerm_spring_cloud_service.app", ResourceType: "azurerm_spring_cloud_service", Unsupported: true},
+	}, resources)
+}
+
+func TestGuardrailsCoverage(t *testing.T) {
+	resources := []GuardrailResource{
+		{Name: "azurerm_public_ip.web", ResourceType: "azurerm_public_ip", MonthlyCost: 3.65},
+		{Name: "azurerm_linux_virtual_machine.app", ResourceType: "azurerm_linux_virtual_machine", MonthlyCost: 70.08, Tags: map[string]string{"team": "payments"}},
+		{Name: "azurerm_lb.web", ResourceType: "azurerm_lb", UnpricedComponents: []string{"azurerm_lb.web (Data processed)", "azurerm_lb.web (Rules)"}},
+		{Name: "azurerm_spring_cloud_service.app", ResourceType: "azurerm_spring_cloud_service", Unsupported: true, Tags: map[string]string{"team": "payments"}},
+		{Name: "azurerm_monitor_action_group.ops", ResourceType: "azurerm_monitor_action_group", Unsupported: true},
+		{Name: "azurerm_resource_group.main", ResourceType: "azurerm_resource_group", Free: true},
+	}
+
+	tests := []struct {
+		name      string
+		guardrail GuardrailModel
+		expected  string
+	}{
+		{
+			name: "coverage",
+			guardrail: GuardrailModel{
+				Condition: types.StringValue("estimate_coverage_percentage"),
+				Threshold: types.NumberValue(big.NewFloat(90)),
+			},
+			expected: "Estimate coverage 50.00% is below threshold 90.00%. 3 of 6 resources are not fully estimated: azurerm_lb.web (2 unpriced cost components), azurerm_spring_cloud_service.app (not supported), azurerm_monitor_action_group.ops (not supported).",
+		},
+		{
+			name: "coverage with allowed resource types",
+			guardrail: GuardrailModel{
+				Condition: types.StringValue("estimate_coverage_percentage"),
+				Threshold: types.NumberValue(big.NewFloat(60)),
+				AllowedUnestimatedResourceTypes: []types.String{
+					types.StringValue("azurerm_monitor_*"),
+					types.StringValue("azurerm_lb"),
+				},
+			},
+		},
+		{
+			name: "scoped coverage",
+			guardrail: GuardrailModel{
+				Condition: types.StringValue("estimate_coverage_percentage"),
+				Threshold: types.NumberValue(big.NewFloat(100)),
+				Tags:      map[string]types.String{"team": types.StringValue("payments")},
+			},
+			expected: "Estimate coverage 50.00% of resources matching tag team=payments is below threshold 100.00%. 1 of 2 resources are not fully estimated: azurerm_spring_cloud_service.app (not supported).",
+		},
+		{
+			name: "unpriced components",
+			guardrail: GuardrailModel{
+				Condition: types.StringValue("unpriced_monthly_components"),
+				Threshold: types.NumberValue(big.NewFloat(0)),
+			},
+			expected: "2 cost components have no price, which exceeds threshold 0. Components: azurerm_lb.web (Data processed), azurerm_lb.web (Rules).",
+		},
+		{
+			name: "unpriced components within the threshold",
+			guardrail: GuardrailModel{
+				Condition: types.StringValue("unpriced_monthly_components"),
+				Threshold: types.NumberValue(big.NewFloat(2)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.guardrail.Action = types.StringValue("block")
+			diags := Guardrails(true, []GuardrailModel{tt.guardrail}, 73.73, 73.73, resources, nil, "USD")
+			if tt.expected == "" {
+				assert.Empty(t, diags)
+				return
+			}
+			require.Len(t, diags.Errors(), 1)
+			assert.Equal(t, tt.expected, diags.Errors()[0].Detail())
+		})
+	}
+
+	// Unsupported resources have no cost, so they are left out of the cost conditions
+	diags := Guardrails(true, []GuardrailModel{{
+		Condition: types.StringValue("monthly_cost_budget"),
+		Threshold: types.NumberValue(big.NewFloat(50)),
+		Action:    types.StringValue("block"),
+		Tags:      map[string]types.String{"team": types.StringValue("payments")},
+	}}, 73.73, 73.73, resources, nil, "USD")
+	require.Len(t, diags.Errors(), 1)
+	assert.Equal(t, "Monthly cost $70.08 of resources matching tag team=payments exceeds budget $50.00. Resources: azurerm_linux_virtual_machine.app ($70.08).", diags.Errors()[0].Detail())
+}
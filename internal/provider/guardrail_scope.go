@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform"
+)
+
+// maxGuardrailResources is the number of resources that are named in a scoped guardrail violation.
+const maxGuardrailResources = 10
+
+var addressIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// GuardrailResource is a resource of the new estimate that scoped guardrails are evaluated against. Unsupported
+// and free resources have no cost, and are only evaluated by the coverage conditions.
+type GuardrailResource struct {
+	Project      string
+	Name         string
+	ResourceType string
+	Tags         map[string]string
+	MonthlyCost  float64
+	Unsupported  bool
+	// Free is set for the resources of the types that the resource registry knows to be free.
+	Free bool
+	// UnpricedComponents are the cost components that no price was found for, e.g. `azurerm_lb.web (Data processed)`.
+	UnpricedComponents []string
+}
+
+// NewGuardrailResources returns the guardrail resources of a project's parsed resources. Resources of the types
+// that the resource registry knows to be free are kept, so that they count as estimated. Other resources without a
+// price, e.g. a basic load balancer rule, and resources without cost components are left out.
+func NewGuardrailResources(project string, resources []*tfschema.Resource) []GuardrailResource {
+	guardrailResources := make([]GuardrailResource, 0, len(resources))
+	for _, r := range resources {
+		free := r.NoPrice && isFreeResourceType(r.ResourceType)
+		if (r.NoPrice && !free) || (!r.IsSkipped && len(r.CostComponents) == 0 && len(r.SubResources) == 0) {
+			continue
+		}
+		res := GuardrailResource{
+			Project:      project,
+			Name:         r.Name,
+			ResourceType: r.ResourceType,
+			Unsupported:  r.IsSkipped && !free,
+			Free:         free,
+		}
+		if r.Tags != nil {
+			res.Tags = *r.Tags
+		}
+		if r.MonthlyCost != nil {
+			res.MonthlyCost = roundCost(r.MonthlyCost.InexactFloat64())
+		}
+		res.UnpricedComponents = missingPriceComponents(res.address(), r)
+		guardrailResources = append(guardrailResources, res)
+	}
+	return guardrailResources
+}
+
+// isFreeResourceType returns whether the resource registry knows the resource type to be free.
+func isFreeResourceType(resourceType string) bool {
+	registryItem, ok := (*terraform.ResourceRegistryMap)[resourceType]
+	return ok && registryItem.NoPrice
+}
+
+// pricedGuardrailResources returns the resources that are supported and not free, which the cost conditions are
+// evaluated against.
+func pricedGuardrailResources(resources []GuardrailResource) []GuardrailResource {
+	priced := make([]GuardrailResource, 0, len(resources))
+	for _, res := range resources {
+		if !res.Unsupported && !res.Free {
+			priced = append(priced, res)
+		}
+	}
+	return priced
+}
+
+// guardrailScope limits a guardrail to the resources with a resource type, an address matching a glob and tag values.
+// An empty scope matches every resource.
+type guardrailScope struct {
+	resourceType string
+	address      string
+	addressRegex *regexp.Regexp
+	tags         map[string]string
+}
+
+func newGuardrailScope(guardrail GuardrailModel) guardrailScope {
+	scope := guardrailScope{
+		resourceType: guardrail.ResourceType.ValueString(),
+		address:      guardrail.Address.ValueString(),
+		tags:         make(map[string]string, len(guardrail.Tags)),
+	}
+	if scope.address != "" {
+		scope.addressRegex = globRegex(scope.address)
+	}
+	for k, v := range guardrail.Tags {
+		scope.tags[k] = v.ValueString()
+	}
+	return scope
+}
+
+// globRegex returns a regex for a glob in which `*` matches any characters, including dots and brackets, so that
+// `module.aks.*` matches every resource in the module.
+func globRegex(glob string) *regexp.Regexp {
+	parts := strings.Split(glob, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+func (s guardrailScope) isEmpty() bool {
+	return s.resourceType == "" && s.address == "" && len(s.tags) == 0
+}
+
+func (s guardrailScope) matches(res GuardrailResource) bool {
+	if s.resourceType != "" && res.ResourceType != s.resourceType {
+		return false
+	}
+	if s.addressRegex != nil && !s.addressRegex.MatchString(res.Name) && !s.addressRegex.MatchString(res.address()) {
+		return false
+	}
+	for k, v := range s.tags {
+		if res.Tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the scope in guardrail violations, e.g. `resource_type "azurerm_kubernetes_cluster", tag team=payments`.
+func (s guardrailScope) String() string {
+	var parts []string
+	if s.resourceType != "" {
+		parts = append(parts, fmt.Sprintf("resource_type %q", s.resourceType))
+	}
+	if s.address != "" {
+		parts = append(parts, fmt.Sprintf("address %q", s.address))
+	}
+
+	keys := make([]string, 0, len(s.tags))
+	for k := range s.tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("tag %s=%s", k, s.tags[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// filterResources returns the resources in the scope.
+func (s guardrailScope) filterResources(resources []GuardrailResource) []GuardrailResource {
+	filtered := make([]GuardrailResource, 0)
+	for _, res := range resources {
+		if s.matches(res) {
+			filtered = append(filtered, res)
+		}
+	}
+	return filtered
+}
+
+// filterDiffs returns the diffs of the resources in the scope. Removed resources aren't in the new estimate, so their
+// resource type is taken from their address and they never match a tag scope.
+func (s guardrailScope) filterDiffs(diffs []ResourceDiffModel, resources []GuardrailResource) []ResourceDiffModel {
+	byAddress := make(map[string]GuardrailResource, len(resources))
+	for _, res := range resources {
+		byAddress[res.address()] = res
+	}
+
+	filtered := make([]ResourceDiffModel, 0)
+	for _, d := range diffs {
+		res, ok := byAddress[d.address()]
+		if !ok {
+			res = GuardrailResource{Project: d.Project, Name: d.Name, ResourceType: resourceTypeFromAddress(d.Name)}
+		}
+		if s.matches(res) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+func (r GuardrailResource) address() string {
+	if r.Project == "" {
+		return r.Name
+	}
+	return r.Project + ":" + r.Name
+}
+
+// resourceTypeFromAddress returns the resource type of a resource address such as `module.app.azurerm_public_ip.web["a"]`.
+func resourceTypeFromAddress(address string) string {
+	parts := strings.Split(addressIndexRegex.ReplaceAllString(address, ""), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
+
+// describeResourceCosts lists the most expensive resources with their monthly cost, e.g. `a ($10.00), b ($5.00)`.
+func describeResourceCosts(resources []GuardrailResource, currency string) string {
+	sorted := make([]GuardrailResource, len(resources))
+	copy(sorted, resources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MonthlyCost > sorted[j].MonthlyCost
+	})
+
+	descriptions := make([]string, 0, maxGuardrailResources)
+	for i, res := range sorted {
+		if i == maxGuardrailResources {
+			descriptions = append(descriptions, fmt.Sprintf("and %d more", len(sorted)-maxGuardrailResources))
+			break
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", res.address(), formatCost(res.MonthlyCost, currency)))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// describeCostIncreases lists the resources with the largest monthly cost increases, e.g. `a (+$10.00)`.
+func describeCostIncreases(diffs []ResourceDiffModel, currency string) string {
+	increases := make([]ResourceDiffModel, 0)
+	for _, d := range diffs {
+		if d.MonthlyCostChange > 0 {
+			increases = append(increases, d)
+		}
+	}
+	sort.SliceStable(increases, func(i, j int) bool {
+		return increases[i].MonthlyCostChange > increases[j].MonthlyCostChange
+	})
+
+	descriptions := make([]string, 0, maxGuardrailResources)
+	for i, d := range increases {
+		if i == maxGuardrailResources {
+			descriptions = append(descriptions, fmt.Sprintf("and %d more", len(increases)-maxGuardrailResources))
+			break
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (+%s)", d.address(), formatCost(d.MonthlyCostChange, currency)))
+	}
+	return strings.Join(descriptions, ", ")
+}
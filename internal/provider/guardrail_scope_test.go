@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardrailScope(t *testing.T) {
+	res := GuardrailResource{
+		Project:      "prod",
+		Name:         `module.aks["west"].azurerm_kubernetes_cluster.main`,
+		ResourceType: "azurerm_kubernetes_cluster",
+		Tags:         map[string]string{"team": "payments", "env": "prod"},
+	}
+
+	testCases := []struct {
+		name      string
+		guardrail GuardrailModel
+		expected  bool
+	}{
+		{"resource type", GuardrailModel{ResourceType: types.StringValue("azurerm_kubernetes_cluster")}, true},
+		{"other resource type", GuardrailModel{ResourceType: types.StringValue("azurerm_public_ip")}, false},
+		{"address glob", GuardrailModel{Address: types.StringValue("module.aks*")}, true},
+		{"project address glob", GuardrailModel{Address: types.StringValue("prod:module.aks*")}, true},
+		{"other address glob", GuardrailModel{Address: types.StringValue("module.app.*")}, false},
+		{"tags", GuardrailModel{Tags: map[string]types.String{"team": types.StringValue("payments")}}, true},
+		{"other tag value", GuardrailModel{Tags: map[string]types.String{"team": types.StringValue("platform")}}, false},
+		{"all", GuardrailModel{
+			ResourceType: types.StringValue("azurerm_kubernetes_cluster"),
+			Address:      types.StringValue("*.main"),
+			Tags:         map[string]types.String{"env": types.StringValue("prod")},
+		}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, newGuardrailScope(tc.guardrail).matches(res))
+		})
+	}
+}
+
+func TestResourceTypeFromAddress(t *testing.T) {
+	assert.Equal(t, "azurerm_public_ip", resourceTypeFromAddress("azurerm_public_ip.web"))
+	assert.Equal(t, "azurerm_public_ip", resourceTypeFromAddress(`module.app["a.b"].azurerm_public_ip.web[0]`))
+	assert.Equal(t, "", resourceTypeFromAddress("invalid"))
+}
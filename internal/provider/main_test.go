@@ -0,0 +1,18 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/plancost/terraform-provider-plancost/internal/testcase"
+)
+
+func TestMain(m *testing.M) {
+	testcase.Main(m)
+}
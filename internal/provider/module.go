@@ -10,29 +10,99 @@ package provider
 import (
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/plancost/terraform-provider-plancost/internal/hclparser/hcl"
 	"github.com/plancost/terraform-provider-plancost/internal/hclparser/terraform"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
 	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
 )
 
-func ParseModule(moduleSourceDir string, usageDataMap tfschema.UsageMap, variableOptions ...hcl.Option) ([]*tfschema.Resource, []tfschema.CoreResource, error) {
-	provider, err := terraform.NewHCLProvider(moduleSourceDir, &terraform.HCLProviderConfig{}, variableOptions...)
+// ParseModule loads the resources of the module in moduleSourceDir. The partial resources that the resources are
+// built from are returned as well, as they hold the core resources and the attribute values of the resources.
+func ParseModule(moduleSourceDir string, usageDataMap tfschema.UsageMap, variableOptions ...hcl.Option) ([]*tfschema.Resource, []*tfschema.PartialResource, error) {
+	project, err := loadModule(moduleSourceDir, usageDataMap, variableOptions...)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	res := buildResources(project.PartialResources)
+	return res, project.PartialResources, nil
+}
+
+func loadModule(moduleSourceDir string, usageDataMap tfschema.UsageMap, variableOptions ...hcl.Option) (*tfschema.Project, error) {
+	provider, err := terraform.NewHCLProvider(moduleSourceDir, &terraform.HCLProviderConfig{}, variableOptions...)
+	if err != nil {
+		return nil, err
+	}
+
 	projects, err := provider.LoadResources(usageDataMap)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if len(projects) == 0 {
-		return nil, nil, fmt.Errorf("LoadResources returns empty projects")
+		return nil, fmt.Errorf("LoadResources returns empty projects")
+	}
+	return projects[0], nil
+}
+
+// loadPlanJSON loads the project of a plan JSON file produced by `terraform show -json`. The plan holds the
+// values computed by Terraform, so it doesn't suffer from the unknown values that parsing the module HCL leaves
+// behind. The resources in the plan's prior state are loaded as the past resources.
+func loadPlanJSON(planJSONFile string, usageDataMap tfschema.UsageMap) (*tfschema.Project, error) {
+	provider := terraform.NewPlanJSONProvider(planJSONFile, true)
+
+	projects, err := provider.LoadResources(usageDataMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("LoadResources returns empty projects")
+	}
+	return projects[0], nil
+}
+
+// parseEstimateProject parses the resources of a project, either from its plan JSON file if one is
+// set or from the module in its working directory. Past resources are only known for plan JSON files.
+// The resources are priced with the pricing profile of the project. Parsing failures and warnings are
+// returned as diagnostics, so that resources missing from the estimate are never silently left out.
+func parseEstimateProject(project estimateProject, usageDataMap tfschema.UsageMap) ([]*tfschema.Resource, []*tfschema.Resource, []*tfschema.PartialResource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	source := project.WorkingDirectory
+	var loaded *tfschema.Project
+	var err error
+	if project.PlanJSONFile != "" {
+		source = project.PlanJSONFile
+		loaded, err = loadPlanJSON(project.PlanJSONFile, usageDataMap)
+	} else {
+		options := expandVariableOptions(project.VarFiles, project.WorkingDirectory)
+		loaded, err = loadModule(project.WorkingDirectory, usageDataMap, options...)
+	}
+	if err != nil {
+		diags.AddError(
+			"Module Calculation Error",
+			fmt.Sprintf("Failed to calculate module %s: %s", source, err.Error()),
+		)
+		return nil, nil, nil, diags
+	}
+	diags.Append(ProjectDiagnostics(loaded.Metadata)...)
+
+	applyPricingProfile(loaded.PartialResources, project.PricingProfile)
+	res := buildResources(loaded.PartialResources)
+	if project.PlanJSONFile == "" {
+		return res, nil, loaded.PartialResources, diags
 	}
 
+	applyPricingProfile(loaded.PartialPastResources, project.PricingProfile)
+	pastRes := buildResources(loaded.PartialPastResources)
+	return res, pastRes, loaded.PartialResources, diags
+}
+
+func buildResources(partialResources []*tfschema.PartialResource) []*tfschema.Resource {
 	res := make([]*tfschema.Resource, 0)
-	coreResources := make([]tfschema.CoreResource, 0)
-	for _, rd := range projects[0].PartialResources {
+	for _, rd := range partialResources {
 		if rd.Resource != nil {
 			rd.Resource.ResourceType = rd.Type
 			rd.Resource.Tags = rd.Tags
@@ -40,7 +110,6 @@ func ParseModule(moduleSourceDir string, usageDataMap tfschema.UsageMap, variabl
 			continue
 		}
 		if rd.CoreResource != nil {
-			coreResources = append(coreResources, rd.CoreResource)
 			if rd.UsageData != nil {
 				rd.CoreResource.PopulateUsage(rd.UsageData)
 			}
@@ -54,5 +123,52 @@ func ParseModule(moduleSourceDir string, usageDataMap tfschema.UsageMap, variabl
 			continue
 		}
 	}
-	return res, coreResources, nil
+	return res
+}
+
+// partialCoreResources returns the core resources of the partial resources.
+func partialCoreResources(partialResources []*tfschema.PartialResource) []tfschema.CoreResource {
+	coreResources := make([]tfschema.CoreResource, 0)
+	for _, rd := range partialResources {
+		if rd.CoreResource != nil {
+			coreResources = append(coreResources, rd.CoreResource)
+		}
+	}
+	return coreResources
+}
+
+// PriceResources populates prices for the parsed resources that have cost components, applies the
+// configured discounts and calculates their costs. It returns the priced resources and their total monthly cost.
+func PriceResources(priceFetcher *prices.PriceFetcher, allParsedResources []*tfschema.Resource, discounts []DiscountModel) ([]*tfschema.Resource, float64, error) {
+	allCostResources := make([]*tfschema.Resource, 0)
+	for _, res := range allParsedResources {
+		if len(res.CostComponents) == 0 && len(res.SubResources) == 0 {
+			continue
+		}
+		allCostResources = append(allCostResources, res)
+	}
+
+	// Populate prices for all cost components
+	if err := priceFetcher.PopulatePrices(allCostResources); err != nil {
+		return nil, 0, err
+	}
+
+	// Apply discounts
+	if len(discounts) > 0 {
+		for _, costResource := range allCostResources {
+			ApplyDiscount(costResource, discounts)
+		}
+	}
+
+	totalCost := 0.0
+	for _, costResource := range allCostResources {
+		// Calculate costs based on populated prices
+		costResource.CalculateCosts()
+
+		// Add resource costs to total
+		if costResource.MonthlyCost != nil {
+			totalCost += costResource.MonthlyCost.InexactFloat64()
+		}
+	}
+	return allCostResources, totalCost, nil
 }
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestParseEstimateProject_PlanJSON(t *testing.T) {
+	wd, _ := os.Getwd()
+	project := estimateProject{PlanJSONFile: path.Join(wd, "testdata", "plan_json", "tfplan.json")}
+	resources, pastResources, partialResources, diags := parseEstimateProject(project, tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, partialResources)
+
+	costResources := make(map[string]*tfschema.Resource)
+	for _, r := range resources {
+		if len(r.CostComponents) > 0 {
+			costResources[r.Name] = r
+		}
+	}
+
+	// The count comes from a variable that is only known through the plan.
+	assert.Len(t, costResources, 2)
+	for _, name := range []string{"azurerm_public_ip.example[0]", "azurerm_public_ip.example[1]"} {
+		r, ok := costResources[name]
+		if assert.True(t, ok, "missing resource %s", name) {
+			assert.Equal(t, "azurerm_public_ip", r.ResourceType)
+			assert.Equal(t, "IP address (static, regional)", r.CostComponents[0].Name)
+			if assert.NotNil(t, r.Tags) {
+				assert.Equal(t, "prod", (*r.Tags)["environment"])
+			}
+		}
+	}
+
+	// Only the resource group exists in the prior state.
+	pastNames := make([]string, 0)
+	for _, r := range pastResources {
+		pastNames = append(pastNames, r.Name)
+	}
+	assert.Equal(t, []string{"azurerm_resource_group.example"}, pastNames)
+}
+
+func TestParseEstimateProject_MissingPlanJSON(t *testing.T) {
+	project := estimateProject{PlanJSONFile: path.Join(t.TempDir(), "tfplan.json")}
+	_, _, _, diags := parseEstimateProject(project, tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	if assert.True(t, diags.HasError()) {
+		assert.Equal(t, "Module Calculation Error", diags.Errors()[0].Summary())
+	}
+}
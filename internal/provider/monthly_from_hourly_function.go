@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+var _ function.Function = &MonthlyFromHourlyFunction{}
+
+// MonthlyFromHourlyFunction converts an hourly cost into a monthly cost.
+type MonthlyFromHourlyFunction struct{}
+
+func NewMonthlyFromHourlyFunction() function.Function {
+	return &MonthlyFromHourlyFunction{}
+}
+
+func (f *MonthlyFromHourlyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "monthly_from_hourly"
+}
+
+func (f *MonthlyFromHourlyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert an hourly cost into a monthly cost",
+		MarkdownDescription: "Returns the monthly cost of `hourly_cost`, using the same 730 hours per month as `plancost_estimate`.",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:                "hourly_cost",
+				MarkdownDescription: "The hourly cost, e.g. the result of `provider::plancost::price`.",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *MonthlyFromHourlyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var hourlyCost float64
+	if resp.Error = req.Arguments.Get(ctx, &hourlyCost); resp.Error != nil {
+		return
+	}
+
+	// Multiply as decimals so that prices such as 0.005 don't pick up floating point noise
+	monthlyCost := decimal.NewFromFloat(hourlyCost).Mul(tfschema.HourToMonthUnitMultiplier)
+	resp.Error = resp.Result.Set(ctx, monthlyCost.InexactFloat64())
+}
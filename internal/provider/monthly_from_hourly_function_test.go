@@ -0,0 +1,22 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyFromHourlyFunction(t *testing.T) {
+	result, funcErr := runFunction(t, NewMonthlyFromHourlyFunction(), types.Float64Unknown(), types.Float64Value(0.005))
+	require.Nil(t, funcErr)
+	assert.Equal(t, types.Float64Value(3.65), result)
+}
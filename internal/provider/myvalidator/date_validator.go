@@ -0,0 +1,39 @@
+package myvalidator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Custom validator for dates in the YYYY-MM-DD format
+type dateValidator struct{}
+
+func (v dateValidator) Description(ctx context.Context) string {
+	return "string must be a date in the YYYY-MM-DD format"
+}
+
+func (v dateValidator) MarkdownDescription(ctx context.Context) string {
+	return "string must be a date in the `YYYY-MM-DD` format"
+}
+
+func (v dateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := time.Parse(time.DateOnly, req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Date",
+			fmt.Sprintf("The string must be a date in the YYYY-MM-DD format: %s", err),
+		)
+	}
+}
+
+func ValidDate() validator.String {
+	return dateValidator{}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/plancost/terraform-provider-plancost/internal/dynamic"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+var _ function.Function = &PriceFunction{}
+
+// attributeFilterRegex matches attribute filter values in the `/regex/flags` format of the pricing service.
+var attributeFilterRegex = regexp.MustCompile(`^/.+/[a-z]*$`)
+
+// sharedPriceFetcher is the price fetcher of the price functions of a provider instance. Provider functions don't
+// receive the provider data, so Configure shares the fetcher of the provider configuration through it. If the function
+// is called before the provider is configured, e.g. during validation, a fetcher for the PLANCOST_* environment
+// variables is built once.
+type sharedPriceFetcher struct {
+	mux        sync.Mutex
+	configured *prices.PriceFetcher
+	env        *prices.PriceFetcher
+	envDiags   diag.Diagnostics
+	envOnce    sync.Once
+}
+
+// setConfigured shares the price fetcher of the configured provider with the price function.
+func (s *sharedPriceFetcher) setConfigured(priceFetcher *prices.PriceFetcher) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.configured = priceFetcher
+}
+
+// get returns the price fetcher of the configured provider, or the fetcher for the environment variables.
+func (s *sharedPriceFetcher) get() (*prices.PriceFetcher, diag.Diagnostics) {
+	s.mux.Lock()
+	configured := s.configured
+	s.mux.Unlock()
+	if configured != nil {
+		return configured, nil
+	}
+
+	s.envOnce.Do(func() {
+		// A function call never clears the price cache, that only happens when the provider is configured
+		s.env, s.envDiags = newPriceFetcher(PlanCostProviderModel{ClearPriceCache: types.BoolValue(false)})
+	})
+	return s.env, s.envDiags
+}
+
+// PriceFunction looks up the unit price of a single product in the pricing service.
+type PriceFunction struct {
+	fetcher *sharedPriceFetcher
+}
+
+// PriceFilterModel is the product and price filter accepted by the price function.
+type PriceFilterModel struct {
+	VendorName       string            `json:"vendor_name"`
+	Service          string            `json:"service"`
+	ProductFamily    string            `json:"product_family"`
+	Region           string            `json:"region"`
+	Sku              string            `json:"sku"`
+	AttributeFilters map[string]string `json:"attribute_filters"`
+	PurchaseOption   string            `json:"purchase_option"`
+	Unit             string            `json:"unit"`
+	Description      string            `json:"description"`
+	StartUsageAmount string            `json:"start_usage_amount"`
+	TermLength       string            `json:"term_length"`
+}
+
+func NewPriceFunction(fetcher *sharedPriceFetcher) function.Function {
+	return &PriceFunction{fetcher: fetcher}
+}
+
+func (f *PriceFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "price"
+}
+
+func (f *PriceFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Look up the unit price of a product",
+		MarkdownDescription: "Returns the unit price of the product that matches `sku_filter`, in the currency of the provider. Prices are fetched with the pricing service, price snapshot, price sheet, price cache and currency settings of the provider configuration. Provider functions can't read the provider configuration until the provider is configured, so calls before that, e.g. during `terraform validate`, use the `PLANCOST_*` environment variables instead. If several prices match, the smallest non-zero price is returned. Prices are looked up when the function is called, so the result can change between runs when the prices of the pricing service change; use `pricing_snapshot_file` for results that don't change.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "sku_filter",
+				MarkdownDescription: "An object with the `vendor_name` (required), `service`, `product_family`, `region`, `sku` and `attribute_filters` of the product, and the `purchase_option`, `unit`, `description`, `start_usage_amount` and `term_length` of the price. `attribute_filters` is a map of product attribute values; values in the `/regex/i` format are matched as regular expressions.",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *PriceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filterValue types.Dynamic
+	if resp.Error = req.Arguments.Get(ctx, &filterValue); resp.Error != nil {
+		return
+	}
+
+	filter, err := decodePriceFilter(filterValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	priceFetcher, diags := f.fetcher.get()
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	component := &tfschema.CostComponent{
+		Name:          "Price",
+		ProductFilter: filter.productFilter(),
+		PriceFilter:   filter.priceFilter(),
+	}
+	resource := &tfschema.Resource{
+		Name:           "provider::plancost::price",
+		ResourceType:   "provider::plancost::price",
+		CostComponents: []*tfschema.CostComponent{component},
+	}
+	if err := priceFetcher.PopulatePrices([]*tfschema.Resource{resource}); err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Failed to fetch the price: %s", err.Error()))
+		return
+	}
+	// The fetcher is shared between calls, so the missing prices of the fetcher aren't only those of this call
+	if component.PriceNotFound {
+		b, _ := json.Marshal(filter)
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("No price found for %s", string(b)))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, component.Price().InexactFloat64())
+}
+
+// decodePriceFilter decodes the sku_filter argument. Unknown keys are rejected so that a misspelled filter
+// doesn't silently match a different product.
+func decodePriceFilter(value types.Dynamic) (PriceFilterModel, error) {
+	var filter PriceFilterModel
+
+	b, err := dynamic.ToJSON(value)
+	if err != nil {
+		return filter, fmt.Errorf("invalid sku_filter: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&filter); err != nil {
+		return filter, fmt.Errorf("invalid sku_filter: %w", err)
+	}
+
+	if filter.VendorName == "" {
+		return filter, fmt.Errorf("invalid sku_filter: vendor_name is required")
+	}
+	return filter, nil
+}
+
+func (m PriceFilterModel) productFilter() *tfschema.ProductFilter {
+	filter := &tfschema.ProductFilter{
+		VendorName:    optionalString(m.VendorName),
+		Service:       optionalString(m.Service),
+		ProductFamily: optionalString(m.ProductFamily),
+		Region:        optionalString(m.Region),
+		Sku:           optionalString(m.Sku),
+	}
+
+	// Sort the attribute filters so that the same filter always results in the same query
+	keys := make([]string, 0, len(m.AttributeFilters))
+	for k := range m.AttributeFilters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := m.AttributeFilters[k]
+		if attributeFilterRegex.MatchString(v) {
+			filter.AttributeFilters = append(filter.AttributeFilters, &tfschema.AttributeFilter{Key: k, ValueRegex: &v})
+		} else {
+			filter.AttributeFilters = append(filter.AttributeFilters, &tfschema.AttributeFilter{Key: k, Value: &v})
+		}
+	}
+	return filter
+}
+
+func (m PriceFilterModel) priceFilter() *tfschema.PriceFilter {
+	if m.PurchaseOption == "" && m.Unit == "" && m.Description == "" && m.StartUsageAmount == "" && m.TermLength == "" {
+		return nil
+	}
+	return &tfschema.PriceFilter{
+		PurchaseOption:   optionalString(m.PurchaseOption),
+		Unit:             optionalString(m.Unit),
+		Description:      optionalString(m.Description),
+		StartUsageAmount: optionalString(m.StartUsageAmount),
+		TermLength:       optionalString(m.TermLength),
+	}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/dynamic"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFunction calls the provider function with the arguments and returns its result.
+func runFunction(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestPriceFunction(t *testing.T) {
+	fetcher := &sharedPriceFetcher{}
+	strPtr := func(s string) *string { return &s }
+
+	snapshotFile := filepath.Join(t.TempDir(), "prices.json")
+	snapshot := map[string]interface{}{
+		"version":  apiclient.PriceSnapshotVersion,
+		"currency": "EUR",
+		"entries": []interface{}{
+			map[string]interface{}{
+				"productFilter": &tfschema.ProductFilter{
+					VendorName: strPtr("azure"),
+					Service:    strPtr("Virtual Machines"),
+					Region:     strPtr("westeurope"),
+					AttributeFilters: []*tfschema.AttributeFilter{
+						{Key: "productName", ValueRegex: strPtr("/Windows/i")},
+						{Key: "skuName", Value: strPtr("D2s v3")},
+					},
+				},
+				"priceFilter": &tfschema.PriceFilter{PurchaseOption: strPtr("Consumption")},
+				"result":      json.RawMessage(`{"data":{"products":[{"prices":[{"priceHash":"a","EUR":"0.176"}]}]}}`),
+			},
+		},
+	}
+	b, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(snapshotFile, b, 0644))
+	t.Setenv("PLANCOST_PRICING_SNAPSHOT_FILE", snapshotFile)
+
+	filter, err := dynamic.ToDynamic(map[string]interface{}{
+		"vendor_name":     "azure",
+		"service":         "Virtual Machines",
+		"region":          "westeurope",
+		"purchase_option": "Consumption",
+		"attribute_filters": map[string]string{
+			"skuName":     "D2s v3",
+			"productName": "/Windows/i",
+		},
+	})
+	require.NoError(t, err)
+
+	result, funcErr := runFunction(t, NewPriceFunction(fetcher), types.Float64Unknown(), filter)
+	require.Nil(t, funcErr)
+	assert.Equal(t, types.Float64Value(0.176), result)
+
+	// A product that isn't in the snapshot has no price
+	filter, err = dynamic.ToDynamic(map[string]interface{}{"vendor_name": "azure", "service": "Unknown"})
+	require.NoError(t, err)
+
+	_, funcErr = runFunction(t, NewPriceFunction(fetcher), types.Float64Unknown(), filter)
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, "No price found")
+
+	// A found price isn't reported as missing because of an earlier call
+	filter, err = dynamic.ToDynamic(map[string]interface{}{
+		"vendor_name":     "azure",
+		"service":         "Virtual Machines",
+		"region":          "westeurope",
+		"purchase_option": "Consumption",
+		"attribute_filters": map[string]string{
+			"skuName":     "D2s v3",
+			"productName": "/Windows/i",
+		},
+	})
+	require.NoError(t, err)
+
+	result, funcErr = runFunction(t, NewPriceFunction(fetcher), types.Float64Unknown(), filter)
+	require.Nil(t, funcErr)
+	assert.Equal(t, types.Float64Value(0.176), result)
+}
+
+func TestPriceFunction_Fetcher(t *testing.T) {
+	fetcher := &sharedPriceFetcher{}
+
+	cacheDir := t.TempDir()
+	cached := filepath.Join(cacheDir, "1f.json")
+	require.NoError(t, os.WriteFile(cached, []byte("{}"), 0644))
+	t.Setenv("PLANCOST_PRICE_CACHE_DIR", cacheDir)
+	t.Setenv("PLANCOST_CLEAR_PRICE_CACHE", "true")
+
+	// The fetcher for the environment variables is built once and doesn't clear the price cache
+	first, diags := fetcher.get()
+	require.False(t, diags.HasError())
+	second, _ := fetcher.get()
+	assert.Same(t, first, second)
+	assert.FileExists(t, cached)
+
+	// The fetcher of the configured provider is used once the provider is configured
+	configured := prices.NewPriceFetcher("https://api.plancost.io", "")
+	fetcher.setConfigured(configured)
+	got, _ := fetcher.get()
+	assert.Same(t, configured, got)
+
+	// Each provider instance has its own fetcher, so the configuration of one doesn't leak into another
+	first = prices.NewPriceFetcher("https://api.plancost.io", "")
+	second = prices.NewPriceFetcher("https://api.plancost.io", "")
+	p1, p2 := &PlanCostProvider{}, &PlanCostProvider{}
+	p1.priceFunctionFetcher.setConfigured(first)
+	p2.priceFunctionFetcher.setConfigured(second)
+	got, _ = p1.priceFunctionFetcher.get()
+	assert.Same(t, first, got)
+	got, _ = p2.priceFunctionFetcher.get()
+	assert.Same(t, second, got)
+}
+
+func TestPriceFunction_InvalidFilter(t *testing.T) {
+	filter, err := dynamic.ToDynamic(map[string]interface{}{"vendor_name": "azure", "sku_name": "D2s v3"})
+	require.NoError(t, err)
+
+	_, funcErr := runFunction(t, NewPriceFunction(&sharedPriceFetcher{}), types.Float64Unknown(), filter)
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, `unknown field "sku_name"`)
+
+	filter, err = dynamic.ToDynamic(map[string]interface{}{"service": "Virtual Machines"})
+	require.NoError(t, err)
+
+	_, funcErr = runFunction(t, NewPriceFunction(&sharedPriceFetcher{}), types.Float64Unknown(), filter)
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, "vendor_name is required")
+}
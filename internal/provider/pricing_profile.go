@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform"
+)
+
+// applyPricingProfile sets the pricing profile on the resource data of the partial resources and rebuilds their core
+// resources, as the profile changes the license and rates that the core resources are priced with. It reports whether
+// the partial resources need to be built again.
+func applyPricingProfile(partialResources []*tfschema.PartialResource, profile string) bool {
+	if profile == "" || profile == tfschema.PricingProfilePayAsYouGo {
+		return false
+	}
+
+	for _, partial := range partialResources {
+		d := partial.ResourceData
+		if d == nil || partial.CoreResource == nil {
+			continue
+		}
+		registryItem, ok := (*terraform.ResourceRegistryMap)[d.Type]
+		if !ok || registryItem.CoreRFunc == nil {
+			continue
+		}
+
+		if d.ProjectMetadata == nil {
+			d.ProjectMetadata = make(map[string]string)
+		}
+		d.ProjectMetadata[tfschema.PricingProfileMetadataKey] = profile
+		if coreRes := registryItem.CoreRFunc(d); coreRes != nil {
+			partial.CoreResource = coreRes
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func TestParseEstimateProjectPricingProfile(t *testing.T) {
+	wd, _ := os.Getwd()
+
+	tests := []struct {
+		profile      string
+		vmComponent  string
+		vmPurchase   string
+		sqlComponent string
+		sqlPurchase  string
+		sqlLicense   bool
+	}{
+		{
+			profile:      "",
+			vmComponent:  "Instance usage (Windows, pay as you go, Standard_D2s_v5)",
+			vmPurchase:   "Consumption",
+			sqlComponent: "Compute (provisioned, GP_Gen5_2)",
+			sqlPurchase:  "Consumption",
+			sqlLicense:   true,
+		},
+		{
+			profile:      tfschema.PricingProfileDevTest,
+			vmComponent:  "Instance usage (Windows, dev/test, Standard_D2s_v5)",
+			vmPurchase:   "DevTestConsumption",
+			sqlComponent: "Compute (dev/test, provisioned, GP_Gen5_2)",
+			sqlPurchase:  "DevTestConsumption",
+		},
+		{
+			profile:      tfschema.PricingProfileHybridBenefitWindows,
+			vmComponent:  "Instance usage (Windows, hybrid benefit, Standard_D2s_v5)",
+			vmPurchase:   "DevTestConsumption",
+			sqlComponent: "Compute (provisioned, GP_Gen5_2)",
+			sqlPurchase:  "Consumption",
+			sqlLicense:   true,
+		},
+		{
+			profile:      tfschema.PricingProfileHybridBenefitSQL,
+			vmComponent:  "Instance usage (Windows, pay as you go, Standard_D2s_v5)",
+			vmPurchase:   "Consumption",
+			sqlComponent: "Compute (provisioned, GP_Gen5_2)",
+			sqlPurchase:  "Consumption",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			resources, _, _, diags := parseEstimateProject(estimateProject{
+				WorkingDirectory: path.Join(wd, "testdata", "pricing_profile"),
+				PricingProfile:   tt.profile,
+			}, tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+			require.False(t, diags.HasError(), diags)
+
+			components := make(map[string]*tfschema.CostComponent)
+			for _, res := range resources {
+				for _, cc := range res.CostComponents {
+					components[res.Name+"/"+cc.Name] = cc
+				}
+			}
+
+			vm, ok := components["azurerm_windows_virtual_machine.app/"+tt.vmComponent]
+			require.True(t, ok, "missing %s", tt.vmComponent)
+			assert.Equal(t, tt.vmPurchase, *vm.PriceFilter.PurchaseOption)
+
+			sql, ok := components["azurerm_mssql_database.app/"+tt.sqlComponent]
+			require.True(t, ok, "missing %s", tt.sqlComponent)
+			assert.Equal(t, tt.sqlPurchase, *sql.PriceFilter.PurchaseOption)
+
+			_, ok = components["azurerm_mssql_database.app/SQL license"]
+			assert.Equal(t, tt.sqlLicense, ok)
+		})
+	}
+}
@@ -9,16 +9,26 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
 	"github.com/plancost/terraform-provider-plancost/internal/prices"
 )
 
@@ -34,12 +44,25 @@ type PlanCostProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// priceFunctionFetcher is the price fetcher of the price functions of this provider instance.
+	priceFunctionFetcher sharedPriceFetcher
 }
 
 // PlanCostProviderModel describes the provider data model.
 type PlanCostProviderModel struct {
 	ApiEndpoint types.String `tfsdk:"api_endpoint"`
 	ApiKey      types.String `tfsdk:"api_key"`
+	Currency    types.String `tfsdk:"currency"`
+
+	PricingSnapshotFile types.String `tfsdk:"pricing_snapshot_file"`
+	PriceSheetFile      types.String `tfsdk:"price_sheet_file"`
+
+	PriceCacheDir       types.String `tfsdk:"price_cache_dir"`
+	PriceCacheTTL       types.String `tfsdk:"price_cache_ttl"`
+	PriceCacheMaxSizeMB types.Int64  `tfsdk:"price_cache_max_size_mb"`
+	DisablePriceCache   types.Bool   `tfsdk:"disable_price_cache"`
+	ClearPriceCache     types.Bool   `tfsdk:"clear_price_cache"`
 }
 
 type PlanCostProviderData struct {
@@ -64,6 +87,44 @@ func (p *PlanCostProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"currency": schema.StringAttribute{
+				MarkdownDescription: "The ISO 4217 code of the currency that prices are fetched and reported in, e.g. `EUR` or `GBP`. Defaults to `USD`, or to the currency of the `pricing_snapshot_file`. Can also be set via the PLANCOST_CURRENCY environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(prices.CurrencyCodeRegex, "must be an upper case ISO 4217 currency code, e.g. EUR"),
+				},
+			},
+			"pricing_snapshot_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to a price snapshot file, as written by the `export_pricing_snapshot_file` attribute of `plancost_estimate`. If set, prices are resolved from the snapshot instead of the pricing API, so estimates work without network access. Cost components that aren't in the snapshot are reported as missing prices. Can also be set via the PLANCOST_PRICING_SNAPSHOT_FILE environment variable.",
+				Optional:            true,
+			},
+			"price_sheet_file": schema.StringAttribute{
+				MarkdownDescription: "Absolute path to a price sheet with negotiated prices, e.g. an Enterprise Agreement or Microsoft Customer Agreement price sheet export, as a CSV file or a `.json` file. The unit price of a row overrides the price of the cost components whose meter it matches, and those components are marked in the `view`. Can also be set via the PLANCOST_PRICE_SHEET_FILE environment variable.",
+				Optional:            true,
+			},
+			"price_cache_dir": schema.StringAttribute{
+				MarkdownDescription: "The directory in which prices fetched from the pricing service are cached between runs. Defaults to `plancost/prices` in the user's cache directory. Can also be set via the PLANCOST_PRICE_CACHE_DIR environment variable.",
+				Optional:            true,
+			},
+			"price_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long a cached price is used before it is fetched again, as a duration such as `12h` or `30m`. Defaults to `24h`. Expired prices that were fetched within the last 7 days are still used, with a warning, if the pricing service can't be reached. Can also be set via the PLANCOST_PRICE_CACHE_TTL environment variable.",
+				Optional:            true,
+			},
+			"price_cache_max_size_mb": schema.Int64Attribute{
+				MarkdownDescription: "The maximum size of the price cache directory in megabytes. The least recently fetched prices are removed first. Must be at least `1`. Defaults to `100`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"disable_price_cache": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, prices are always fetched from the pricing service and the price cache is neither read nor written. Defaults to `false`. Can also be set via the PLANCOST_DISABLE_PRICE_CACHE environment variable.",
+				Optional:            true,
+			},
+			"clear_price_cache": schema.BoolAttribute{
+				MarkdownDescription: "If `true`, the price cache is cleared when the provider is configured, so every price is fetched again. The provider is configured on every plan and apply, so the cache is cleared on every run while this is set; it is meant for a single run and should be removed afterwards. Prefer setting the PLANCOST_CLEAR_PRICE_CACHE environment variable for that run only. Defaults to `false`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -111,13 +172,6 @@ func (p *PlanCostProvider) Configure(ctx context.Context, request provider.Confi
 		return
 	}
 
-	endpoint := "https://api.plancost.io"
-	if !config.ApiEndpoint.IsNull() {
-		endpoint = config.ApiEndpoint.ValueString()
-	} else if v := os.Getenv("PLANCOST_API_ENDPOINT"); v != "" {
-		endpoint = v
-	}
-
 	apiKey := ""
 	if !config.ApiKey.IsNull() {
 		apiKey = config.ApiKey.ValueString()
@@ -131,14 +185,211 @@ func (p *PlanCostProvider) Configure(ctx context.Context, request provider.Confi
 		client = apiclient.NewAPIClient("https://plancost.io/api", apiKey)
 	}
 
+	priceFetcher, diags := newPriceFetcher(config)
+	if response.Diagnostics.Append(diags...); response.Diagnostics.HasError() {
+		return
+	}
+
+	p.priceFunctionFetcher.setConfigured(priceFetcher)
+
 	providerData := &PlanCostProviderData{
-		PriceFetcher: prices.NewPriceFetcher(endpoint, ""),
+		PriceFetcher: priceFetcher,
 		Client:       client,
 	}
 	response.DataSourceData = providerData
 	response.ResourceData = providerData
 }
 
+// newPriceFetcher returns a PriceFetcher for the pricing service, price snapshot, cache and currency settings of
+// the provider configuration. Settings that are null fall back to their environment variables and defaults.
+func newPriceFetcher(config PlanCostProviderModel) (*prices.PriceFetcher, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	endpoint := "https://api.plancost.io"
+	if !config.ApiEndpoint.IsNull() {
+		endpoint = config.ApiEndpoint.ValueString()
+	} else if v := os.Getenv("PLANCOST_API_ENDPOINT"); v != "" {
+		endpoint = v
+	}
+
+	priceFetcher := prices.NewPriceFetcher(endpoint, "")
+
+	currency := ""
+	if !config.Currency.IsNull() {
+		currency = config.Currency.ValueString()
+	} else if v := os.Getenv("PLANCOST_CURRENCY"); v != "" {
+		currency = strings.ToUpper(v)
+		if !prices.CurrencyCodeRegex.MatchString(currency) {
+			diags.AddError(
+				"Invalid Currency",
+				fmt.Sprintf("The PLANCOST_CURRENCY environment variable %q must be an ISO 4217 currency code, e.g. EUR.", v),
+			)
+			return nil, diags
+		}
+	}
+
+	snapshotFile := ""
+	if !config.PricingSnapshotFile.IsNull() {
+		snapshotFile = config.PricingSnapshotFile.ValueString()
+	} else if v := os.Getenv("PLANCOST_PRICING_SNAPSHOT_FILE"); v != "" {
+		snapshotFile = v
+	}
+
+	if snapshotFile != "" {
+		snapshot, err := apiclient.LoadPriceSnapshot(snapshotFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("pricing_snapshot_file"),
+				"Price Snapshot Loading Error",
+				fmt.Sprintf("Failed to load the price snapshot %s: %s", snapshotFile, err.Error()),
+			)
+			return nil, diags
+		}
+		if currency == "" {
+			currency = snapshot.Currency
+		} else if currency != snapshot.Currency {
+			diags.AddAttributeError(
+				path.Root("currency"),
+				"Price Snapshot Currency Mismatch",
+				fmt.Sprintf("The price snapshot %s contains %s prices, but %s was requested. Export a snapshot in %s, or remove the currency setting.", snapshotFile, snapshot.Currency, currency, currency),
+			)
+			return nil, diags
+		}
+		priceFetcher.UseSnapshot(snapshot)
+	} else {
+		// The snapshot is the only source of prices in offline mode, so the cache is only used for the pricing service
+		cache, cacheDiags := configurePriceCache(config)
+		if diags.Append(cacheDiags...); diags.HasError() {
+			return nil, diags
+		}
+		if cache != nil {
+			priceFetcher.UseDiskCache(cache)
+		}
+	}
+
+	if currency != "" {
+		priceFetcher.SetCurrency(currency)
+	}
+
+	priceSheetFile := ""
+	if !config.PriceSheetFile.IsNull() {
+		priceSheetFile = config.PriceSheetFile.ValueString()
+	} else if v := os.Getenv("PLANCOST_PRICE_SHEET_FILE"); v != "" {
+		priceSheetFile = v
+	}
+
+	if priceSheetFile != "" {
+		sheet, err := prices.LoadPriceSheet(priceSheetFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("price_sheet_file"),
+				"Price Sheet Loading Error",
+				fmt.Sprintf("Failed to load the price sheet %s: %s", priceSheetFile, err.Error()),
+			)
+			return nil, diags
+		}
+		if sheet.Currency != "" && sheet.Currency != priceFetcher.Currency() {
+			diags.AddAttributeError(
+				path.Root("price_sheet_file"),
+				"Price Sheet Currency Mismatch",
+				fmt.Sprintf("The price sheet %s contains %s prices, but prices are reported in %s. Set the currency setting to %s.", priceSheetFile, sheet.Currency, priceFetcher.Currency(), sheet.Currency),
+			)
+			return nil, diags
+		}
+		if regions := sheet.UnknownRegions(); len(regions) > 0 {
+			diags.AddAttributeWarning(
+				path.Root("price_sheet_file"),
+				"Unknown Price Sheet Regions",
+				fmt.Sprintf("The price sheet %s has rows for regions that don't match any Azure region: %s. Those rows are never used. Use the ARM names of the regions, e.g. eastus.", priceSheetFile, strings.Join(regions, ", ")),
+			)
+		}
+		if count := sheet.UnmatchedRows(); count > 0 {
+			diags.AddAttributeWarning(
+				path.Root("price_sheet_file"),
+				"Unmatched Price Sheet Rows",
+				fmt.Sprintf("%d rows of the price sheet %s have no meterName, skuName or armSkuName. Those rows are never used, since prices aren't matched by meter ID or SKU ID.", count, priceSheetFile),
+			)
+		}
+		priceFetcher.UsePriceSheet(sheet)
+	}
+
+	return priceFetcher, diags
+}
+
+// configurePriceCache returns the disk cache for prices fetched from the pricing service, or nil if the cache is
+// disabled.
+func configurePriceCache(config PlanCostProviderModel) (*apiclient.DiskCache, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	disabled := config.DisablePriceCache.ValueBool()
+	if config.DisablePriceCache.IsNull() {
+		disabled, _ = strconv.ParseBool(os.Getenv("PLANCOST_DISABLE_PRICE_CACHE"))
+	}
+	if disabled {
+		return nil, diags
+	}
+
+	dir := ""
+	if !config.PriceCacheDir.IsNull() {
+		dir = config.PriceCacheDir.ValueString()
+	} else if v := os.Getenv("PLANCOST_PRICE_CACHE_DIR"); v != "" {
+		dir = v
+	} else {
+		defaultDir, err := apiclient.DefaultDiskCacheDir()
+		if err != nil {
+			logging.Logger.Debug().Err(err).Msg("Price cache disabled, no user cache directory")
+			return nil, diags
+		}
+		dir = defaultDir
+	}
+
+	ttl := apiclient.DefaultPriceCacheTTL
+	ttlStr := config.PriceCacheTTL.ValueString()
+	if config.PriceCacheTTL.IsNull() {
+		ttlStr = os.Getenv("PLANCOST_PRICE_CACHE_TTL")
+	}
+	if ttlStr != "" {
+		v, err := time.ParseDuration(ttlStr)
+		if err != nil || v <= 0 {
+			diags.AddAttributeError(
+				path.Root("price_cache_ttl"),
+				"Invalid Price Cache TTL",
+				fmt.Sprintf("The price cache TTL %q must be a positive duration such as 12h or 30m.", ttlStr),
+			)
+			return nil, diags
+		}
+		ttl = v
+	}
+
+	maxSize := int64(apiclient.DefaultPriceCacheMaxSize)
+	if !config.PriceCacheMaxSizeMB.IsNull() {
+		maxSize = config.PriceCacheMaxSizeMB.ValueInt64() * 1024 * 1024
+	}
+
+	cache, err := apiclient.NewDiskCache(dir, ttl, maxSize)
+	if err != nil {
+		// The cache only speeds up estimates, so an unusable directory doesn't fail the run
+		diags.AddAttributeWarning(
+			path.Root("price_cache_dir"),
+			"Price Cache Disabled",
+			fmt.Sprintf("The price cache directory %s can't be used: %s", dir, err.Error()),
+		)
+		return nil, diags
+	}
+
+	clearCache := config.ClearPriceCache.ValueBool()
+	if config.ClearPriceCache.IsNull() {
+		clearCache, _ = strconv.ParseBool(os.Getenv("PLANCOST_CLEAR_PRICE_CACHE"))
+	}
+	if clearCache {
+		if err := cache.Clear(); err != nil {
+			diags.AddAttributeWarning(path.Root("clear_price_cache"), "Price Cache Not Cleared", err.Error())
+		}
+	}
+
+	return cache, diags
+}
+
 func (p *PlanCostProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewEstimateResource,
@@ -150,11 +401,18 @@ func (p *PlanCostProvider) EphemeralResources(ctx context.Context) []func() ephe
 }
 
 func (p *PlanCostProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewEstimateDataSource,
+	}
 }
 
 func (p *PlanCostProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		func() function.Function { return NewPriceFunction(&p.priceFunctionFetcher) },
+		NewMonthlyFromHourlyFunction,
+		NewFormatCostFunction,
+		NewResourceCostFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
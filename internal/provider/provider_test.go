@@ -21,6 +21,8 @@ func TestAccProvider_MissingAPIKey_InCI(t *testing.T) {
 	// Simulate CI environment
 	t.Setenv("CI", "true")
 
+	testcase.UsePricingFixtures(t)
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testcase.ProviderFactories,
 		Steps: []resource.TestStep{
@@ -48,6 +50,8 @@ func TestAccProvider_MissingAPIKey_Local(t *testing.T) {
 	t.Setenv("GITLAB_CI", "")
 	t.Setenv("TF_BUILD", "")
 
+	testcase.UsePricingFixtures(t)
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testcase.ProviderFactories,
 		Steps: []resource.TestStep{
@@ -0,0 +1,313 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
+	"github.com/plancost/terraform-provider-plancost/internal/optimization"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// RecommendationRuleType is the type of the recommendations of recommendation_rule blocks.
+	RecommendationRuleType = "Custom"
+
+	recommendationSeverityInfo    = "info"
+	recommendationSeverityWarning = "warning"
+	recommendationSeverityError   = "error"
+)
+
+type RecommendationRuleModel struct {
+	ResourceType types.String            `tfsdk:"resource_type"`
+	Attributes   map[string]types.String `tfsdk:"attributes"`
+	Message      types.String            `tfsdk:"message"`
+	Severity     types.String            `tfsdk:"severity"`
+	Replacement  map[string]types.String `tfsdk:"replacement"`
+}
+
+// recommendationRule is a user-defined optimization rule. It matches the resources of a type whose attributes have the
+// given values, and suggests replacing some of those values, e.g. a cheaper disk SKU.
+type recommendationRule struct {
+	resourceType string
+	attributes   map[string]string
+	message      string
+	severity     string
+	replacement  map[string]string
+}
+
+func newRecommendationRule(rule RecommendationRuleModel) recommendationRule {
+	r := recommendationRule{
+		resourceType: rule.ResourceType.ValueString(),
+		attributes:   make(map[string]string, len(rule.Attributes)),
+		message:      rule.Message.ValueString(),
+		severity:     rule.Severity.ValueString(),
+		replacement:  make(map[string]string, len(rule.Replacement)),
+	}
+	if r.severity == "" {
+		r.severity = recommendationSeverityInfo
+	}
+	for k, v := range rule.Attributes {
+		r.attributes[k] = v.ValueString()
+	}
+	for k, v := range rule.Replacement {
+		r.replacement[k] = v.ValueString()
+	}
+	return r
+}
+
+// matches reports whether the resource has the rule's resource type and attribute values. Attributes are gjson paths,
+// e.g. `os_disk.0.storage_account_type`, and their values are compared as strings.
+func (r recommendationRule) matches(d *tfschema.ResourceData) bool {
+	if d == nil || d.Type != r.resourceType {
+		return false
+	}
+	for k, v := range r.attributes {
+		if value := d.RawValues.Get(k); !value.Exists() || value.String() != v {
+			return false
+		}
+	}
+	return true
+}
+
+// suggestion describes the replacement of the rule, e.g. `storage_account_type = "StandardSSD_LRS"`.
+func (r recommendationRule) suggestion() string {
+	keys := make([]string, 0, len(r.replacement))
+	for k := range r.replacement {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s = %q", k, r.replacement[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// RecommendationRules applies the recommendation rules to the resources of a project. When a rule has a replacement,
+// the resource is rebuilt with the replaced attribute values and priced with the same discounts, and the difference
+// with its current monthly cost is reported as the savings.
+func RecommendationRules(rules []RecommendationRuleModel, project string, partialResources []*tfschema.PartialResource, costResources []*tfschema.Resource, priceFetcher *prices.PriceFetcher, discounts []DiscountModel) ([]optimization.OptimizationRecommendation, error) {
+	recommendations := make([]optimization.OptimizationRecommendation, 0)
+	if len(rules) == 0 {
+		return recommendations, nil
+	}
+
+	monthlyCosts := make(map[string]float64, len(costResources))
+	for _, res := range costResources {
+		if res.MonthlyCost != nil {
+			monthlyCosts[res.Name] = res.MonthlyCost.InexactFloat64()
+		}
+	}
+
+	for _, ruleModel := range rules {
+		rule := newRecommendationRule(ruleModel)
+		for _, partial := range partialResources {
+			if !rule.matches(partial.ResourceData) {
+				continue
+			}
+
+			rec := optimization.OptimizationRecommendation{
+				ResourceAddress: GuardrailResource{Project: project, Name: partial.Address}.address(),
+				Description:     rule.message,
+				Type:            RecommendationRuleType,
+				Severity:        rule.severity,
+				Replacement:     rule.replacement,
+			}
+			if len(rule.replacement) > 0 {
+				rec.Description = fmt.Sprintf("%s Suggested: %s.", rule.message, rule.suggestion())
+
+				monthlyCost, ok := monthlyCosts[partial.Address]
+				if ok {
+					replacementCost, priced, err := replacementMonthlyCost(partial.ResourceData, rule.replacement, priceFetcher, discounts)
+					if err != nil {
+						logging.Logger.Warn().Err(err).Msgf("Unable to price the replacement %s of %s, no savings are reported", rule.suggestion(), partial.Address)
+					} else if priced {
+						rec.SavingsAmount = roundCost(monthlyCost - replacementCost)
+						if monthlyCost > 0 {
+							rec.SavingsPercentage = rec.SavingsAmount / monthlyCost
+						}
+					} else {
+						logging.Logger.Debug().Msgf("Unable to price the replacement %s of %s, no savings are reported", rule.suggestion(), partial.Address)
+					}
+				}
+			}
+			recommendations = append(recommendations, rec)
+		}
+	}
+	return recommendations, nil
+}
+
+// RecommendationRuleDiagnostics reports the recommendations of the rules with a warning or error severity.
+func RecommendationRuleDiagnostics(recommendations []optimization.OptimizationRecommendation, currency string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, rec := range recommendations {
+		if rec.Type != RecommendationRuleType {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s: %s", rec.ResourceAddress, rec.Description)
+		if rec.SavingsAmount != 0 {
+			msg += fmt.Sprintf(" Estimated savings: %s/month.", formatCost(rec.SavingsAmount, currency))
+		}
+		switch rec.Severity {
+		case recommendationSeverityWarning:
+			diags.AddWarning("Recommendation Rule", msg)
+		case recommendationSeverityError:
+			diags.AddError("Recommendation Rule", msg)
+		}
+	}
+	return diags
+}
+
+// replacementMonthlyCost returns the monthly cost of the resource with the replaced attribute values. The cost is only
+// known if the replaced resource is supported and all of its prices are found, e.g. a misspelled SKU has no price.
+func replacementMonthlyCost(d *tfschema.ResourceData, replacement map[string]string, priceFetcher *prices.PriceFetcher, discounts []DiscountModel) (float64, bool, error) {
+	replaced, err := withAttributeValues(d, replacement)
+	if err != nil {
+		return 0, false, err
+	}
+
+	res := rebuildResource(replaced)
+	if res == nil {
+		return 0, false, nil
+	}
+	costResources, monthlyCost, err := PriceResources(priceFetcher, []*tfschema.Resource{res}, discounts)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, costResource := range costResources {
+		costComponents := append([]*tfschema.CostComponent{}, costResource.CostComponents...)
+		for _, subRes := range costResource.FlattenedSubResources() {
+			costComponents = append(costComponents, subRes.CostComponents...)
+		}
+		for _, cc := range costComponents {
+			if cc.PriceNotFound {
+				return 0, false, nil
+			}
+		}
+	}
+	return monthlyCost, true, nil
+}
+
+// rebuildResource builds the resource of the resource data with its registry item, the same as the parser does.
+func rebuildResource(d *tfschema.ResourceData) *tfschema.Resource {
+	registryItem, ok := (*terraform.ResourceRegistryMap)[d.Type]
+	if !ok || registryItem.NoPrice {
+		return nil
+	}
+
+	var res *tfschema.Resource
+	if registryItem.CoreRFunc != nil {
+		coreRes := registryItem.CoreRFunc(d)
+		if coreRes == nil {
+			return nil
+		}
+		if d.UsageData != nil {
+			coreRes.PopulateUsage(d.UsageData)
+		}
+		res = coreRes.BuildResource()
+	} else {
+		res = registryItem.RFunc(d, d.UsageData)
+	}
+	if res == nil {
+		return nil
+	}
+
+	res.ResourceType = d.Type
+	res.Tags = d.Tags
+	return res
+}
+
+// withAttributeValues returns a copy of the resource data with the attribute values replaced. Attributes are paths of
+// object keys and list indexes separated by dots, e.g. `os_disk.0.storage_account_type`.
+func withAttributeValues(d *tfschema.ResourceData, values map[string]string) (*tfschema.ResourceData, error) {
+	var raw interface{}
+	if d.RawValues.Raw != "" {
+		if err := json.Unmarshal([]byte(d.RawValues.Raw), &raw); err != nil {
+			return nil, err
+		}
+	}
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+
+	for k, v := range values {
+		var err error
+		if raw, err = setAttributeValue(raw, strings.Split(k, "."), v); err != nil {
+			return nil, fmt.Errorf("unable to set attribute %s: %w", k, err)
+		}
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.WithRawValues(gjson.ParseBytes(b)), nil
+}
+
+// validateAttributePath checks that an attribute path of a replacement is a path of object keys and list indexes, e.g.
+// `os_disk.0.storage_account_type`, without empty segments or the wildcards and modifiers of gjson paths.
+func validateAttributePath(k string) error {
+	for _, segment := range strings.Split(k, ".") {
+		if segment == "" {
+			return fmt.Errorf("%q has an empty path segment", k)
+		}
+		if strings.ContainsAny(segment, `*?#|@\`) {
+			return fmt.Errorf("%q must be a path of object keys and list indexes separated by dots", k)
+		}
+	}
+	return nil
+}
+
+func setAttributeValue(value interface{}, path []string, v string) (interface{}, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		child, err := setAttributeValue(typed[path[0]], path[1:], v)
+		if err != nil {
+			return nil, err
+		}
+		typed[path[0]] = child
+		return typed, nil
+	case []interface{}:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= len(typed) {
+			return nil, fmt.Errorf("%q is not an index of a list of %d elements", path[0], len(typed))
+		}
+		child, err := setAttributeValue(typed[i], path[1:], v)
+		if err != nil {
+			return nil, err
+		}
+		typed[i] = child
+		return typed, nil
+	case nil:
+		child, err := setAttributeValue(nil, path[1:], v)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{path[0]: child}, nil
+	default:
+		return nil, fmt.Errorf("%q is not an object", path[0])
+	}
+}
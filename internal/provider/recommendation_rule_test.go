@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/prices"
+	tfschema "github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+// diskPrices prices the storage of standard SSD disks at 9.60 and of premium SSD disks at 19.71 per month.
+func diskPrices(t *testing.T, resources []*tfschema.Resource) *prices.PriceFetcher {
+	t.Helper()
+
+	snapshot := apiclient.NewPriceSnapshot("USD")
+	for _, res := range resources {
+		for _, cc := range res.CostComponents {
+			price := "0.0012"
+			if strings.HasPrefix(cc.Name, "Storage (E") {
+				price = "9.60"
+			} else if strings.HasPrefix(cc.Name, "Storage (P") {
+				price = "19.71"
+			}
+			snapshot.AddFilters(cc.ProductFilter, cc.PriceFilter, gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"`+cc.Name+`","USD":"`+price+`"}]}]}}`))
+		}
+	}
+
+	priceFetcher := prices.NewPriceFetcher("", "")
+	priceFetcher.UseSnapshot(snapshot)
+	return priceFetcher
+}
+
+func TestRecommendationRules(t *testing.T) {
+	wd, _ := os.Getwd()
+	parsedResources, partialResources, err := ParseModule(path.Join(wd, "testdata", "recommendation_rule"), tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.NoError(t, err)
+
+	// Price both the premium disks and their standard SSD replacements
+	var standardDisk *tfschema.Resource
+	for _, partial := range partialResources {
+		if partial.Address == "azurerm_managed_disk.premium" {
+			replaced, err := withAttributeValues(partial.ResourceData, map[string]string{"storage_account_type": "StandardSSD_LRS"})
+			require.NoError(t, err)
+			standardDisk = rebuildResource(replaced)
+		}
+	}
+	require.NotNil(t, standardDisk)
+	priceFetcher := diskPrices(t, append([]*tfschema.Resource{standardDisk}, parsedResources...))
+
+	costResources, _, err := PriceResources(priceFetcher, parsedResources, nil)
+	require.NoError(t, err)
+
+	rules := []RecommendationRuleModel{
+		{
+			ResourceType: types.StringValue("azurerm_managed_disk"),
+			Attributes:   map[string]types.String{"storage_account_type": types.StringValue("Premium_LRS")},
+			Message:      types.StringValue("Use standard SSD disks outside of production."),
+			Severity:     types.StringValue("warning"),
+			Replacement:  map[string]types.String{"storage_account_type": types.StringValue("StandardSSD_LRS")},
+		},
+		{
+			ResourceType: types.StringValue("azurerm_managed_disk"),
+			Attributes:   map[string]types.String{"disk_size_gb": types.StringValue("128")},
+			Message:      types.StringValue("Check that the disks are still in use."),
+		},
+	}
+
+	recommendations, err := RecommendationRules(rules, "", partialResources, costResources, priceFetcher, nil)
+	require.NoError(t, err)
+	require.Len(t, recommendations, 3)
+
+	assert.Equal(t, "azurerm_managed_disk.premium", recommendations[0].ResourceAddress)
+	assert.Equal(t, `Use standard SSD disks outside of production. Suggested: storage_account_type = "StandardSSD_LRS".`, recommendations[0].Description)
+	assert.Equal(t, RecommendationRuleType, recommendations[0].Type)
+	assert.Equal(t, "warning", recommendations[0].Severity)
+	assert.Equal(t, map[string]string{"storage_account_type": "StandardSSD_LRS"}, recommendations[0].Replacement)
+	assert.Equal(t, 10.11, recommendations[0].SavingsAmount)
+	assert.InDelta(t, 10.11/19.71, recommendations[0].SavingsPercentage, 0.0001)
+
+	// Rules without a replacement have no savings, and default to the info severity
+	assert.ElementsMatch(t, []string{"azurerm_managed_disk.premium", "azurerm_managed_disk.standard"}, []string{recommendations[1].ResourceAddress, recommendations[2].ResourceAddress})
+	assert.Equal(t, "Check that the disks are still in use.", recommendations[1].Description)
+	assert.Equal(t, "info", recommendations[1].Severity)
+	assert.Zero(t, recommendations[1].SavingsAmount)
+
+	// Only the warning is reported as a diagnostic
+	diags := RecommendationRuleDiagnostics(recommendations, "USD")
+	require.Len(t, diags, 1)
+	assert.Equal(t, `azurerm_managed_disk.premium: Use standard SSD disks outside of production. Suggested: storage_account_type = "StandardSSD_LRS". Estimated savings: $10.11/month.`, diags[0].Detail())
+	assert.False(t, diags.HasError())
+}
+
+func TestRecommendationRulesUnpricedReplacement(t *testing.T) {
+	wd, _ := os.Getwd()
+	parsedResources, partialResources, err := ParseModule(path.Join(wd, "testdata", "recommendation_rule"), tfschema.NewUsageMapFromInterface(map[string]interface{}{}))
+	require.NoError(t, err)
+
+	priceFetcher := diskPrices(t, parsedResources)
+	costResources, _, err := PriceResources(priceFetcher, parsedResources, nil)
+	require.NoError(t, err)
+
+	// The ultra disk isn't in the snapshot, so the savings of the replacement are unknown
+	recommendations, err := RecommendationRules([]RecommendationRuleModel{
+		{
+			ResourceType: types.StringValue("azurerm_managed_disk"),
+			Attributes:   map[string]types.String{"storage_account_type": types.StringValue("StandardSSD_LRS")},
+			Message:      types.StringValue("Use ultra disks."),
+			Severity:     types.StringValue("error"),
+			Replacement:  map[string]types.String{"storage_account_type": types.StringValue("UltraSSD_LRS")},
+		},
+	}, "app", partialResources, costResources, priceFetcher, nil)
+	require.NoError(t, err)
+	require.Len(t, recommendations, 1)
+	assert.Equal(t, "app:azurerm_managed_disk.standard", recommendations[0].ResourceAddress)
+	assert.Zero(t, recommendations[0].SavingsAmount)
+
+	diags := RecommendationRuleDiagnostics(recommendations, "USD")
+	assert.True(t, diags.HasError())
+
+	// A replacement that can't be applied to the resource is still recommended, without savings
+	recommendations, err = RecommendationRules([]RecommendationRuleModel{
+		{
+			ResourceType: types.StringValue("azurerm_managed_disk"),
+			Attributes:   map[string]types.String{"storage_account_type": types.StringValue("StandardSSD_LRS")},
+			Message:      types.StringValue("Use ultra disks."),
+			Replacement:  map[string]types.String{"storage_account_type.0": types.StringValue("UltraSSD_LRS")},
+		},
+	}, "app", partialResources, costResources, priceFetcher, nil)
+	require.NoError(t, err)
+	require.Len(t, recommendations, 1)
+	assert.Zero(t, recommendations[0].SavingsAmount)
+}
+
+func TestWithAttributeValues(t *testing.T) {
+	d := tfschema.NewResourceData("azurerm_linux_virtual_machine", "azurerm", "azurerm_linux_virtual_machine.app", nil,
+		gjson.Parse(`{"size":"Standard_D2s_v3","os_disk":[{"storage_account_type":"Premium_LRS","disk_size_gb":30}]}`))
+
+	replaced, err := withAttributeValues(d, map[string]string{
+		"size":                           "Standard_B2s",
+		"os_disk.0.storage_account_type": "StandardSSD_LRS",
+		"priority":                       "Spot",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Standard_B2s", replaced.Get("size").String())
+	assert.Equal(t, "StandardSSD_LRS", replaced.Get("os_disk.0.storage_account_type").String())
+	assert.Equal(t, int64(30), replaced.Get("os_disk.0.disk_size_gb").Int())
+	assert.Equal(t, "Spot", replaced.Get("priority").String())
+
+	// The original resource data is unchanged
+	assert.Equal(t, "Standard_D2s_v3", d.Get("size").String())
+	assert.Equal(t, "Premium_LRS", d.Get("os_disk.0.storage_account_type").String())
+
+	// The copy records its own attribute reads
+	assert.Equal(t, []string{"os_disk.0.storage_account_type", "size"}, d.ReadAttributes())
+	assert.Equal(t, []string{"os_disk.0.disk_size_gb", "os_disk.0.storage_account_type", "priority", "size"}, replaced.ReadAttributes())
+
+	_, err = withAttributeValues(d, map[string]string{"os_disk.1.storage_account_type": "StandardSSD_LRS"})
+	assert.Error(t, err)
+}
+
+func TestValidateAttributePath(t *testing.T) {
+	assert.NoError(t, validateAttributePath("size"))
+	assert.NoError(t, validateAttributePath("os_disk.0.storage_account_type"))
+	assert.Error(t, validateAttributePath(""))
+	assert.Error(t, validateAttributePath("os_disk..storage_account_type"))
+	assert.Error(t, validateAttributePath("os_disk.#.storage_account_type"))
+	assert.Error(t, validateAttributePath("tags|@reverse"))
+}
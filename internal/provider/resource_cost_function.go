@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/plancost/terraform-provider-plancost/internal/dynamic"
+)
+
+var _ function.Function = &ResourceCostFunction{}
+
+// ResourceCostFunction returns the monthly cost of a single resource of an estimate.
+type ResourceCostFunction struct{}
+
+func NewResourceCostFunction() function.Function {
+	return &ResourceCostFunction{}
+}
+
+func (f *ResourceCostFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resource_cost"
+}
+
+func (f *ResourceCostFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Get the monthly cost of a resource from an estimate",
+		MarkdownDescription: "Returns the monthly cost of the resource at `address`, including its sub-resources, from the `resources` attribute of `plancost_estimate`. If `address` has no index, the costs of all instances created with `count` or `for_each` are added up. Resources of multi-project estimates are addressed as `project:address`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "resources",
+				MarkdownDescription: "The `resources` attribute of a `plancost_estimate` resource or data source.",
+			},
+			function.StringParameter{
+				Name:                "address",
+				MarkdownDescription: "The address of the resource, e.g. `azurerm_linux_virtual_machine.example` or `module.app.azurerm_public_ip.example[\"web\"]`.",
+			},
+		},
+		Return: function.Float64Return{},
+	}
+}
+
+func (f *ResourceCostFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourcesValue types.Dynamic
+	var address string
+	if resp.Error = req.Arguments.Get(ctx, &resourcesValue, &address); resp.Error != nil {
+		return
+	}
+
+	var resources []CostResourceModel
+	if err := dynamic.Unmarshal(resourcesValue, &resources); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The resources must be the resources attribute of a plancost_estimate: %s", err.Error()))
+		return
+	}
+
+	cost, ok := resourceCost(resources, address)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("No resource found with the address %s", address))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, cost)
+}
+
+// resourceCost returns the monthly cost of the resource at address. An address without an index matches all
+// instances of the resource.
+func resourceCost(resources []CostResourceModel, address string) (float64, bool) {
+	var cost float64
+	var found bool
+	for _, r := range resources {
+		name := r.Name
+		if r.Project != "" && strings.HasPrefix(address, r.Project+":") {
+			name = r.Project + ":" + r.Name
+		}
+
+		if name == address || strings.HasPrefix(name, address+"[") {
+			cost += calculateResourceCost(r)
+			found = true
+		}
+	}
+	return roundCost(cost), found
+}
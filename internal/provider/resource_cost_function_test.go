@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/plancost/terraform-provider-plancost/internal/dynamic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCostFunction(t *testing.T) {
+	resources, err := dynamic.ToDynamic([]CostResourceModel{
+		{
+			Name:           "azurerm_linux_virtual_machine.example",
+			CostComponents: []CostComponentModel{{Name: "Instance usage", MonthlyCost: 70.08}},
+			SubResources: []CostResourceModel{
+				{Name: "os_disk", CostComponents: []CostComponentModel{{Name: "Storage", MonthlyCost: 5.89}}},
+			},
+		},
+		{Name: "azurerm_public_ip.example[0]", CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}}},
+		{Name: "azurerm_public_ip.example[1]", CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}}},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		address  string
+		expected float64
+	}{
+		{"azurerm_linux_virtual_machine.example", 75.97},
+		{"azurerm_public_ip.example[1]", 3.65},
+		{"azurerm_public_ip.example", 7.3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			result, funcErr := runFunction(t, NewResourceCostFunction(), types.Float64Unknown(), resources, types.StringValue(tc.address))
+			require.Nil(t, funcErr)
+			assert.Equal(t, types.Float64Value(tc.expected), result)
+		})
+	}
+
+	_, funcErr := runFunction(t, NewResourceCostFunction(), types.Float64Unknown(), resources, types.StringValue("azurerm_public_ip.missing"))
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, "No resource found")
+}
+
+func TestResourceCostFunction_Projects(t *testing.T) {
+	resources := []CostResourceModel{
+		{Project: "dev", Name: "azurerm_public_ip.example", CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 3.65}}},
+		{Project: "prod", Name: "azurerm_public_ip.example", CostComponents: []CostComponentModel{{Name: "IP address", MonthlyCost: 7.3}}},
+	}
+
+	cost, ok := resourceCost(resources, "prod:azurerm_public_ip.example")
+	assert.True(t, ok)
+	assert.Equal(t, 7.3, cost)
+
+	// Without a project the resource is matched in every project
+	cost, ok = resourceCost(resources, "azurerm_public_ip.example")
+	assert.True(t, ok)
+	assert.Equal(t, 10.95, cost)
+}
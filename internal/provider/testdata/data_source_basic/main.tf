@@ -0,0 +1,24 @@
+provider "azurerm" {
+  features {}
+  skip_provider_registration = true
+}
+
+resource "azurerm_resource_group" "example" {
+  name     = "exampleRG1"
+  location = "eastus"
+}
+resource "azurerm_public_ip" "example" {
+  name                = "example-public-ip"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+  allocation_method   = "Static"
+  sku                 = "Standard"
+}
+
+data "plancost_estimate" "this" {
+  working_directory = abspath(path.module)
+}
+
+output "monthly_cost" {
+  value = data.plancost_estimate.this.monthly_cost
+}
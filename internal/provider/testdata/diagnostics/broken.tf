@@ -0,0 +1,3 @@
+resource "azurerm_public_ip" "web" {
+  name = "web-ip"
+  sku  = "Standard"
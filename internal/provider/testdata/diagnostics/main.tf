@@ -0,0 +1,22 @@
+provider "azurerm" {
+  features {}
+}
+
+variable "vm_size" {
+  type = string
+}
+
+resource "azurerm_linux_virtual_machine" "app" {
+  name                = "app-vm"
+  location            = "eastus"
+  resource_group_name = "example-resources"
+  size                = var.vm_size
+  admin_username      = "adminuser"
+
+  network_interface_ids = []
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+}
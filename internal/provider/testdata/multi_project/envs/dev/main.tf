@@ -0,0 +1,15 @@
+provider "azurerm" {
+  features {}
+  skip_provider_registration = true
+}
+
+variable "ip_count" {
+  type    = number
+  default = 1
+}
+
+module "public_ip" {
+  source   = "../../modules/public_ip"
+  ip_count = var.ip_count
+  location = "eastus"
+}
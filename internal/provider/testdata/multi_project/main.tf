@@ -0,0 +1,11 @@
+resource "plancost_estimate" "this" {
+  projects {
+    name              = "dev"
+    working_directory = abspath("${path.module}/envs/dev")
+  }
+
+  projects {
+    name              = "prod"
+    working_directory = abspath("${path.module}/envs/prod")
+  }
+}
@@ -0,0 +1,16 @@
+variable "ip_count" {
+  type = number
+}
+
+variable "location" {
+  type = string
+}
+
+resource "azurerm_public_ip" "example" {
+  count               = var.ip_count
+  name                = "example-public-ip-${count.index}"
+  location            = var.location
+  resource_group_name = "exampleRG1"
+  allocation_method   = "Static"
+  sku                 = "Standard"
+}
@@ -0,0 +1,3 @@
+resource "plancost_estimate" "this" {
+  plan_json_file = abspath("${path.module}/tfplan.json")
+}
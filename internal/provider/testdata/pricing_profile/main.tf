@@ -0,0 +1,25 @@
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_windows_virtual_machine" "app" {
+  name                = "app-vm"
+  location            = "eastus"
+  resource_group_name = "example-resources"
+  size                = "Standard_D2s_v5"
+  admin_username      = "adminuser"
+  admin_password      = "P@ssw0rd1234!"
+
+  network_interface_ids = []
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+}
+
+resource "azurerm_mssql_database" "app" {
+  name      = "app-db"
+  server_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Sql/servers/app-sql"
+  sku_name  = "GP_Gen5_2"
+}
@@ -0,0 +1,21 @@
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_managed_disk" "premium" {
+  name                 = "premium-disk"
+  location             = "eastus"
+  resource_group_name  = "example-resources"
+  storage_account_type = "Premium_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = 128
+}
+
+resource "azurerm_managed_disk" "standard" {
+  name                 = "standard-disk"
+  location             = "eastus"
+  resource_group_name  = "example-resources"
+  storage_account_type = "StandardSSD_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = 128
+}
@@ -0,0 +1,43 @@
+provider "google" {
+  project = "example-project"
+  region  = "us-central1"
+}
+
+resource "google_compute_instance" "labelled" {
+  name         = "labelled"
+  machine_type = "e2-medium"
+  zone         = "us-central1-a"
+
+  labels = {
+    environment = "prod"
+  }
+
+  boot_disk {
+    initialize_params {
+      image = "debian-cloud/debian-12"
+    }
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
+
+resource "google_storage_bucket" "unlabelled" {
+  name     = "unlabelled"
+  location = "US"
+}
+
+resource "google_sql_database_instance" "user_labelled" {
+  name             = "user-labelled"
+  database_version = "POSTGRES_15"
+  region           = "us-central1"
+
+  settings {
+    tier = "db-f1-micro"
+
+    user_labels = {
+      environment = "dev"
+    }
+  }
+}
@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const defaultDBAllocatedStorage = 20
+
+type dbEngine struct {
+	DatabaseEngine  string
+	DatabaseEdition string
+	LicenseIncluded bool
+}
+
+var dbEngineMap = map[string]dbEngine{
+	"mysql":         {DatabaseEngine: "MySQL"},
+	"postgres":      {DatabaseEngine: "PostgreSQL"},
+	"mariadb":       {DatabaseEngine: "MariaDB"},
+	"oracle-se2":    {DatabaseEngine: "Oracle", DatabaseEdition: "Standard Two", LicenseIncluded: true},
+	"oracle-ee":     {DatabaseEngine: "Oracle", DatabaseEdition: "Enterprise"},
+	"sqlserver-ex":  {DatabaseEngine: "SQL Server", DatabaseEdition: "Express", LicenseIncluded: true},
+	"sqlserver-web": {DatabaseEngine: "SQL Server", DatabaseEdition: "Web", LicenseIncluded: true},
+	"sqlserver-se":  {DatabaseEngine: "SQL Server", DatabaseEdition: "Standard", LicenseIncluded: true},
+	"sqlserver-ee":  {DatabaseEngine: "SQL Server", DatabaseEdition: "Enterprise", LicenseIncluded: true},
+}
+
+var dbStorageTypeMap = map[string]struct {
+	VolumeType string
+	Label      string
+}{
+	"standard": {"Magnetic", "magnetic"},
+	"gp2":      {"General Purpose", "general purpose SSD, gp2"},
+	"gp3":      {"General Purpose-GP3", "general purpose SSD, gp3"},
+	"io1":      {"Provisioned IOPS", "provisioned IOPS SSD, io1"},
+}
+
+type DBInstance struct {
+	Address          string
+	Region           string
+	InstanceClass    string
+	Engine           string
+	LicenseModel     string
+	MultiAZ          bool
+	StorageType      string
+	AllocatedStorage int64
+	IOPS             int64
+
+	AdditionalBackupStorageGB *float64 `infracost_usage:"additional_backup_storage_gb"`
+}
+
+func (r *DBInstance) CoreType() string {
+	return "DBInstance"
+}
+
+func (r *DBInstance) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "additional_backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+	}
+}
+
+func (r *DBInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *DBInstance) BuildResource() *schema.Resource {
+	engine, ok := dbEngineMap[strings.ToLower(r.Engine)]
+	if !ok {
+		// The resource isn't free, so it is reported as unsupported rather than as a free resource
+		logging.Logger.Warn().Msgf("Skipping resource %s. Unsupported database engine %s", r.Address, r.Engine)
+		return &schema.Resource{
+			Name:        r.Address,
+			IsSkipped:   true,
+			SkipMessage: fmt.Sprintf("Unsupported database engine %s", r.Engine),
+			UsageSchema: r.UsageSchema(),
+		}
+	}
+
+	deploymentOption := "Single-AZ"
+	if r.MultiAZ {
+		deploymentOption = "Multi-AZ"
+	}
+
+	costComponents := []*schema.CostComponent{
+		r.instanceCostComponent(engine, deploymentOption),
+		r.storageCostComponent(deploymentOption),
+	}
+
+	if strings.ToLower(r.StorageType) == "io1" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Storage IOPS",
+			Unit:            "IOPS",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(r.IOPS)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("Provisioned IOPS"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+					{Key: "usagetype", ValueRegex: regexPtr("RDS:PIOPS$")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+		})
+	}
+
+	costComponents = append(costComponents, &schema.CostComponent{
+		Name:            "Additional backup storage",
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: floatPtrToDecimalPtr(r.AdditionalBackupStorageGB),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("AmazonRDS"),
+			ProductFamily: strPtr("Storage Snapshot"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: regexPtr("RDS:ChargedBackupUsage$")},
+				{Key: "databaseEngine", Value: strPtr(engine.DatabaseEngine)},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+		UsageBased:  true,
+	})
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+func (r *DBInstance) instanceCostComponent(engine dbEngine, deploymentOption string) *schema.CostComponent {
+	licenseModel := "No license required"
+	if engine.LicenseIncluded {
+		licenseModel = "License included"
+	}
+	if strings.ToLower(r.LicenseModel) == "bring-your-own-license" {
+		licenseModel = "Bring your own license"
+	}
+
+	attributeFilters := []*schema.AttributeFilter{
+		{Key: "instanceType", Value: strPtr(r.InstanceClass)},
+		{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+		{Key: "databaseEngine", Value: strPtr(engine.DatabaseEngine)},
+		{Key: "licenseModel", Value: strPtr(licenseModel)},
+	}
+	if engine.DatabaseEdition != "" {
+		attributeFilters = append(attributeFilters, &schema.AttributeFilter{Key: "databaseEdition", Value: strPtr(engine.DatabaseEdition)})
+	}
+
+	return &schema.CostComponent{
+		Name:           fmt.Sprintf("Database instance (on-demand, %s, %s)", deploymentOption, r.InstanceClass),
+		Unit:           "hours",
+		UnitMultiplier: decimal.NewFromInt(1),
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:       strPtr(vendorName),
+			Region:           strPtr(r.Region),
+			Service:          strPtr("AmazonRDS"),
+			ProductFamily:    strPtr("Database Instance"),
+			AttributeFilters: attributeFilters,
+		},
+		PriceFilter: priceFilterOnDemand,
+	}
+}
+
+func (r *DBInstance) storageCostComponent(deploymentOption string) *schema.CostComponent {
+	storageType := strings.ToLower(r.StorageType)
+	if storageType == "" {
+		storageType = "gp2"
+	}
+
+	storage, ok := dbStorageTypeMap[storageType]
+	if !ok {
+		storage = dbStorageTypeMap["gp2"]
+	}
+
+	allocatedStorage := r.AllocatedStorage
+	if allocatedStorage <= 0 {
+		allocatedStorage = defaultDBAllocatedStorage
+	}
+
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("Storage (%s)", storage.Label),
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(allocatedStorage)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("AmazonRDS"),
+			ProductFamily: strPtr("Database Storage"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "volumeType", Value: strPtr(storage.VolumeType)},
+				{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+	}
+}
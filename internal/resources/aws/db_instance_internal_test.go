@@ -0,0 +1,25 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestDBInstanceUnsupportedEngine(t *testing.T) {
+	r := &DBInstance{Address: "aws_db_instance.db2", Region: "us-east-1", InstanceClass: "db.m5.large", Engine: "db2-se"}
+	res := r.BuildResource()
+
+	// Unsupported engines are reported as unsupported, not as free resources
+	assert.Equal(t, true, res.IsSkipped)
+	assert.Equal(t, false, res.NoPrice)
+	assert.Equal(t, "Unsupported database engine db2-se", res.SkipMessage)
+	assert.Equal(t, 0, len(res.CostComponents))
+}
@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const (
+	defaultEBSVolumeType = "gp2"
+	defaultEBSVolumeSize = 8
+
+	gp3BaselineIOPS       = 3000
+	gp3BaselineThroughput = 125
+)
+
+// io2IOPSTiers are the io2 IOPS pricing tiers: the first 32,000 IOPS, the next 32,000 IOPS and the IOPS over 64,000.
+var io2IOPSTiers = []struct {
+	name      string
+	limit     int64
+	usageType string
+}{
+	{"Provisioned IOPS (first 32K)", 32000, "EBS:VolumeP-IOPS.io2"},
+	{"Provisioned IOPS (next 32K)", 64000, "EBS:VolumeP-IOPS.io2.tier2"},
+	{"Provisioned IOPS (over 64K)", 0, "EBS:VolumeP-IOPS.io2.tier3"},
+}
+
+var ebsVolumeNameMap = map[string]string{
+	"standard": "Magnetic storage",
+	"gp2":      "General Purpose SSD storage (gp2)",
+	"gp3":      "General Purpose SSD storage (gp3)",
+	"io1":      "Provisioned IOPS SSD storage (io1)",
+	"io2":      "Provisioned IOPS SSD storage (io2)",
+	"st1":      "Throughput Optimized HDD storage (st1)",
+	"sc1":      "Cold HDD storage (sc1)",
+}
+
+type EBSVolume struct {
+	Address string
+	Region  string
+	EBSVolumeData
+	MonthlyStandardIORequests *int64 `infracost_usage:"monthly_standard_io_requests"`
+}
+
+type EBSVolumeData struct {
+	Type       string
+	SizeGB     int64
+	IOPS       int64
+	Throughput int64
+}
+
+var EBSVolumeUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_standard_io_requests", ValueType: schema.Int64, DefaultValue: 0},
+}
+
+func (r *EBSVolume) CoreType() string {
+	return "EBSVolume"
+}
+
+func (r *EBSVolume) UsageSchema() []*schema.UsageItem {
+	return EBSVolumeUsageSchema
+}
+
+func (r *EBSVolume) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *EBSVolume) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: ebsVolumeCostComponents(r.Region, r.EBSVolumeData, intPtrToDecimalPtr(r.MonthlyStandardIORequests)),
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+func ebsVolumeCostComponents(region string, v EBSVolumeData, monthlyIORequests *decimal.Decimal) []*schema.CostComponent {
+	volumeType := v.Type
+	if volumeType == "" {
+		volumeType = defaultEBSVolumeType
+	}
+
+	name, ok := ebsVolumeNameMap[volumeType]
+	if !ok {
+		name = fmt.Sprintf("Storage (%s)", volumeType)
+	}
+
+	size := v.SizeGB
+	if size <= 0 {
+		size = defaultEBSVolumeSize
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            name,
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: decimalPtr(decimal.NewFromInt(size)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("Storage"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "volumeApiName", Value: strPtr(volumeType)},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+		},
+	}
+
+	switch volumeType {
+	case "io1":
+		costComponents = append(costComponents, ebsIOPSCostComponent(region, volumeType, decimal.NewFromInt(v.IOPS), "EBS:VolumeP-IOPS.piops"))
+	case "io2":
+		costComponents = append(costComponents, io2IOPSCostComponents(region, v.IOPS)...)
+	case "gp3":
+		if v.IOPS > gp3BaselineIOPS {
+			costComponents = append(costComponents, ebsIOPSCostComponent(region, volumeType, decimal.NewFromInt(v.IOPS-gp3BaselineIOPS), "EBS:VolumeP-IOPS.gp3"))
+		}
+
+		if v.Throughput > gp3BaselineThroughput {
+			costComponents = append(costComponents, &schema.CostComponent{
+				Name:            "Provisioned throughput",
+				Unit:            "Mbps",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: decimalPtr(decimal.NewFromInt(v.Throughput - gp3BaselineThroughput)),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr(vendorName),
+					Region:        strPtr(region),
+					Service:       strPtr("AmazonEC2"),
+					ProductFamily: strPtr("Provisioned Throughput"),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "volumeApiName", Value: strPtr(volumeType)},
+						{Key: "usagetype", ValueRegex: regexPtr("EBS:VolumeP-Throughput.gp3$")},
+					},
+				},
+				PriceFilter: priceFilterOnDemand,
+			})
+		}
+	case "standard":
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "I/O requests",
+			Unit:            "1M request",
+			UnitMultiplier:  decimal.NewFromInt(1000000),
+			MonthlyQuantity: monthlyIORequests,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(region),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("System Operation"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "volumeApiName", Value: strPtr(volumeType)},
+					{Key: "usagetype", ValueRegex: regexPtr("EBS:VolumeIOUsage$")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+			UsageBased:  true,
+		})
+	}
+
+	return costComponents
+}
+
+// io2IOPSCostComponents returns a cost component for each io2 IOPS tier that the IOPS reach.
+func io2IOPSCostComponents(region string, iops int64) []*schema.CostComponent {
+	costComponents := make([]*schema.CostComponent, 0, len(io2IOPSTiers))
+	var tierStart int64
+	for _, tier := range io2IOPSTiers {
+		if tierStart > 0 && iops <= tierStart {
+			break
+		}
+		quantity := iops - tierStart
+		if tier.limit > 0 && iops > tier.limit {
+			quantity = tier.limit - tierStart
+		}
+
+		c := ebsIOPSCostComponent(region, "io2", decimal.NewFromInt(quantity), tier.usageType)
+		c.Name = tier.name
+		costComponents = append(costComponents, c)
+		tierStart = tier.limit
+	}
+	return costComponents
+}
+
+func ebsIOPSCostComponent(region, volumeType string, iops decimal.Decimal, usageType string) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            "Provisioned IOPS",
+		Unit:            "IOPS",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(iops),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("System Operation"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "volumeApiName", Value: strPtr(volumeType)},
+				{Key: "usagetype", ValueRegex: regexPtr(fmt.Sprintf("%s$", usageType))},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+	}
+}
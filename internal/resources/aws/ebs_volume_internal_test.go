@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestEBSVolumeCostComponents(t *testing.T) {
+	tests := []struct {
+		volume     EBSVolumeData
+		expected   []string
+		quantities []string
+	}{
+		{EBSVolumeData{}, []string{"General Purpose SSD storage (gp2)"}, []string{"8"}},
+		{EBSVolumeData{Type: "gp3", SizeGB: 100}, []string{"General Purpose SSD storage (gp3)"}, []string{"100"}},
+		{EBSVolumeData{Type: "gp3", SizeGB: 100, IOPS: 4000, Throughput: 250}, []string{"General Purpose SSD storage (gp3)", "Provisioned IOPS", "Provisioned throughput"}, []string{"100", "1000", "125"}},
+		{EBSVolumeData{Type: "io2", SizeGB: 50, IOPS: 2000}, []string{"Provisioned IOPS SSD storage (io2)", "Provisioned IOPS (first 32K)"}, []string{"50", "2000"}},
+		{EBSVolumeData{Type: "io2", SizeGB: 50, IOPS: 40000}, []string{"Provisioned IOPS SSD storage (io2)", "Provisioned IOPS (first 32K)", "Provisioned IOPS (next 32K)"}, []string{"50", "32000", "8000"}},
+		{EBSVolumeData{Type: "io2", SizeGB: 50, IOPS: 80000}, []string{"Provisioned IOPS SSD storage (io2)", "Provisioned IOPS (first 32K)", "Provisioned IOPS (next 32K)", "Provisioned IOPS (over 64K)"}, []string{"50", "32000", "32000", "16000"}},
+	}
+
+	for _, test := range tests {
+		components := ebsVolumeCostComponents("us-east-1", test.volume, nil)
+		assert.Equal(t, len(test.expected), len(components))
+		for i, c := range components {
+			assert.Equal(t, test.expected[i], c.Name)
+			assert.Equal(t, test.quantities[i], c.MonthlyQuantity.String())
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+var instanceOperatingSystemMap = map[string]struct {
+	Name  string
+	Label string
+}{
+	"linux":   {"Linux", "Linux/UNIX"},
+	"windows": {"Windows", "Windows"},
+	"rhel":    {"RHEL", "RHEL"},
+	"suse":    {"SUSE", "SUSE"},
+}
+
+var instanceTenancyMap = map[string]string{
+	"default":   "Shared",
+	"dedicated": "Dedicated",
+	"host":      "Host",
+}
+
+type Instance struct {
+	Address         string
+	Region          string
+	InstanceType    string
+	Tenancy         string
+	RootBlockDevice *EBSVolumeData
+	EBSBlockDevices []*EBSBlockDevice
+
+	OperatingSystem *string  `infracost_usage:"operating_system"`
+	MonthlyHrs      *float64 `infracost_usage:"monthly_hrs"`
+}
+
+type EBSBlockDevice struct {
+	DeviceName string
+	EBSVolumeData
+}
+
+func (r *Instance) CoreType() string {
+	return "Instance"
+}
+
+func (r *Instance) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "operating_system", ValueType: schema.String, DefaultValue: "linux"},
+		{Key: "monthly_hrs", ValueType: schema.Float64, DefaultValue: 730},
+	}
+}
+
+func (r *Instance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *Instance) BuildResource() *schema.Resource {
+	costComponents := make([]*schema.CostComponent, 0)
+
+	// Instances on a dedicated host are billed through the host.
+	if strings.ToLower(r.Tenancy) != "host" {
+		costComponents = append(costComponents, r.computeCostComponent())
+	}
+
+	subResources := make([]*schema.Resource, 0)
+
+	rootBlockDevice := EBSVolumeData{}
+	if r.RootBlockDevice != nil {
+		rootBlockDevice = *r.RootBlockDevice
+	}
+	subResources = append(subResources, &schema.Resource{
+		Name:           "root_block_device",
+		CostComponents: ebsVolumeCostComponents(r.Region, rootBlockDevice, nil),
+	})
+
+	for _, device := range r.EBSBlockDevices {
+		subResources = append(subResources, &schema.Resource{
+			Name:           fmt.Sprintf("ebs_block_device{device_name: %s}", device.DeviceName),
+			CostComponents: ebsVolumeCostComponents(r.Region, device.EBSVolumeData, nil),
+		})
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		SubResources:   subResources,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+func (r *Instance) computeCostComponent() *schema.CostComponent {
+	osKey := "linux"
+	if r.OperatingSystem != nil && *r.OperatingSystem != "" {
+		osKey = strings.ToLower(*r.OperatingSystem)
+	}
+
+	os, ok := instanceOperatingSystemMap[osKey]
+	if !ok {
+		os = instanceOperatingSystemMap["linux"]
+	}
+
+	tenancy, ok := instanceTenancyMap[strings.ToLower(r.Tenancy)]
+	if !ok {
+		tenancy = instanceTenancyMap["default"]
+	}
+
+	qty := schema.HourToMonthUnitMultiplier
+	if r.MonthlyHrs != nil {
+		qty = decimal.NewFromFloat(*r.MonthlyHrs)
+	}
+
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("Instance usage (%s, on-demand, %s)", os.Label, r.InstanceType),
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(qty),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr(r.InstanceType)},
+				{Key: "tenancy", Value: strPtr(tenancy)},
+				{Key: "operatingSystem", Value: strPtr(os.Name)},
+				{Key: "preInstalledSw", Value: strPtr("NA")},
+				{Key: "licenseModel", Value: strPtr("No License required")},
+				{Key: "capacitystatus", Value: strPtr("Used")},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+	}
+}
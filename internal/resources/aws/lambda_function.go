@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"math"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const defaultLambdaMemorySize = 128
+
+type LambdaFunction struct {
+	Address      string
+	Region       string
+	MemorySize   int64
+	Architecture string
+
+	MonthlyRequests   *int64   `infracost_usage:"monthly_requests"`
+	RequestDurationMS *float64 `infracost_usage:"request_duration_ms"`
+}
+
+func (r *LambdaFunction) CoreType() string {
+	return "LambdaFunction"
+}
+
+func (r *LambdaFunction) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "monthly_requests", ValueType: schema.Int64, DefaultValue: 0},
+		{Key: "request_duration_ms", ValueType: schema.Float64, DefaultValue: 0},
+	}
+}
+
+func (r *LambdaFunction) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *LambdaFunction) BuildResource() *schema.Resource {
+	groupSuffix := ""
+	if strings.ToLower(r.Architecture) == "arm64" {
+		groupSuffix = "-ARM"
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            "Requests",
+			Unit:            "1M requests",
+			UnitMultiplier:  decimal.NewFromInt(1000000),
+			MonthlyQuantity: intPtrToDecimalPtr(r.MonthlyRequests),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "group", Value: strPtr("AWS-Lambda-Requests" + groupSuffix)},
+					{Key: "usagetype", ValueRegex: regexPtr("Request")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+			UsageBased:  true,
+		},
+		{
+			Name:            "Duration",
+			Unit:            "GB-seconds",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: lambdaGBSeconds(r.MemorySize, r.MonthlyRequests, r.RequestDurationMS),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AWSLambda"),
+				ProductFamily: strPtr("Serverless"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "group", Value: strPtr("AWS-Lambda-Duration" + groupSuffix)},
+					{Key: "usagetype", ValueRegex: regexPtr("GB-Second")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption:   strPtr("on_demand"),
+				StartUsageAmount: strPtr("0"),
+			},
+			UsageBased: true,
+		},
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+// lambdaGBSeconds returns the monthly compute in GB-seconds. Lambda bills the duration of each
+// request rounded up to the nearest millisecond.
+func lambdaGBSeconds(memorySize int64, monthlyRequests *int64, requestDurationMS *float64) *decimal.Decimal {
+	if monthlyRequests == nil || requestDurationMS == nil {
+		return nil
+	}
+
+	if memorySize <= 0 {
+		memorySize = defaultLambdaMemorySize
+	}
+
+	gb := decimal.NewFromInt(memorySize).Div(decimal.NewFromInt(1024))
+	seconds := decimal.NewFromFloat(math.Ceil(*requestDurationMS)).Div(decimal.NewFromInt(1000))
+
+	return decimalPtr(decimal.NewFromInt(*monthlyRequests).Mul(seconds).Mul(gb))
+}
@@ -0,0 +1,35 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestLambdaGBSeconds(t *testing.T) {
+	requests := int64(1000000)
+	tests := []struct {
+		memorySize int64
+		durationMS float64
+		expected   string
+	}{
+		{128, 100, "12500"},
+		{512, 100, "50000"},
+		{1024, 99.2, "100000"},
+		{0, 1000, "125000"},
+	}
+
+	for _, test := range tests {
+		actual := lambdaGBSeconds(test.memorySize, &requests, &test.durationMS)
+		assert.Equal(t, test.expected, actual.String())
+	}
+
+	assert.Equal(t, true, lambdaGBSeconds(128, nil, nil) == nil)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+var lbTypeMap = map[string]struct {
+	Name          string
+	ProductFamily string
+	CapacityUnit  string
+}{
+	"application": {"Application load balancer", "Load Balancer-Application", "LCU"},
+	"network":     {"Network load balancer", "Load Balancer-Network", "NLCU"},
+	"gateway":     {"Gateway load balancer", "Load Balancer-Gateway", "GLCU"},
+}
+
+// LB is an application, network or gateway load balancer.
+type LB struct {
+	Address          string
+	Region           string
+	LoadBalancerType string
+
+	CapacityUnits *float64 `infracost_usage:"capacity_units"`
+}
+
+func (r *LB) CoreType() string {
+	return "LB"
+}
+
+func (r *LB) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "capacity_units", ValueType: schema.Float64, DefaultValue: 0},
+	}
+}
+
+func (r *LB) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *LB) BuildResource() *schema.Resource {
+	lbType, ok := lbTypeMap[strings.ToLower(r.LoadBalancerType)]
+	if !ok {
+		lbType = lbTypeMap["application"]
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           lbType.Name,
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AWSELB"),
+				ProductFamily: strPtr(lbType.ProductFamily),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: regexPtr("LoadBalancerUsage$")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+		},
+		{
+			Name:           "Load balancer capacity units",
+			Unit:           lbType.CapacityUnit,
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: floatPtrToDecimalPtr(r.CapacityUnits),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AWSELB"),
+				ProductFamily: strPtr(lbType.ProductFamily),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: regexPtr("LCUUsage$")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+			UsageBased:  true,
+		},
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
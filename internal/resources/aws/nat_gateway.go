@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+type NATGateway struct {
+	Address string
+	Region  string
+
+	MonthlyDataProcessedGB *float64 `infracost_usage:"monthly_data_processed_gb"`
+}
+
+func (r *NATGateway) CoreType() string {
+	return "NATGateway"
+}
+
+func (r *NATGateway) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "monthly_data_processed_gb", ValueType: schema.Float64, DefaultValue: 0},
+	}
+}
+
+func (r *NATGateway) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *NATGateway) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           "NAT gateway",
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("NAT Gateway"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: regexPtr("NatGateway-Hours$")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+		},
+		{
+			Name:            "Data processed",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: floatPtrToDecimalPtr(r.MonthlyDataProcessedGB),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AmazonEC2"),
+				ProductFamily: strPtr("NAT Gateway"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: regexPtr("NatGateway-Bytes$")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+			UsageBased:  true,
+		},
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
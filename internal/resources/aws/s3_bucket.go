@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+// S3Bucket prices the S3 Standard storage class of a bucket. All of its costs are usage based.
+type S3Bucket struct {
+	Address string
+	Region  string
+
+	StorageGB            *float64 `infracost_usage:"storage_gb"`
+	MonthlyTier1Requests *int64   `infracost_usage:"monthly_tier_1_requests"`
+	MonthlyTier2Requests *int64   `infracost_usage:"monthly_tier_2_requests"`
+}
+
+func (r *S3Bucket) CoreType() string {
+	return "S3Bucket"
+}
+
+func (r *S3Bucket) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+		{Key: "monthly_tier_1_requests", ValueType: schema.Int64, DefaultValue: 0},
+		{Key: "monthly_tier_2_requests", ValueType: schema.Int64, DefaultValue: 0},
+	}
+}
+
+func (r *S3Bucket) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *S3Bucket) BuildResource() *schema.Resource {
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            "Storage (standard)",
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: floatPtrToDecimalPtr(r.StorageGB),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(r.Region),
+				Service:       strPtr("AmazonS3"),
+				ProductFamily: strPtr("Storage"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "volumeType", Value: strPtr("Standard")},
+					{Key: "usagetype", ValueRegex: regexPtr("TimedStorage-ByteHrs$")},
+				},
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption:   strPtr("on_demand"),
+				StartUsageAmount: strPtr("0"),
+			},
+			UsageBased: true,
+		},
+		s3RequestsCostComponent("PUT, COPY, POST, LIST requests", r.Region, "Requests-Tier1$", intPtrToDecimalPtr(r.MonthlyTier1Requests)),
+		s3RequestsCostComponent("GET, SELECT, and all other requests", r.Region, "Requests-Tier2$", intPtrToDecimalPtr(r.MonthlyTier2Requests)),
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+func s3RequestsCostComponent(name, region, usageTypeRegex string, requests *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "1k requests",
+		UnitMultiplier:  decimal.NewFromInt(1000),
+		MonthlyQuantity: requests,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(region),
+			Service:       strPtr("AmazonS3"),
+			ProductFamily: strPtr("API Request"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "usagetype", ValueRegex: regexPtr(usageTypeRegex)},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+		UsageBased:  true,
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const (
+	vendorName = "aws"
+)
+
+var (
+	priceFilterOnDemand = &schema.PriceFilter{
+		PurchaseOption: strPtr("on_demand"),
+	}
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func intPtrToDecimalPtr(i *int64) *decimal.Decimal {
+	if i == nil {
+		return nil
+	}
+	return decimalPtr(decimal.NewFromInt(*i))
+}
+
+func floatPtrToDecimalPtr(f *float64) *decimal.Decimal {
+	if f == nil {
+		return nil
+	}
+	return decimalPtr(decimal.NewFromFloat(*f))
+}
+
+func regexPtr(regex string) *string {
+	return strPtr(fmt.Sprintf("/%s/i", regex))
+}
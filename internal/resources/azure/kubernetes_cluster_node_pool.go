@@ -30,6 +30,8 @@ type KubernetesClusterNodePool struct {
 	Region       string
 	NodeCount    int64
 	VMSize       string
+	Mode         string
+	Priority     string
 	OS           string
 	OSDiskType   string
 	OSDiskSizeGB int64
@@ -62,12 +64,12 @@ func (r *KubernetesClusterNodePool) BuildResource() *schema.Resource {
 		nodeCount = decimal.NewFromInt(*r.Nodes)
 	}
 
-	pool := aksClusterNodePool(r.Address, r.Region, r.VMSize, r.OS, r.OSDiskType, r.OSDiskSizeGB, nodeCount, r.MonthlyHours, r.IsDevTest)
+	pool := aksClusterNodePool(r.Address, r.Region, r.VMSize, r.Priority, r.OS, r.OSDiskType, r.OSDiskSizeGB, nodeCount, r.MonthlyHours, r.IsDevTest)
 	pool.UsageSchema = r.UsageSchema()
 	return pool
 }
 
-func aksClusterNodePool(name, region, instanceType, os string, osDiskType string, osDiskSizeGB int64, nodeCount decimal.Decimal, monthlyHours *float64, isDevTest bool) *schema.Resource {
+func aksClusterNodePool(name, region, instanceType, priority, os string, osDiskType string, osDiskSizeGB int64, nodeCount decimal.Decimal, monthlyHours *float64, isDevTest bool) *schema.Resource {
 	var costComponents []*schema.CostComponent
 	var subResources []*schema.Resource
 
@@ -76,9 +78,9 @@ func aksClusterNodePool(name, region, instanceType, os string, osDiskType string
 	}
 
 	if strings.EqualFold(os, "windows") {
-		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, "None", monthlyHours, isDevTest))
+		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, "None", priority, monthlyHours, isDevTest))
 	} else {
-		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, monthlyHours))
+		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, priority, monthlyHours))
 	}
 
 	mainResource.CostComponents = costComponents
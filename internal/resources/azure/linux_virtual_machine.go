@@ -30,6 +30,7 @@ type LinuxVirtualMachine struct {
 	Region          string
 	Size            string
 	UltraSSDEnabled bool
+	Priority        string
 	OSDiskData      *ManagedDiskData
 	OSDisk          *OSDiskUsage `infracost_usage:"os_disk"`
 	MonthlyHrs      *float64     `infracost_usage:"monthly_hrs"`
@@ -57,7 +58,7 @@ func (r *LinuxVirtualMachine) PopulateUsage(u *schema.UsageData) {
 func (r *LinuxVirtualMachine) BuildResource() *schema.Resource {
 	instanceType := r.Size
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(r.Region, instanceType, r.MonthlyHrs)}
+	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(r.Region, instanceType, r.Priority, r.MonthlyHrs)}
 
 	if r.UltraSSDEnabled {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(r.Region))
@@ -83,9 +84,10 @@ func (r *LinuxVirtualMachine) BuildResource() *schema.Resource {
 	}
 }
 
-func linuxVirtualMachineCostComponent(region string, instanceType string, monthlyHours *float64) *schema.CostComponent {
+func linuxVirtualMachineCostComponent(region string, instanceType string, priority string, monthlyHours *float64) *schema.CostComponent {
 	purchaseOption := "Consumption"
-	purchaseOptionLabel := "pay as you go"
+	// Note-plancost: the priority selects the pay as you go, Spot or Low Priority SKU
+	skuNameRe, purchaseOptionLabel := vmPriorityPricing(priority)
 
 	productNameRe := "/Series( Linux)?$/i"
 	if strings.HasPrefix(strings.ToLower(instanceType), "basic_") {
@@ -111,7 +113,7 @@ func linuxVirtualMachineCostComponent(region string, instanceType string, monthl
 			ProductFamily: strPtr("Compute"),
 			AttributeFilters: []*schema.AttributeFilter{
 				{Key: "meterName", ValueRegex: strPtr("/^(?!.*(Expired|Free)$).*$/i")},
-				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "skuName", ValueRegex: strPtr(skuNameRe)},
 				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", instanceType))},
 				{Key: "productName", ValueRegex: strPtr(productNameRe)},
 			},
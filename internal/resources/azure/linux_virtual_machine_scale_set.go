@@ -26,6 +26,7 @@ type LinuxVirtualMachineScaleSet struct {
 	Address         string
 	SKU             string
 	UltraSSDEnabled bool
+	Priority        string
 	Region          string
 	OSDiskData      *ManagedDiskData
 	Instances       *int64       `infracost_usage:"instances"`
@@ -55,7 +56,7 @@ func (r *LinuxVirtualMachineScaleSet) BuildResource() *schema.Resource {
 
 	instanceType := r.SKU
 
-	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(r.Region, instanceType, nil)}
+	costComponents := []*schema.CostComponent{linuxVirtualMachineCostComponent(r.Region, instanceType, r.Priority, nil)}
 	subResources := make([]*schema.Resource, 0)
 
 	if r.UltraSSDEnabled {
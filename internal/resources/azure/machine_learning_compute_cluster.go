@@ -31,6 +31,7 @@ type MachineLearningComputeCluster struct {
 	Address      string
 	Region       string
 	InstanceType string
+	Priority     string
 	MinNodeCount int64
 	Instances    *int64   `infracost_usage:"instances"`
 	MonthlyHours *float64 `infracost_usage:"monthly_hrs"`
@@ -60,7 +61,7 @@ func (r *MachineLearningComputeCluster) PopulateUsage(u *schema.UsageData) {
 // See providers folder for more information.
 func (r *MachineLearningComputeCluster) BuildResource() *schema.Resource {
 	costComponents := []*schema.CostComponent{
-		linuxVirtualMachineCostComponent(r.Region, r.InstanceType, r.MonthlyHours),
+		linuxVirtualMachineCostComponent(r.Region, r.InstanceType, r.Priority, r.MonthlyHours),
 	}
 
 	res := &schema.Resource{
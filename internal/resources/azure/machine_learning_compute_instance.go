@@ -55,7 +55,7 @@ func (r *MachineLearningComputeInstance) PopulateUsage(u *schema.UsageData) {
 // See providers folder for more information.
 func (r *MachineLearningComputeInstance) BuildResource() *schema.Resource {
 	costComponents := []*schema.CostComponent{
-		linuxVirtualMachineCostComponent(r.Region, r.InstanceType, r.MonthlyHours),
+		linuxVirtualMachineCostComponent(r.Region, r.InstanceType, "", r.MonthlyHours),
 	}
 
 	return &schema.Resource{
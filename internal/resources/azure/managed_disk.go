@@ -132,6 +132,11 @@ var ultraDiskSizes = []int{4, 8, 16, 32, 64, 128, 256, 512}
 var ultraDiskSizeStep = 1024
 var ultraDiskMaxSize = 65536
 
+const (
+	premiumV2DiskBaselineIOPS = 3000
+	premiumV2DiskBaselineMBPS = 125
+)
+
 var diskProductNameMap = map[string]string{
 	"Standard":    "Standard HDD Managed Disks",
 	"StandardSSD": "Standard SSD Managed Disks",
@@ -157,6 +162,11 @@ func managedDiskCostComponents(region, diskType string, diskSizeGB, diskIOPSRead
 		return ultraDiskCostComponents(region, storageReplicationType, diskSizeGB, diskIOPSReadWrite, diskMBPSReadWrite)
 	}
 
+	// Note-plancost: Premium SSD v2 disks are priced by provisioned capacity, IOPS and throughput like ultra disks
+	if strings.ToLower(diskTypePrefix) == "premiumv2" {
+		return premiumV2DiskCostComponents(region, storageReplicationType, diskSizeGB, diskIOPSReadWrite, diskMBPSReadWrite)
+	}
+
 	return standardPremiumDiskCostComponents(region, diskTypePrefix, storageReplicationType, diskSizeGB, monthlyDiskOperations)
 }
 
@@ -322,6 +332,82 @@ func ultraDiskCostComponents(region string, storageReplicationType string, diskS
 	return costComponents
 }
 
+// premiumV2DiskCostComponents returns the cost components of a Premium SSD v2 disk. The baseline of 3,000 IOPS and
+// 125 MB/s is included in the price of the capacity, so only the IOPS and throughput above the baseline are charged.
+func premiumV2DiskCostComponents(region string, storageReplicationType string, diskSizeGB, diskIOPSReadWrite, diskMBPSReadWrite int64) []*schema.CostComponent {
+	diskSize := int64(1024)
+	iops := int64(premiumV2DiskBaselineIOPS)
+	throughput := int64(premiumV2DiskBaselineMBPS)
+
+	if diskSizeGB > 0 {
+		diskSize = diskSizeGB
+	}
+
+	if diskIOPSReadWrite > 0 {
+		iops = diskIOPSReadWrite
+	}
+
+	if diskMBPSReadWrite > 0 {
+		throughput = diskMBPSReadWrite
+	}
+
+	skuName := fmt.Sprintf("Premium %s", storageReplicationType)
+	productFilter := func(meterNameRegex string) *schema.ProductFilter {
+		return &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(region),
+			Service:       strPtr("Storage"),
+			ProductFamily: strPtr("Storage"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "productName", Value: strPtr("Azure Premium SSD v2")},
+				{Key: "skuName", Value: strPtr(skuName)},
+				{Key: "meterName", ValueRegex: regexPtr(meterNameRegex)},
+			},
+		}
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Storage (premium v2, %d GiB)", diskSize),
+			Unit:           "GiB",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(diskSize)),
+			ProductFilter:  productFilter("Provisioned Capacity$"),
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		},
+	}
+
+	if iops > premiumV2DiskBaselineIOPS {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           "Provisioned IOPS",
+			Unit:           "IOPS",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(iops - premiumV2DiskBaselineIOPS)),
+			ProductFilter:  productFilter("Provisioned IOPS$"),
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		})
+	}
+
+	if throughput > premiumV2DiskBaselineMBPS {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:           "Throughput",
+			Unit:           "MB/s",
+			UnitMultiplier: schema.HourToMonthUnitMultiplier,
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(throughput - premiumV2DiskBaselineMBPS)),
+			ProductFilter:  productFilter("Provisioned Throughput \\(MBps\\)$"),
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("Consumption"),
+			},
+		})
+	}
+
+	return costComponents
+}
+
 func mapDiskName(diskType string, requestedSize int) string {
 	diskTypeMap, ok := diskSizeMap[diskType]
 	if !ok {
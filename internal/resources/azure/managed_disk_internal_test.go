@@ -83,3 +83,24 @@ func TestMapStorageReplicationType(t *testing.T) {
 		assert.Equal(t, test.expected, actual)
 	}
 }
+
+func TestPremiumV2DiskCostComponents(t *testing.T) {
+	tests := []struct {
+		diskSizeGB int64
+		iops       int64
+		mbps       int64
+		expected   map[string]string
+	}{
+		{0, 0, 0, map[string]string{"Storage (premium v2, 1024 GiB)": "1024"}},
+		{128, 3000, 125, map[string]string{"Storage (premium v2, 128 GiB)": "128"}},
+		{128, 5000, 200, map[string]string{"Storage (premium v2, 128 GiB)": "128", "Provisioned IOPS": "2000", "Throughput": "75"}},
+	}
+
+	for _, test := range tests {
+		actual := make(map[string]string)
+		for _, cc := range managedDiskCostComponents("eastus", "PremiumV2_LRS", test.diskSizeGB, test.iops, test.mbps, nil) {
+			actual[cc.Name] = cc.HourlyQuantity.String()
+		}
+		assert.Equal(t, test.expected, actual)
+	}
+}
@@ -98,9 +98,9 @@ func (r *VirtualMachine) BuildResource() *schema.Resource {
 
 	if strings.ToLower(os) == "windows" {
 		licenseType := r.LicenseType
-		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType, r.MonthlyHours, r.IsDevTest))
+		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType, "", r.MonthlyHours, r.IsDevTest))
 	} else {
-		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, r.MonthlyHours))
+		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, "", r.MonthlyHours))
 	}
 
 	// TODO: is this always assuming ultrassdreservation cost?
@@ -137,6 +137,40 @@ func (r *VirtualMachine) BuildResource() *schema.Resource {
 	}
 }
 
+// Note-plancost: Spot and low priority VMs are priced with the Spot and Low Priority SKUs of the VM size.
+const (
+	// VMPrioritySpot is the priority of Spot VMs, which can be evicted when Azure needs the capacity.
+	VMPrioritySpot = "Spot"
+	// VMPriorityLowPriority is the priority of low priority VMs, the predecessor of Spot VMs that is still used by
+	// legacy scale sets and machine learning compute clusters.
+	VMPriorityLowPriority = "Low Priority"
+)
+
+// NormalizeVMPriority returns the VM priority of the priority attribute of a resource, e.g. `Spot` of virtual machines,
+// `Low` of legacy scale sets or `LowPriority` of machine learning compute clusters. Regular priorities return "".
+func NormalizeVMPriority(priority string) string {
+	switch strings.ToLower(strings.ReplaceAll(priority, " ", "")) {
+	case "spot":
+		return VMPrioritySpot
+	case "low", "lowpriority":
+		return VMPriorityLowPriority
+	default:
+		return ""
+	}
+}
+
+// vmPriorityPricing returns the skuName filter and the purchase option label of the VM priority.
+func vmPriorityPricing(priority string) (skuNameRegex, label string) {
+	switch priority {
+	case VMPrioritySpot:
+		return "/ Spot$/i", "spot"
+	case VMPriorityLowPriority:
+		return "/ Low Priority$/i", "low priority"
+	default:
+		return "/^(?!.*(Low Priority|Spot)$).*$/i", "pay as you go"
+	}
+}
+
 func ultraSSDReservationCostComponent(region string) *schema.CostComponent {
 	return &schema.CostComponent{
 		Name:           "Ultra disk reservation (if unattached)",
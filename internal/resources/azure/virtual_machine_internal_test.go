@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeVMPriority(t *testing.T) {
+	tests := []struct {
+		priority string
+		expected string
+	}{
+		{"", ""},
+		{"Regular", ""},
+		{"Dedicated", ""},
+		{"Spot", VMPrioritySpot},
+		{"spot", VMPrioritySpot},
+		{"Low", VMPriorityLowPriority},
+		{"LowPriority", VMPriorityLowPriority},
+		{"Low Priority", VMPriorityLowPriority},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, NormalizeVMPriority(tt.priority), tt.priority)
+	}
+}
+
+func TestVirtualMachineCostComponentPriority(t *testing.T) {
+	tests := []struct {
+		name         string
+		priority     string
+		licenseType  string
+		isDevTest    bool
+		expectedName string
+		skuNameRegex string
+		purchase     string
+	}{
+		{
+			name:         "pay as you go",
+			expectedName: "Instance usage (Windows, pay as you go, Standard_D2s_v5)",
+			skuNameRegex: "/^(?!.*(Low Priority|Spot)$).*$/i",
+			purchase:     "Consumption",
+		},
+		{
+			name:         "spot",
+			priority:     VMPrioritySpot,
+			expectedName: "Instance usage (Windows, spot, Standard_D2s_v5)",
+			skuNameRegex: "/ Spot$/i",
+			purchase:     "Consumption",
+		},
+		{
+			name:         "spot with hybrid benefit in dev/test",
+			priority:     VMPrioritySpot,
+			licenseType:  "Windows_Server",
+			isDevTest:    true,
+			expectedName: "Instance usage (Windows, spot, Standard_D2s_v5)",
+			skuNameRegex: "/ Spot$/i",
+			purchase:     "Consumption",
+		},
+		{
+			name:         "low priority",
+			priority:     VMPriorityLowPriority,
+			expectedName: "Instance usage (Windows, low priority, Standard_D2s_v5)",
+			skuNameRegex: "/ Low Priority$/i",
+			purchase:     "Consumption",
+		},
+		{
+			name:         "hybrid benefit",
+			licenseType:  "Windows_Server",
+			expectedName: "Instance usage (Windows, hybrid benefit, Standard_D2s_v5)",
+			skuNameRegex: "/^(?!.*(Low Priority|Spot)$).*$/i",
+			purchase:     "DevTestConsumption",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := windowsVirtualMachineCostComponent("eastus", "Standard_D2s_v5", tt.licenseType, tt.priority, nil, tt.isDevTest)
+			assert.Equal(t, tt.expectedName, cc.Name)
+			assert.Equal(t, tt.skuNameRegex, *cc.ProductFilter.AttributeFilters[0].ValueRegex)
+			assert.Equal(t, tt.purchase, *cc.PriceFilter.PurchaseOption)
+		})
+	}
+
+	cc := linuxVirtualMachineCostComponent("eastus", "Standard_D2s_v5", VMPrioritySpot, nil)
+	assert.Equal(t, "Instance usage (Linux, spot, Standard_D2s_v5)", cc.Name)
+	assert.Equal(t, "/ Spot$/i", *cc.ProductFilter.AttributeFilters[1].ValueRegex)
+}
+
+func TestMachineLearningComputeClusterPriority(t *testing.T) {
+	cluster := &MachineLearningComputeCluster{
+		Address:      "azurerm_machine_learning_compute_cluster.example",
+		Region:       "westeurope",
+		InstanceType: "Standard_D2s_v3",
+		Priority:     NormalizeVMPriority("LowPriority"),
+		MinNodeCount: 2,
+	}
+
+	res := cluster.BuildResource()
+	cc := res.CostComponents[0]
+	assert.Equal(t, "Instance usage (Linux, low priority, Standard_D2s_v3)", cc.Name)
+	assert.Equal(t, "/ Low Priority$/i", *cc.ProductFilter.AttributeFilters[1].ValueRegex)
+	assert.Equal(t, "1460", cc.MonthlyQuantity.String())
+}
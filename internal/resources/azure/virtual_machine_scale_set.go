@@ -32,6 +32,7 @@ type VirtualMachineScaleSet struct {
 	IsWindows                 bool
 	IsDevTest                 bool
 	LicenseType               string
+	Priority                  string
 	StorageProfileOSDiskData  *ManagedDiskData
 	StorageProfileOSDisksData []*ManagedDiskData
 
@@ -99,7 +100,7 @@ func (r *VirtualMachineScaleSet) BuildResource() *schema.Resource {
 	}
 
 	if strings.ToLower(os) == "linux" {
-		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, nil))
+		costComponents = append(costComponents, linuxVirtualMachineCostComponent(region, instanceType, r.Priority, nil))
 	}
 
 	if strings.ToLower(os) == "windows" {
@@ -107,7 +108,7 @@ func (r *VirtualMachineScaleSet) BuildResource() *schema.Resource {
 		if r.LicenseType != "" {
 			licenseType = r.LicenseType
 		}
-		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType, nil, r.IsDevTest))
+		costComponents = append(costComponents, windowsVirtualMachineCostComponent(region, instanceType, licenseType, r.Priority, nil, r.IsDevTest))
 	}
 
 	res := &schema.Resource{
@@ -31,6 +31,7 @@ type WindowsVirtualMachine struct {
 	Size                                  string
 	LicenseType                           string
 	AdditionalCapabilitiesUltraSSDEnabled bool
+	Priority                              string
 	OSDiskData                            *ManagedDiskData
 	MonthlyHours                          *float64     `infracost_usage:"monthly_hrs"`
 	OSDisk                                *OSDiskUsage `infracost_usage:"os_disk"`
@@ -70,7 +71,7 @@ func (r *WindowsVirtualMachine) BuildResource() *schema.Resource {
 	instanceType := r.Size
 	licenseType := r.LicenseType
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, r.MonthlyHours, r.IsDevTest)}
+	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, r.Priority, r.MonthlyHours, r.IsDevTest)}
 
 	if r.AdditionalCapabilitiesUltraSSDEnabled {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -97,9 +98,10 @@ func (r *WindowsVirtualMachine) BuildResource() *schema.Resource {
 	}
 }
 
-func windowsVirtualMachineCostComponent(region string, instanceType string, licenseType string, monthlyHours *float64, isDevTest bool) *schema.CostComponent {
+func windowsVirtualMachineCostComponent(region string, instanceType string, licenseType string, priority string, monthlyHours *float64, isDevTest bool) *schema.CostComponent {
 	purchaseOption := "Consumption"
-	purchaseOptionLabel := "pay as you go"
+	// Note-plancost: the priority selects the pay as you go, Spot or Low Priority SKU
+	skuNameRe, purchaseOptionLabel := vmPriorityPricing(priority)
 
 	productNameRe := "/(Series )?Windows$/i"
 	if strings.HasPrefix(instanceType, "Basic_") {
@@ -108,12 +110,15 @@ func windowsVirtualMachineCostComponent(region string, instanceType string, lice
 		instanceType = fmt.Sprintf("Standard_%s", instanceType)
 	}
 
-	if strings.ToLower(licenseType) == "windows_client" || strings.ToLower(licenseType) == "windows_server" {
+	// Note-plancost: Spot and Low Priority SKUs only have consumption prices, which include the Windows license
+	spot := priority != ""
+
+	if !spot && (strings.ToLower(licenseType) == "windows_client" || strings.ToLower(licenseType) == "windows_server") {
 		purchaseOption = "DevTestConsumption"
 		purchaseOptionLabel = "hybrid benefit"
 	}
 
-	if isDevTest {
+	if !spot && isDevTest {
 		purchaseOption = "DevTestConsumption"
 		purchaseOptionLabel = "dev/test"
 	}
@@ -134,7 +139,7 @@ func windowsVirtualMachineCostComponent(region string, instanceType string, lice
 			Service:       strPtr("Virtual Machines"),
 			ProductFamily: strPtr("Compute"),
 			AttributeFilters: []*schema.AttributeFilter{
-				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "skuName", ValueRegex: strPtr(skuNameRe)},
 				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", instanceType))},
 				{Key: "productName", ValueRegex: strPtr(productNameRe)},
 			},
@@ -28,6 +28,7 @@ type WindowsVirtualMachineScaleSet struct {
 	SKU                                   string
 	LicenseType                           string
 	AdditionalCapabilitiesUltraSSDEnabled bool
+	Priority                              string
 	IsDevTest                             bool
 	OSDiskData                            *ManagedDiskData
 	Instances                             *int64       `infracost_usage:"instances"`
@@ -59,7 +60,7 @@ func (r *WindowsVirtualMachineScaleSet) BuildResource() *schema.Resource {
 	instanceType := r.SKU
 	licenseType := r.LicenseType
 
-	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, nil, r.IsDevTest)}
+	costComponents := []*schema.CostComponent{windowsVirtualMachineCostComponent(region, instanceType, licenseType, r.Priority, nil, r.IsDevTest)}
 
 	if r.AdditionalCapabilitiesUltraSSDEnabled {
 		costComponents = append(costComponents, ultraSSDReservationCostComponent(region))
@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package gcp
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const (
+	defaultComputeDiskType = "pd-standard"
+	defaultComputeDiskSize = 10
+)
+
+var computeDiskTypeMap = map[string]struct {
+	Name        string
+	Description string
+}{
+	"pd-standard": {"Standard provisioned storage (pd-standard)", "^Storage PD Capacity"},
+	"pd-balanced": {"Balanced provisioned storage (pd-balanced)", "^Balanced PD Capacity"},
+	"pd-ssd":      {"SSD provisioned storage (pd-ssd)", "^SSD backed PD Capacity"},
+	"pd-extreme":  {"Extreme provisioned storage (pd-extreme)", "^Extreme PD Capacity"},
+}
+
+type ComputeDisk struct {
+	Address string
+	Region  string
+	ComputeDiskData
+}
+
+type ComputeDiskData struct {
+	Type   string
+	SizeGB int64
+}
+
+func (r *ComputeDisk) CoreType() string {
+	return "ComputeDisk"
+}
+
+func (r *ComputeDisk) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{}
+}
+
+func (r *ComputeDisk) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *ComputeDisk) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: []*schema.CostComponent{computeDiskCostComponent(r.Region, r.ComputeDiskData, decimal.NewFromInt(1))},
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+// computeDiskCostComponent returns the storage cost of count persistent disks.
+func computeDiskCostComponent(region string, disk ComputeDiskData, count decimal.Decimal) *schema.CostComponent {
+	diskType := disk.Type
+	if diskType == "" {
+		diskType = defaultComputeDiskType
+	}
+
+	t, ok := computeDiskTypeMap[diskType]
+	if !ok {
+		t.Name = fmt.Sprintf("Provisioned storage (%s)", diskType)
+		t.Description = computeDiskTypeMap[defaultComputeDiskType].Description
+	}
+
+	size := disk.SizeGB
+	if size <= 0 {
+		size = defaultComputeDiskSize
+	}
+
+	return &schema.CostComponent{
+		Name:            t.Name,
+		Unit:            "GB",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(decimal.NewFromInt(size).Mul(count)),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(region),
+			Service:       strPtr("Compute Engine"),
+			ProductFamily: strPtr("Storage"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: regexPtr(t.Description)},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+type ComputeInstance struct {
+	Address     string
+	Region      string
+	MachineType string
+	Preemptible bool
+	BootDisk    ComputeDiskData
+
+	MonthlyHrs *float64 `infracost_usage:"monthly_hrs"`
+}
+
+func (r *ComputeInstance) CoreType() string {
+	return "ComputeInstance"
+}
+
+func (r *ComputeInstance) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "monthly_hrs", ValueType: schema.Float64, DefaultValue: 730},
+	}
+}
+
+func (r *ComputeInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *ComputeInstance) BuildResource() *schema.Resource {
+	qty := schema.HourToMonthUnitMultiplier
+	if r.MonthlyHrs != nil {
+		qty = decimal.NewFromFloat(*r.MonthlyHrs)
+	}
+
+	costComponents := make([]*schema.CostComponent, 0)
+	if c := computeMachineCostComponent(r.Region, r.MachineType, r.Preemptible, qty); c != nil {
+		costComponents = append(costComponents, c)
+	}
+
+	subResources := []*schema.Resource{
+		{
+			Name:           "boot_disk",
+			CostComponents: []*schema.CostComponent{computeDiskCostComponent(r.Region, r.BootDisk, decimal.NewFromInt(1))},
+		},
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		SubResources:   subResources,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+// computeMachineCostComponent returns the compute cost of a predefined machine type running for
+// the given number of hours per month. Custom machine types are not supported.
+func computeMachineCostComponent(region, machineType string, preemptible bool, monthlyHours decimal.Decimal) *schema.CostComponent {
+	if strings.Contains(strings.ToLower(machineType), "custom") {
+		logging.Logger.Warn().Msgf("Custom machine type %s is not supported", machineType)
+		return nil
+	}
+
+	purchaseOption := "on-demand"
+	priceFilter := priceFilterOnDemand
+	if preemptible {
+		purchaseOption = "preemptible"
+		priceFilter = priceFilterPreemptible
+	}
+
+	return &schema.CostComponent{
+		Name:            fmt.Sprintf("Instance usage (Linux/UNIX, %s, %s)", purchaseOption, machineType),
+		Unit:            "hours",
+		UnitMultiplier:  decimal.NewFromInt(1),
+		MonthlyQuantity: decimalPtr(monthlyHours),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(region),
+			Service:       strPtr("Compute Engine"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "machineType", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", machineType))},
+			},
+		},
+		PriceFilter: priceFilter,
+	}
+}
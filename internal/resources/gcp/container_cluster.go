@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package gcp
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/plancost/terraform-provider-plancost/internal/usage"
+)
+
+type ContainerCluster struct {
+	Address         string
+	Region          string
+	IsRegional      bool
+	IsAutopilot     bool
+	DefaultNodePool *ContainerNodePoolData
+
+	DefaultPool *DefaultNodePoolUsage `infracost_usage:"default_pool"`
+}
+
+type DefaultNodePoolUsage struct {
+	Nodes *int64 `infracost_usage:"nodes"`
+}
+
+func (r *ContainerCluster) CoreType() string {
+	return "ContainerCluster"
+}
+
+func (r *ContainerCluster) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{
+			Key:          "default_pool",
+			ValueType:    schema.SubResourceUsage,
+			DefaultValue: &usage.ResourceUsage{Name: "default_pool", Items: ContainerNodePoolUsageSchema},
+		},
+	}
+}
+
+func (r *ContainerCluster) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *ContainerCluster) BuildResource() *schema.Resource {
+	description := "Zonal Kubernetes Clusters"
+	if r.IsAutopilot {
+		description = "Autopilot Kubernetes Clusters"
+	} else if r.IsRegional {
+		description = "Regional Kubernetes Clusters"
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           "Cluster management fee",
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr("global"),
+				Service:       strPtr("Kubernetes Engine"),
+				ProductFamily: strPtr("Compute"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "description", Value: strPtr(description)},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+		},
+	}
+
+	subResources := make([]*schema.Resource, 0)
+	if r.DefaultNodePool != nil && !r.IsAutopilot {
+		var nodes *int64
+		if r.DefaultPool != nil {
+			nodes = r.DefaultPool.Nodes
+		}
+
+		subResources = append(subResources, &schema.Resource{
+			Name:           "default_pool",
+			CostComponents: nodePoolCostComponents(r.Region, *r.DefaultNodePool, nodes),
+			UsageSchema:    ContainerNodePoolUsageSchema,
+		})
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		SubResources:   subResources,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package gcp
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const (
+	defaultNodeMachineType = "e2-medium"
+	defaultNodeDiskType    = "pd-balanced"
+	defaultNodeDiskSize    = 100
+)
+
+type ContainerNodePool struct {
+	Address string
+	Region  string
+	ContainerNodePoolData
+
+	Nodes *int64 `infracost_usage:"nodes"`
+}
+
+// ContainerNodePoolData describes the nodes of a node pool. NodeCount is the number of nodes in each of
+// the ZoneCount zones the pool runs in.
+type ContainerNodePoolData struct {
+	MachineType string
+	DiskType    string
+	DiskSizeGB  int64
+	Preemptible bool
+	NodeCount   int64
+	ZoneCount   int64
+}
+
+var ContainerNodePoolUsageSchema = []*schema.UsageItem{
+	{Key: "nodes", ValueType: schema.Int64, DefaultValue: 0},
+}
+
+func (r *ContainerNodePool) CoreType() string {
+	return "ContainerNodePool"
+}
+
+func (r *ContainerNodePool) UsageSchema() []*schema.UsageItem {
+	return ContainerNodePoolUsageSchema
+}
+
+func (r *ContainerNodePool) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *ContainerNodePool) BuildResource() *schema.Resource {
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: nodePoolCostComponents(r.Region, r.ContainerNodePoolData, r.Nodes),
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+func nodePoolCostComponents(region string, pool ContainerNodePoolData, nodesOverride *int64) []*schema.CostComponent {
+	zones := pool.ZoneCount
+	if zones <= 0 {
+		zones = 1
+	}
+
+	nodes := decimal.NewFromInt(pool.NodeCount * zones)
+	if nodesOverride != nil {
+		nodes = decimal.NewFromInt(*nodesOverride)
+	}
+
+	machineType := pool.MachineType
+	if machineType == "" {
+		machineType = defaultNodeMachineType
+	}
+
+	disk := ComputeDiskData{Type: pool.DiskType, SizeGB: pool.DiskSizeGB}
+	if disk.Type == "" {
+		disk.Type = defaultNodeDiskType
+	}
+	if disk.SizeGB <= 0 {
+		disk.SizeGB = defaultNodeDiskSize
+	}
+
+	costComponents := make([]*schema.CostComponent, 0)
+	if c := computeMachineCostComponent(region, machineType, pool.Preemptible, schema.HourToMonthUnitMultiplier.Mul(nodes)); c != nil {
+		costComponents = append(costComponents, c)
+	}
+	costComponents = append(costComponents, computeDiskCostComponent(region, disk, nodes))
+
+	return costComponents
+}
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package gcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/logging"
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const defaultSQLDiskSize = 10
+
+var sqlDatabaseEngineMap = map[string]string{
+	"MYSQL":     "MySQL",
+	"POSTGRES":  "PostgreSQL",
+	"SQLSERVER": "SQL Server",
+}
+
+var sqlSharedCoreTierMap = map[string]string{
+	"db-f1-micro": "Micro instance",
+	"db-g1-small": "Small instance",
+}
+
+var sqlMachineSeriesMemoryPerCPU = map[string]float64{
+	"standard": 3.75,
+	"highmem":  6.5,
+}
+
+type SQLDatabaseInstance struct {
+	Address          string
+	Region           string
+	DatabaseVersion  string
+	Tier             string
+	AvailabilityType string
+	DiskType         string
+	DiskSizeGB       int64
+
+	BackupStorageGB *float64 `infracost_usage:"backup_storage_gb"`
+}
+
+func (r *SQLDatabaseInstance) CoreType() string {
+	return "SQLDatabaseInstance"
+}
+
+func (r *SQLDatabaseInstance) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "backup_storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+	}
+}
+
+func (r *SQLDatabaseInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *SQLDatabaseInstance) BuildResource() *schema.Resource {
+	engine := sqlDatabaseEngine(r.DatabaseVersion)
+	if engine == "" {
+		// The resource isn't free, so it is reported as unsupported rather than as a free resource
+		logging.Logger.Warn().Msgf("Skipping resource %s. Unsupported database version %s", r.Address, r.DatabaseVersion)
+		return &schema.Resource{
+			Name:        r.Address,
+			IsSkipped:   true,
+			SkipMessage: fmt.Sprintf("Unsupported database version %s", r.DatabaseVersion),
+			UsageSchema: r.UsageSchema(),
+		}
+	}
+
+	availability := "Zonal"
+	if strings.ToUpper(r.AvailabilityType) == "REGIONAL" {
+		availability = "Regional"
+	}
+
+	costComponents := r.instanceCostComponents(engine, availability)
+
+	storageName, storageDescription := "Storage (SSD, %s)", "Standard storage"
+	if strings.ToUpper(r.DiskType) == "PD_HDD" {
+		storageName, storageDescription = "Storage (HDD, %s)", "Low cost storage"
+	}
+
+	diskSize := r.DiskSizeGB
+	if diskSize <= 0 {
+		diskSize = defaultSQLDiskSize
+	}
+
+	costComponents = append(costComponents,
+		r.costComponent(fmt.Sprintf(storageName, strings.ToLower(availability)), "GB", decimal.NewFromInt(1), nil, decimalPtr(decimal.NewFromInt(diskSize)), fmt.Sprintf("^Cloud SQL for %s: %s - %s", engine, availability, storageDescription)),
+	)
+
+	backup := r.costComponent("Backups", "GB", decimal.NewFromInt(1), nil, floatPtrToDecimalPtr(r.BackupStorageGB), fmt.Sprintf("^Cloud SQL for %s: Backups", engine))
+	backup.UsageBased = true
+	costComponents = append(costComponents, backup)
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+func (r *SQLDatabaseInstance) instanceCostComponents(engine, availability string) []*schema.CostComponent {
+	label := strings.ToLower(availability)
+
+	if sku, ok := sqlSharedCoreTierMap[r.Tier]; ok {
+		return []*schema.CostComponent{
+			r.costComponent(fmt.Sprintf("SQL instance (%s, %s)", r.Tier, label), "hours", decimal.NewFromInt(1), decimalPtr(decimal.NewFromInt(1)), nil, fmt.Sprintf("^Cloud SQL for %s: %s - %s", engine, availability, sku)),
+		}
+	}
+
+	vCPUs, memoryGB, ok := sqlTierResources(r.Tier)
+	if !ok {
+		logging.Logger.Warn().Msgf("Could not map tier %s to vCPUs and memory for %s", r.Tier, r.Address)
+		return nil
+	}
+
+	return []*schema.CostComponent{
+		r.costComponent(fmt.Sprintf("vCPUs (%s)", label), "hours", decimal.NewFromInt(1), decimalPtr(vCPUs), nil, fmt.Sprintf("^Cloud SQL for %s: %s - vCPU", engine, availability)),
+		r.costComponent(fmt.Sprintf("Memory (%s)", label), "GB", schema.HourToMonthUnitMultiplier, decimalPtr(memoryGB), nil, fmt.Sprintf("^Cloud SQL for %s: %s - RAM", engine, availability)),
+	}
+}
+
+func (r *SQLDatabaseInstance) costComponent(name, unit string, unitMultiplier decimal.Decimal, hourlyQuantity, monthlyQuantity *decimal.Decimal, description string) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            unit,
+		UnitMultiplier:  unitMultiplier,
+		HourlyQuantity:  hourlyQuantity,
+		MonthlyQuantity: monthlyQuantity,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(r.Region),
+			Service:       strPtr("Cloud SQL"),
+			ProductFamily: strPtr("ApplicationServices"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "description", ValueRegex: regexPtr(description)},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+	}
+}
+
+func sqlDatabaseEngine(databaseVersion string) string {
+	prefix := strings.SplitN(strings.ToUpper(databaseVersion), "_", 2)[0]
+	return sqlDatabaseEngineMap[prefix]
+}
+
+// sqlTierResources returns the vCPUs and memory in GB of a dedicated-core tier, e.g.
+// db-custom-2-7680 or db-n1-standard-2.
+func sqlTierResources(tier string) (decimal.Decimal, decimal.Decimal, bool) {
+	p := strings.Split(strings.ToLower(tier), "-")
+
+	if len(p) == 4 && p[1] == "custom" {
+		cpus, err := strconv.ParseInt(p[2], 10, 64)
+		if err != nil {
+			return decimal.Zero, decimal.Zero, false
+		}
+		memoryMB, err := strconv.ParseInt(p[3], 10, 64)
+		if err != nil {
+			return decimal.Zero, decimal.Zero, false
+		}
+		return decimal.NewFromInt(cpus), decimal.NewFromInt(memoryMB).Div(decimal.NewFromInt(1024)), true
+	}
+
+	if len(p) == 4 && p[1] == "n1" {
+		memoryPerCPU, ok := sqlMachineSeriesMemoryPerCPU[p[2]]
+		if !ok {
+			return decimal.Zero, decimal.Zero, false
+		}
+		cpus, err := strconv.ParseInt(p[3], 10, 64)
+		if err != nil {
+			return decimal.Zero, decimal.Zero, false
+		}
+		return decimal.NewFromInt(cpus), decimal.NewFromInt(cpus).Mul(decimal.NewFromFloat(memoryPerCPU)), true
+	}
+
+	return decimal.Zero, decimal.Zero, false
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package gcp
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestSQLTierResources(t *testing.T) {
+	tests := []struct {
+		tier     string
+		vCPUs    string
+		memoryGB string
+		ok       bool
+	}{
+		{"db-custom-2-7680", "2", "7.5", true},
+		{"db-custom-4-16384", "4", "16", true},
+		{"db-n1-standard-2", "2", "7.5", true},
+		{"db-n1-highmem-4", "4", "26", true},
+		{"db-f1-micro", "0", "0", false},
+		{"db-custom-x-1024", "0", "0", false},
+	}
+
+	for _, test := range tests {
+		vCPUs, memoryGB, ok := sqlTierResources(test.tier)
+		assert.Equal(t, test.ok, ok)
+		assert.Equal(t, test.vCPUs, vCPUs.String())
+		assert.Equal(t, test.memoryGB, memoryGB.String())
+	}
+}
+
+func TestSQLDatabaseEngine(t *testing.T) {
+	tests := []struct {
+		databaseVersion string
+		expected        string
+	}{
+		{"MYSQL_8_0", "MySQL"},
+		{"POSTGRES_15", "PostgreSQL"},
+		{"SQLSERVER_2019_STANDARD", "SQL Server"},
+		{"ORACLE", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, sqlDatabaseEngine(test.databaseVersion))
+	}
+}
+
+func TestSQLDatabaseInstanceUnsupportedVersion(t *testing.T) {
+	r := &SQLDatabaseInstance{Address: "google_sql_database_instance.oracle", Region: "us-central1", DatabaseVersion: "ORACLE_19", Tier: "db-custom-2-7680"}
+	res := r.BuildResource()
+
+	// Unsupported versions are reported as unsupported, not as free resources
+	assert.Equal(t, true, res.IsSkipped)
+	assert.Equal(t, false, res.NoPrice)
+	assert.Equal(t, "Unsupported database version ORACLE_19", res.SkipMessage)
+	assert.Equal(t, 0, len(res.CostComponents))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package gcp
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+var storageClassMap = map[string]struct {
+	Name          string
+	ResourceGroup string
+}{
+	"STANDARD": {"Standard", "RegionalStorage"},
+	"NEARLINE": {"Nearline", "NearlineStorage"},
+	"COLDLINE": {"Coldline", "ColdlineStorage"},
+	"ARCHIVE":  {"Archive", "ArchiveStorage"},
+}
+
+// multiRegionLocations are the multi-region locations of Cloud Storage.
+var multiRegionLocations = []string{"us", "eu", "asia"}
+
+// StorageBucket prices the storage and operations of a bucket. All of its costs are usage based.
+type StorageBucket struct {
+	Address      string
+	Location     string
+	StorageClass string
+
+	StorageGB               *float64 `infracost_usage:"storage_gb"`
+	MonthlyClassAOperations *int64   `infracost_usage:"monthly_class_a_operations"`
+	MonthlyClassBOperations *int64   `infracost_usage:"monthly_class_b_operations"`
+}
+
+func (r *StorageBucket) CoreType() string {
+	return "StorageBucket"
+}
+
+func (r *StorageBucket) UsageSchema() []*schema.UsageItem {
+	return []*schema.UsageItem{
+		{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0},
+		{Key: "monthly_class_a_operations", ValueType: schema.Int64, DefaultValue: 0},
+		{Key: "monthly_class_b_operations", ValueType: schema.Int64, DefaultValue: 0},
+	}
+}
+
+func (r *StorageBucket) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(r, u)
+}
+
+func (r *StorageBucket) BuildResource() *schema.Resource {
+	location := strings.ToLower(r.Location)
+	if location == "" {
+		location = "us"
+	}
+
+	class, ok := storageClassMap[strings.ToUpper(r.StorageClass)]
+	if !ok {
+		class = storageClassMap["STANDARD"]
+	}
+
+	resourceGroup := class.ResourceGroup
+	opsResourceGroup := strings.TrimSuffix(class.ResourceGroup, "Storage") + "Ops"
+	if class.ResourceGroup == "RegionalStorage" && isMultiRegionLocation(location) {
+		resourceGroup = "MultiRegionalStorage"
+		opsResourceGroup = "MultiRegionalOps"
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:            "Storage (" + strings.ToLower(class.Name) + ")",
+			Unit:            "GiB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: floatPtrToDecimalPtr(r.StorageGB),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr(vendorName),
+				Region:        strPtr(location),
+				Service:       strPtr("Cloud Storage"),
+				ProductFamily: strPtr("Storage"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "resourceGroup", Value: strPtr(resourceGroup)},
+					{Key: "description", ValueRegex: strPtr("/^(?!.*(Early Delete|Retrieval)).*$/i")},
+				},
+			},
+			PriceFilter: priceFilterOnDemand,
+			UsageBased:  true,
+		},
+		storageOperationsCostComponent("Object adds, bucket/object list (class A)", location, opsResourceGroup, "Class A", intPtrToDecimalPtr(r.MonthlyClassAOperations)),
+		storageOperationsCostComponent("Object gets, retrieve bucket/object metadata (class B)", location, opsResourceGroup, "Class B", intPtrToDecimalPtr(r.MonthlyClassBOperations)),
+	}
+
+	return &schema.Resource{
+		Name:           r.Address,
+		CostComponents: costComponents,
+		UsageSchema:    r.UsageSchema(),
+	}
+}
+
+func storageOperationsCostComponent(name, location, resourceGroup, class string, operations *decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:            name,
+		Unit:            "10k operations",
+		UnitMultiplier:  decimal.NewFromInt(10000),
+		MonthlyQuantity: operations,
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr(vendorName),
+			Region:        strPtr(location),
+			Service:       strPtr("Cloud Storage"),
+			ProductFamily: strPtr("Storage"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "resourceGroup", Value: strPtr(resourceGroup)},
+				{Key: "description", ValueRegex: regexPtr(class)},
+			},
+		},
+		PriceFilter: priceFilterOnDemand,
+		UsageBased:  true,
+	}
+}
+
+func isMultiRegionLocation(location string) bool {
+	for _, l := range multiRegionLocations {
+		if l == location {
+			return true
+		}
+	}
+	return false
+}
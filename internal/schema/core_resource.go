@@ -89,6 +89,10 @@ type PartialResource struct {
 	// CloudResourceIDs are collected during parsing in case they need to be uploaded to the
 	// Cloud Usage API to be used in the usage estimate calculations.
 	CloudResourceIDs []string
+
+	// Note-plancost: ResourceData is kept so that the resource can be matched on its attribute
+	// values and rebuilt with different values, e.g. by recommendation rules.
+	ResourceData *ResourceData
 }
 
 func NewPartialResource(d *ResourceData, r *Resource, cr CoreResource, cloudResourceIds []string) *PartialResource {
@@ -105,6 +109,7 @@ func NewPartialResource(d *ResourceData, r *Resource, cr CoreResource, cloudReso
 		CoreResource:                            cr,
 		Resource:                                r,
 		CloudResourceIDs:                        cloudResourceIds,
+		ResourceData:                            d,
 		MissingVarsCausingUnknownTagKeys:        d.MissingVarsCausingUnknownTagKeys,
 		MissingVarsCausingUnknownDefaultTagKeys: d.MissingVarsCausingUnknownDefaultTagKeys,
 	}
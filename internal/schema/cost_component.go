@@ -39,8 +39,25 @@ type CostComponent struct {
 	MonthlyCost          *decimal.Decimal
 	UsageBased           bool
 	PriceNotFound        bool
+	// Note-plancost: PriceSource is where the custom price of the component comes from, e.g. PriceSourcePriceSheet.
+	PriceSource string
+	// Note-plancost: AppliedDiscounts are the discount rules that reduced MonthlyCost, in the order they were applied.
+	AppliedDiscounts []AppliedDiscount
+	// Note-plancost: PriceLookup records how the price of the component was chosen, to explain the estimate.
+	PriceLookup *PriceLookup
+	// Note-plancost: hourlyQuantityGiven is whether the resource gave the quantity per hour, see MonthlyQuantityFromHourly.
+	hourlyQuantityGiven bool
 }
 
+// AppliedDiscount is the monthly saving of a discount rule on a cost component.
+type AppliedDiscount struct {
+	Name           string
+	MonthlySavings decimal.Decimal
+}
+
+// PriceSourcePriceSheet is the PriceSource of components priced from the negotiated price sheet of the provider.
+const PriceSourcePriceSheet = "price_sheet"
+
 func (c *CostComponent) CalculateCosts() {
 	c.fillQuantities()
 	if c.HourlyQuantity != nil {
@@ -57,9 +74,16 @@ func (c *CostComponent) fillQuantities() {
 		c.HourlyQuantity = decimalPtr(c.MonthlyQuantity.Div(HourToMonthUnitMultiplier))
 	} else if c.HourlyQuantity != nil && c.MonthlyQuantity == nil {
 		c.MonthlyQuantity = decimalPtr(c.HourlyQuantity.Mul(HourToMonthUnitMultiplier))
+		c.hourlyQuantityGiven = true
 	}
 }
 
+// MonthlyQuantityFromHourly returns whether the monthly quantity was calculated from the hourly quantity the resource
+// gave, i.e. as HourlyQuantity * HourToMonthUnitMultiplier.
+func (c *CostComponent) MonthlyQuantityFromHourly() bool {
+	return c.hourlyQuantityGiven
+}
+
 func (c *CostComponent) SetPrice(price decimal.Decimal) {
 	c.price = price
 }
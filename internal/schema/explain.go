@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package schema
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/tidwall/gjson"
+)
+
+// attributeReads records the attributes that the resource functions read from a ResourceData. Resources read the
+// attributes of the resources they reference too, possibly concurrently, so the keys are guarded by a mutex.
+type attributeReads struct {
+	mux  sync.Mutex
+	keys map[string]bool
+}
+
+func newAttributeReads() *attributeReads {
+	return &attributeReads{keys: make(map[string]bool)}
+}
+
+func (r *attributeReads) add(key string) {
+	if r == nil {
+		return
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.keys[key] = true
+}
+
+// ReadAttributes returns the sorted keys of the attributes that were read through Get, IsEmpty and the OrDefault
+// getters, i.e. the Terraform attributes that the resource is priced from.
+func (d *ResourceData) ReadAttributes() []string {
+	if d.reads == nil {
+		return nil
+	}
+	d.reads.mux.Lock()
+	defer d.reads.mux.Unlock()
+
+	keys := make([]string, 0, len(d.reads.keys))
+	for k := range d.reads.keys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// WithRawValues returns a copy of the resource data with other raw values. The copy has its own references map and
+// records its own attribute reads, so pricing it doesn't change the explanation of the original resource.
+func (d *ResourceData) WithRawValues(rawValues gjson.Result) *ResourceData {
+	c := *d
+	c.RawValues = rawValues
+	c.ReferencesMap = make(map[string][]*ResourceData, len(d.ReferencesMap))
+	for k, refs := range d.ReferencesMap {
+		c.ReferencesMap[k] = append([]*ResourceData(nil), refs...)
+	}
+	c.reads = newAttributeReads()
+	return &c
+}
+
+// The reasons a price was chosen for a cost component, see PriceLookup.
+const (
+	PriceReasonCustomPrice     = "custom price set by the resource"
+	PriceReasonPriceSheet      = "negotiated price of the price sheet"
+	PriceReasonOnlyPrice       = "only matching price"
+	PriceReasonSamePrice       = "all matching prices are equal"
+	PriceReasonSmallestNonZero = "smallest non-zero price"
+	PriceReasonNoProducts      = "no products found"
+	PriceReasonNoPrices        = "no prices found"
+)
+
+// PriceLookup records how the price of a cost component was looked up, so that an estimate can be explained.
+type PriceLookup struct {
+	// Products are the prices of each product that the pricing API returned for the filters of the component.
+	Products [][]LookupPrice
+	// Reason is why the price of the component was chosen, e.g. PriceReasonSmallestNonZero.
+	Reason string
+	// StaleFetchedAt is when the products were fetched if they are expired products of the price cache, used
+	// because the pricing API couldn't be reached. It is zero for current products.
+	StaleFetchedAt time.Time
+}
+
+// LookupPrice is a price returned by the pricing API.
+type LookupPrice struct {
+	Hash  string
+	Price decimal.Decimal
+}
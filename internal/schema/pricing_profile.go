@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package schema
+
+// The pricing profiles of an estimate. They select the rates that the resources are priced at, e.g. the Dev/Test rates
+// of non-production subscriptions, so that estimates match the invoices of those subscriptions.
+const (
+	// PricingProfilePayAsYouGo prices resources at the pay-as-you-go rates of their configuration.
+	PricingProfilePayAsYouGo = "pay_as_you_go"
+	// PricingProfileDevTest prices resources at the rates of Dev/Test subscriptions, which don't charge Windows and SQL
+	// Server licenses.
+	PricingProfileDevTest = "dev_test"
+	// PricingProfileHybridBenefitWindows applies Azure Hybrid Benefit to the Windows VMs without a license type.
+	PricingProfileHybridBenefitWindows = "hybrid_benefit_windows"
+	// PricingProfileHybridBenefitSQL applies Azure Hybrid Benefit to the SQL databases and managed instances that
+	// include the SQL Server license.
+	PricingProfileHybridBenefitSQL = "hybrid_benefit_sql"
+
+	// PricingProfileMetadataKey is the ProjectMetadata key of the pricing profile of a resource.
+	PricingProfileMetadataKey = "pricingProfile"
+)
+
+// PricingProfiles are the valid pricing profiles.
+var PricingProfiles = []string{
+	PricingProfilePayAsYouGo,
+	PricingProfileDevTest,
+	PricingProfileHybridBenefitWindows,
+	PricingProfileHybridBenefitSQL,
+}
+
+// PricingProfile returns the pricing profile of the resource, defaulting to PricingProfilePayAsYouGo.
+func (d *ResourceData) PricingProfile() string {
+	if profile := d.ProjectMetadata[PricingProfileMetadataKey]; profile != "" {
+		return profile
+	}
+	return PricingProfilePayAsYouGo
+}
@@ -37,6 +37,8 @@ const (
 	diagTerragruntModuleEvaluationFailure = 104
 	diagMissingVars                       = 105
 	diagEmptyPathType                     = 106
+	// Note-plancost: files that are skipped because they can't be parsed
+	diagFileParsingFailure = 107
 )
 
 // ProjectDiag holds information about all diagnostics associated with a project.
@@ -49,6 +51,10 @@ type ProjectDiag struct {
 
 	// FriendlyMessage should be used to display a readable message to the CLI user.
 	FriendlyMessage string `json:"-"`
+
+	// Note-plancost: Err is the error that caused the diag. It holds the hcl.Diagnostics with the source ranges of
+	// parsing and evaluation failures, so they can be reported as Terraform diagnostics.
+	Err error `json:"-"`
 }
 
 // IsEmptyPathTypeError checks if the error is a diag for an empty path type.
@@ -109,9 +115,18 @@ func newDiag(code int, message string, isError bool, data interface{}, err error
 		Message: message,
 		IsError: isError,
 		Data:    data,
+		Err:     err,
 	}
 }
 
+// NewDiagFileParsingFailure returns a non-critical project diag for a Terraform
+// file that is skipped because it can't be parsed.
+func NewDiagFileParsingFailure(err error) *ProjectDiag {
+	diag := newDiag(diagFileParsingFailure, err.Error(), false, nil, err)
+	diag.FriendlyMessage = "Some Terraform files could not be parsed and were skipped, so their resources are missing from the estimate."
+	return diag
+}
+
 // NewDiagMissingVars returns a ProjectDiag for missing Terraform vars. This is
 // considered a non-critical error and is used to notify the user.
 func NewDiagMissingVars(vars ...string) *ProjectDiag {
@@ -122,7 +137,8 @@ func NewDiagMissingVars(vars ...string) *ProjectDiag {
 		FriendlyMessage: fmt.Sprintf(
 			"Input values were not provided for following Terraform variables: %s. %s",
 			joinQuotes(vars),
-			"Use --terraform-var-file or --terraform-var to specify them.",
+			// Note-plancost: the provider takes variables from var files and TF_VAR_ environment variables
+			"Set them in `var_file`, terraform.tfvars or TF_VAR_ environment variables.",
 		),
 	}
 }
@@ -145,6 +161,15 @@ func (p *ProjectDiag) Error() string {
 	return p.Message
 }
 
+// Unwrap returns the error that caused the diag.
+func (p *ProjectDiag) Unwrap() error {
+	if p == nil {
+		return nil
+	}
+
+	return p.Err
+}
+
 type ProjectMetadata struct {
 	Path                string             `json:"path"`
 	Type                string             `json:"type"`
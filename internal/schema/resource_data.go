@@ -44,6 +44,9 @@ type ResourceData struct {
 	// use this value instead of the deprecated d.Get("region").String() or
 	// lookupRegion method.
 	Region string
+
+	// Note-plancost: reads are the attributes read by the resource functions, see ReadAttributes.
+	reads *attributeReads
 }
 
 type TagPropagation struct {
@@ -64,6 +67,7 @@ func NewResourceData(resourceType string, providerName string, address string, t
 		ReferencesMap:   make(map[string][]*ResourceData),
 		CFResource:      nil,
 		ProjectMetadata: make(map[string]string),
+		reads:           newAttributeReads(),
 	}
 }
 
@@ -77,6 +81,7 @@ func NewCFResourceData(resourceType string, providerName string, address string,
 		ReferencesMap:   make(map[string][]*ResourceData),
 		CFResource:      cfResource,
 		ProjectMetadata: make(map[string]string),
+		reads:           newAttributeReads(),
 	}
 }
 
@@ -87,6 +92,7 @@ func toAzureCLIName(location string) string {
 }
 
 func (d *ResourceData) Get(key string) gjson.Result {
+	d.reads.add(key)
 	// Note-plancost: mapping region -> location for azure resources
 	if key == "region" && d.RawValues.Get("location").String() != "" {
 		locationV := d.RawValues.Get("location").String()
@@ -145,6 +151,7 @@ func (d *ResourceData) GetBoolOrDefault(key string, def bool) bool {
 // Return true if the key doesn't exist, is null, or is an empty string.
 // Needed because gjson.Exists returns true as long as a key exists, even if it's empty or null.
 func (d *ResourceData) IsEmpty(key string) bool {
+	d.reads.add(key)
 	g := d.RawValues.Get(key)
 	return g.Type == gjson.Null || len(g.Raw) == 0 || g.Raw == "\"\"" || emptyObjectOrArray(g)
 }
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"strings"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+var DefaultProviderRegion = "us-east-1"
+
+func GetDefaultRefIDFunc(d *schema.ResourceData) []string {
+	defaultRefs := []string{d.Get("id").String(), d.Get("name").String()}
+
+	if d.Get("arn").Exists() {
+		defaultRefs = append(defaultRefs, d.Get("arn").String())
+	}
+
+	return defaultRefs
+}
+
+func DefaultCloudResourceIDFunc(d *schema.ResourceData) []string {
+	var ids []string
+
+	id := d.Get("id").String()
+	if id != "" && id != "none" && !strings.HasPrefix(id, "hcl-") {
+		ids = append(ids, id)
+	}
+
+	arn := d.Get("arn").String()
+	if strings.HasPrefix(arn, "arn:aws:") && !strings.HasPrefix(arn, "arn:aws:hcl") {
+		ids = append(ids, arn)
+	}
+
+	return ids
+}
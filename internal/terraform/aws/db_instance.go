@@ -0,0 +1,34 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/aws"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getDBInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "aws_db_instance",
+		CoreRFunc: NewDBInstance,
+	}
+}
+
+func NewDBInstance(d *schema.ResourceData) schema.CoreResource {
+	return &aws.DBInstance{
+		Address:          d.Address,
+		Region:           d.Region,
+		InstanceClass:    d.Get("instance_class").String(),
+		Engine:           d.Get("engine").String(),
+		LicenseModel:     d.Get("license_model").String(),
+		MultiAZ:          d.Get("multi_az").Bool(),
+		StorageType:      d.Get("storage_type").String(),
+		AllocatedStorage: d.Get("allocated_storage").Int(),
+		IOPS:             d.Get("iops").Int(),
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/aws"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getEBSVolumeRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "aws_ebs_volume",
+		CoreRFunc: NewEBSVolume,
+	}
+}
+
+func NewEBSVolume(d *schema.ResourceData) schema.CoreResource {
+	return &aws.EBSVolume{
+		Address: d.Address,
+		Region:  d.Region,
+		EBSVolumeData: aws.EBSVolumeData{
+			Type:       d.Get("type").String(),
+			SizeGB:     d.Get("size").Int(),
+			IOPS:       d.Get("iops").Int(),
+			Throughput: d.Get("throughput").Int(),
+		},
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws_test
+
+import (
+	"testing"
+
+	"github.com/plancost/terraform-provider-plancost/internal/testcase"
+)
+
+func TestAWSEBSVolumeGoldenFile(t *testing.T) {
+	t.Parallel()
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	testcase.GoldenFileResourceTests(t, "ebs_volume_test")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/aws"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "aws_instance",
+		CoreRFunc: NewInstance,
+	}
+}
+
+func NewInstance(d *schema.ResourceData) schema.CoreResource {
+	r := &aws.Instance{
+		Address:      d.Address,
+		Region:       d.Region,
+		InstanceType: d.Get("instance_type").String(),
+		Tenancy:      d.Get("tenancy").String(),
+	}
+
+	if len(d.Get("root_block_device").Array()) > 0 {
+		device := d.Get("root_block_device").Array()[0]
+		r.RootBlockDevice = &aws.EBSVolumeData{
+			Type:       device.Get("volume_type").String(),
+			SizeGB:     device.Get("volume_size").Int(),
+			IOPS:       device.Get("iops").Int(),
+			Throughput: device.Get("throughput").Int(),
+		}
+	}
+
+	for _, device := range d.Get("ebs_block_device").Array() {
+		r.EBSBlockDevices = append(r.EBSBlockDevices, &aws.EBSBlockDevice{
+			DeviceName: device.Get("device_name").String(),
+			EBSVolumeData: aws.EBSVolumeData{
+				Type:       device.Get("volume_type").String(),
+				SizeGB:     device.Get("volume_size").Int(),
+				IOPS:       device.Get("iops").Int(),
+				Throughput: device.Get("throughput").Int(),
+			},
+		})
+	}
+
+	return r
+}
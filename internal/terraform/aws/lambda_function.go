@@ -0,0 +1,34 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/aws"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getLambdaFunctionRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "aws_lambda_function",
+		CoreRFunc: NewLambdaFunction,
+	}
+}
+
+func NewLambdaFunction(d *schema.ResourceData) schema.CoreResource {
+	architecture := "x86_64"
+	if len(d.Get("architectures").Array()) > 0 {
+		architecture = d.Get("architectures").Array()[0].String()
+	}
+
+	return &aws.LambdaFunction{
+		Address:      d.Address,
+		Region:       d.Region,
+		MemorySize:   d.Get("memory_size").Int(),
+		Architecture: architecture,
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/aws"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getLBRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "aws_lb",
+		CoreRFunc: NewLB,
+	}
+}
+
+// aws_alb is an alias of aws_lb.
+func getALBRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "aws_alb",
+		CoreRFunc: NewLB,
+	}
+}
+
+func NewLB(d *schema.ResourceData) schema.CoreResource {
+	loadBalancerType := d.Get("load_balancer_type").String()
+	if loadBalancerType == "" {
+		loadBalancerType = "application"
+	}
+
+	return &aws.LB{
+		Address:          d.Address,
+		Region:           d.Region,
+		LoadBalancerType: loadBalancerType,
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/aws"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getNATGatewayRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "aws_nat_gateway",
+		CoreRFunc: NewNATGateway,
+	}
+}
+
+func NewNATGateway(d *schema.ResourceData) schema.CoreResource {
+	return &aws.NATGateway{
+		Address: d.Address,
+		Region:  d.Region,
+	}
+}
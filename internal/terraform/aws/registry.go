@@ -0,0 +1,108 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package aws
+
+import "github.com/plancost/terraform-provider-plancost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getALBRegistryItem(),
+	getDBInstanceRegistryItem(),
+	getEBSVolumeRegistryItem(),
+	getELBRegistryItem(),
+	getInstanceRegistryItem(),
+	getLambdaFunctionRegistryItem(),
+	getLBRegistryItem(),
+	getNATGatewayRegistryItem(),
+	getS3BucketRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	// Amazon EC2
+	"aws_eip_association",
+	"aws_iam_instance_profile",
+	"aws_key_pair",
+	"aws_launch_template",
+	"aws_placement_group",
+	"aws_volume_attachment",
+
+	// Amazon RDS
+	"aws_db_option_group",
+	"aws_db_parameter_group",
+	"aws_db_subnet_group",
+
+	// Amazon S3
+	"aws_s3_bucket_acl",
+	"aws_s3_bucket_cors_configuration",
+	"aws_s3_bucket_lifecycle_configuration",
+	"aws_s3_bucket_notification",
+	"aws_s3_bucket_ownership_controls",
+	"aws_s3_bucket_policy",
+	"aws_s3_bucket_public_access_block",
+	"aws_s3_bucket_server_side_encryption_configuration",
+	"aws_s3_bucket_versioning",
+	"aws_s3_bucket_website_configuration",
+
+	// AWS IAM
+	"aws_iam_group",
+	"aws_iam_group_membership",
+	"aws_iam_group_policy",
+	"aws_iam_group_policy_attachment",
+	"aws_iam_policy",
+	"aws_iam_policy_attachment",
+	"aws_iam_role",
+	"aws_iam_role_policy",
+	"aws_iam_role_policy_attachment",
+	"aws_iam_user",
+	"aws_iam_user_policy",
+	"aws_iam_user_policy_attachment",
+
+	// AWS Lambda
+	"aws_lambda_alias",
+	"aws_lambda_event_source_mapping",
+	"aws_lambda_function_url",
+	"aws_lambda_layer_version",
+	"aws_lambda_permission",
+
+	// Elastic Load Balancing
+	"aws_alb_listener",
+	"aws_alb_listener_rule",
+	"aws_alb_target_group",
+	"aws_alb_target_group_attachment",
+	"aws_lb_listener",
+	"aws_lb_listener_certificate",
+	"aws_lb_listener_rule",
+	"aws_lb_target_group",
+	"aws_lb_target_group_attachment",
+
+	// Amazon VPC
+	"aws_default_network_acl",
+	"aws_default_route_table",
+	"aws_default_security_group",
+	"aws_default_vpc",
+	"aws_egress_only_internet_gateway",
+	"aws_internet_gateway",
+	"aws_main_route_table_association",
+	"aws_network_acl",
+	"aws_network_acl_rule",
+	"aws_network_interface",
+	"aws_route",
+	"aws_route_table",
+	"aws_route_table_association",
+	"aws_security_group",
+	"aws_security_group_rule",
+	"aws_subnet",
+	"aws_vpc",
+	"aws_vpc_dhcp_options",
+	"aws_vpc_dhcp_options_association",
+	"aws_vpc_security_group_egress_rule",
+	"aws_vpc_security_group_ingress_rule",
+}
+
+var UsageOnlyResources = []string{}
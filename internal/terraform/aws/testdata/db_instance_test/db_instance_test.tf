@@ -0,0 +1,21 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_metadata_api_check     = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_db_instance" "mysql" {
+  instance_class    = "db.t3.micro"
+  engine            = "mysql"
+  allocated_storage = 30
+}
+
+resource "aws_db_instance" "postgres_multi_az" {
+  instance_class    = "db.m5.large"
+  engine            = "postgres"
+  allocated_storage = 100
+  multi_az          = true
+}
@@ -0,0 +1,34 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_metadata_api_check     = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_ebs_volume" "gp2" {
+  availability_zone = "us-east-1a"
+  size              = 40
+}
+
+resource "aws_ebs_volume" "gp3" {
+  availability_zone = "us-east-1a"
+  size              = 100
+  type              = "gp3"
+  iops              = 4000
+  throughput        = 250
+}
+
+resource "aws_ebs_volume" "standard" {
+  availability_zone = "us-east-1a"
+  size              = 20
+  type              = "standard"
+}
+
+resource "aws_ebs_volume" "io2" {
+  availability_zone = "us-east-1a"
+  size              = 100
+  type              = "io2"
+  iops              = 80000
+}
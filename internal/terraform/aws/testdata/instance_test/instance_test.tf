@@ -0,0 +1,30 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_metadata_api_check     = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = "t3.medium"
+
+  root_block_device {
+    volume_size = 50
+    volume_type = "gp3"
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sdf"
+    volume_size = 100
+    volume_type = "io1"
+    iops        = 1000
+  }
+}
+
+resource "aws_instance" "windows" {
+  ami           = "ami-674cbc1e"
+  instance_type = "t3.medium"
+}
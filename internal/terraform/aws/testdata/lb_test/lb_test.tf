@@ -0,0 +1,22 @@
+provider "aws" {
+  region                      = "us-east-1"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_metadata_api_check     = true
+  access_key                  = "mock_access_key"
+  secret_key                  = "mock_secret_key"
+}
+
+resource "aws_lb" "application" {
+  load_balancer_type = "application"
+  subnets            = ["subnet-12345678", "subnet-87654321"]
+}
+
+resource "aws_lb" "network" {
+  load_balancer_type = "network"
+  subnets            = ["subnet-12345678"]
+}
+
+resource "aws_alb" "alias" {
+  subnets = ["subnet-12345678", "subnet-87654321"]
+}
@@ -32,7 +32,7 @@ func NewAppServicePlan(d *schema.ResourceData) schema.CoreResource {
 		SKUSize:     d.Get("sku.0.size").String(),
 		SKUCapacity: d.Get("sku.0.capacity").Int(),
 		Kind:        d.Get("kind").String(),
-		IsDevTest:   d.ProjectMetadata["isProduction"] == "false",
+		IsDevTest:   isDevTest(d),
 	}
 	return r
 }
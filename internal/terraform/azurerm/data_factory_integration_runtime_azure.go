@@ -59,7 +59,7 @@ func newDataFactoryIntegrationRuntimeAzure(d *schema.ResourceData) schema.CoreRe
 
 	r := &azure.DataFactoryIntegrationRuntimeAzure{
 		Address:     d.Address,
-		Region:      d.Region,
+		Region:      d.Get("region").String(),
 		Cores:       cores,
 		ComputeType: computeType,
 	}
@@ -64,7 +64,7 @@ func newDataFactoryIntegrationRuntimeAzureSSIS(d *schema.ResourceData) schema.Co
 
 	r := &azure.DataFactoryIntegrationRuntimeAzureSSIS{
 		Address:         d.Address,
-		Region:          d.Region,
+		Region:          d.Get("region").String(),
 		Enterprise:      enterprise,
 		LicenseIncluded: licenseIncluded,
 		Instances:       nodes,
@@ -66,7 +66,7 @@ func newDataFactoryIntegrationRuntimeManaged(d *schema.ResourceData) schema.Core
 
 	r := &azure.DataFactoryIntegrationRuntimeManaged{
 		Address:         d.Address,
-		Region:          d.Region,
+		Region:          d.Get("region").String(),
 		Enterprise:      enterprise,
 		LicenseIncluded: licenseIncluded,
 		Instances:       nodes,
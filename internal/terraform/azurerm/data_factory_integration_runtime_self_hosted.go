@@ -50,7 +50,7 @@ func getDataFactoryIntegrationRuntimeSelfHostedRegistryItem() *schema.RegistryIt
 func newDataFactoryIntegrationRuntimeSelfHosted(d *schema.ResourceData) schema.CoreResource {
 	r := &azure.DataFactoryIntegrationRuntimeSelfHosted{
 		Address: d.Address,
-		Region:  d.Region,
+		Region:  d.Get("region").String(),
 	}
 	return r
 }
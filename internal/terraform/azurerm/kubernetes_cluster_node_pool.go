@@ -62,11 +62,13 @@ func NewKubernetesClusterNodePool(d *schema.ResourceData) schema.CoreResource {
 		Address:      d.Address,
 		Region:       d.Region,
 		VMSize:       d.Get("vm_size").String(),
+		Mode:         d.Get("mode").String(),
+		Priority:     azure.NormalizeVMPriority(d.Get("priority").String()),
 		OS:           os,
 		OSDiskType:   d.Get("os_disk_type").String(),
 		OSDiskSizeGB: d.Get("os_disk_size_gb").Int(),
 		NodeCount:    nodeCount,
-		IsDevTest:    d.ProjectMetadata["isProduction"] == "false",
+		IsDevTest:    isDevTest(d),
 	}
 	return r
 }
@@ -25,7 +25,7 @@ func getLinuxVirtualMachineRegistryItem() *schema.RegistryItem {
 		CoreRFunc: NewAzureLinuxVirtualMachine,
 		Notes: []string{
 			"Non-standard images such as RHEL are not supported.",
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Reserved instances are not supported.",
 		},
 	}
 }
@@ -35,6 +35,7 @@ func NewAzureLinuxVirtualMachine(d *schema.ResourceData) schema.CoreResource {
 		Region:          d.Region,
 		Size:            d.Get("size").String(),
 		UltraSSDEnabled: d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool(),
+		Priority:        azure.NormalizeVMPriority(d.Get("priority").String()),
 	}
 
 	if len(d.Get("os_disk").Array()) > 0 {
@@ -21,17 +21,21 @@ import (
 
 func getLinuxVirtualMachineScaleSetRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
-		Name:  "azurerm_linux_virtual_machine_scale_set",
-		RFunc: NewLinuxVirtualMachineScaleSet,
+		Name:      "azurerm_linux_virtual_machine_scale_set",
+		CoreRFunc: NewLinuxVirtualMachineScaleSet,
 	}
 }
 
-func NewLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+// Note-plancost: scale sets are core resources, so that the optimization rules can build their alternatives, e.g. with
+// Spot instances. The instances of the usage data override the instances of the scale set.
+func NewLinuxVirtualMachineScaleSet(d *schema.ResourceData) schema.CoreResource {
 	r := &azure.LinuxVirtualMachineScaleSet{
 		Address:         d.Address,
 		Region:          d.Region,
 		SKU:             d.Get("sku").String(),
 		UltraSSDEnabled: d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool(),
+		Priority:        azure.NormalizeVMPriority(d.Get("priority").String()),
+		Instances:       intPtr(d.Get("instances").Int()),
 	}
 
 	if len(d.Get("os_disk").Array()) > 0 {
@@ -42,11 +46,5 @@ func NewLinuxVirtualMachineScaleSet(d *schema.ResourceData, u *schema.UsageData)
 		}
 	}
 
-	r.PopulateUsage(u)
-
-	if u == nil || u.IsEmpty("instances") {
-		r.Instances = intPtr(d.Get("instances").Int())
-	}
-
-	return r.BuildResource()
+	return r
 }
@@ -35,6 +35,7 @@ func newMachineLearningComputeCluster(d *schema.ResourceData) schema.CoreResourc
 		Address:      d.Address,
 		Region:       region,
 		InstanceType: d.Get("vm_size").String(),
+		Priority:     azure.NormalizeVMPriority(d.Get("vm_priority").String()),
 		MinNodeCount: d.Get("scale_settings.0.min_node_count").Int(),
 	}
 }
@@ -77,7 +77,7 @@ func getMSSQLDatabaseRegistryItem() *schema.RegistryItem {
 }
 
 func newAzureRMMSSQLDatabase(d *schema.ResourceData) schema.CoreResource {
-	region := d.Region
+	region := d.Get("region").String()
 
 	sku := d.GetStringOrDefault("sku_name", "GP_S_Gen5_2")
 
@@ -93,7 +93,7 @@ func newAzureRMMSSQLDatabase(d *schema.ResourceData) schema.CoreResource {
 		replicaCount = &val
 	}
 
-	licenseType := d.GetStringOrDefault("license_type", "LicenseIncluded")
+	licenseType := sqlLicenseType(d, d.GetStringOrDefault("license_type", "LicenseIncluded"))
 	storageAccountType := d.GetStringOrDefault("storage_account_type", "Geo")
 
 	r := &azure.SQLDatabase{
@@ -105,7 +105,7 @@ func newAzureRMMSSQLDatabase(d *schema.ResourceData) schema.CoreResource {
 		ReadReplicaCount:  replicaCount,
 		ZoneRedundant:     d.Get("zone_redundant").Bool(),
 		BackupStorageType: storageAccountType,
-		IsDevTest:         d.ProjectMetadata["isProduction"] == "false",
+		IsDevTest:         isDevTest(d),
 	}
 
 	if strings.ToLower(sku) == "elasticpool" || !d.IsEmpty("elastic_pool_id") {
@@ -39,7 +39,7 @@ func getMSSQLElasticPoolRegistryItem() *schema.RegistryItem {
 }
 
 func newMSSQLElasticPool(d *schema.ResourceData) schema.CoreResource {
-	region := d.Region
+	region := d.Get("region").String()
 
 	sku := d.Get("sku.0.name").String()
 	capacity := d.Get("sku.0.capacity").Int()
@@ -38,7 +38,7 @@ func newMSSQLManagedInstance(d *schema.ResourceData) schema.CoreResource {
 
 	r.SKU = d.Get("sku_name").String()
 	r.Cores = d.Get("vcores").Int()
-	r.LicenseType = d.Get("license_type").String()
+	r.LicenseType = sqlLicenseType(d, d.Get("license_type").String())
 	r.StorageAccountType = d.Get("storage_account_type").String()
 	if r.StorageAccountType == "" {
 		r.StorageAccountType = "LRS"
@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package azurerm
+
+import (
+	"strings"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+// isDevTest reports whether the resource is priced at Dev/Test rates, either because its project isn't a production
+// project or because of the dev_test pricing profile.
+func isDevTest(d *schema.ResourceData) bool {
+	return d.ProjectMetadata["isProduction"] == "false" || d.PricingProfile() == schema.PricingProfileDevTest
+}
+
+// windowsLicenseType returns the license type of a Windows VM. The hybrid_benefit_windows pricing profile applies Azure
+// Hybrid Benefit to the VMs without a license type.
+func windowsLicenseType(d *schema.ResourceData, licenseType string) string {
+	if d.PricingProfile() == schema.PricingProfileHybridBenefitWindows && (licenseType == "" || strings.EqualFold(licenseType, "None")) {
+		return "Windows_Server"
+	}
+	return licenseType
+}
+
+// sqlLicenseType returns the license type of a SQL database or managed instance. The hybrid_benefit_sql pricing profile
+// applies Azure Hybrid Benefit to the ones that include the license, and Dev/Test subscriptions don't charge it either.
+func sqlLicenseType(d *schema.ResourceData, licenseType string) string {
+	profile := d.PricingProfile()
+	if (profile == schema.PricingProfileHybridBenefitSQL || profile == schema.PricingProfileDevTest) && (licenseType == "" || strings.EqualFold(licenseType, "LicenseIncluded")) {
+		return "BasePrice"
+	}
+	return licenseType
+}
@@ -121,7 +121,7 @@ func newSQLDatabase(d *schema.ResourceData) schema.CoreResource {
 		ReadReplicaCount:  readReplicas,
 		ZoneRedundant:     d.Get("zone_redundant").Bool(),
 		BackupStorageType: "Geo",
-		IsDevTest:         d.ProjectMetadata["isProduction"] == "false",
+		IsDevTest:         isDevTest(d),
 	}
 	return r
 }
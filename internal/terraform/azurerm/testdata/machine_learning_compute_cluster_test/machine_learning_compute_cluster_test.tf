@@ -140,3 +140,79 @@ resource "azurerm_machine_learning_compute_cluster" "with_instances_and_monthly_
   vm_priority = "LowPriority"
 }
 
+resource "azurerm_machine_learning_compute_cluster" "example_dedicated" {
+  for_each = { for entry in local.permutations : "${entry.vm_size}.${entry.min_node_count}" => entry }
+
+  name                          = "example-cluster"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_size                       = each.value.vm_size
+
+  scale_settings {
+    min_node_count                       = each.value.min_node_count
+    max_node_count                       = each.value.min_node_count + 1
+    scale_down_nodes_after_idle_duration = 30
+  }
+
+  vm_priority = "Dedicated"
+}
+
+resource "azurerm_machine_learning_compute_cluster" "with_monthly_hrs_dedicated" {
+  name                          = "with-monthly-hrs"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_size                       = "Standard_D2s_v3"
+
+  scale_settings {
+    min_node_count                       = 2
+    max_node_count                       = 20
+    scale_down_nodes_after_idle_duration = 30
+  }
+
+  vm_priority = "Dedicated"
+}
+
+resource "azurerm_machine_learning_compute_cluster" "with_monthly_hrs_zero_min_node_count_dedicated" {
+  name                          = "with-monthly-hrs"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_size                       = "Standard_D2s_v3"
+
+  scale_settings {
+    min_node_count                       = 0
+    max_node_count                       = 20
+    scale_down_nodes_after_idle_duration = 30
+  }
+
+  vm_priority = "Dedicated"
+}
+
+resource "azurerm_machine_learning_compute_cluster" "with_instances_dedicated" {
+  name                          = "with-monthly-hrs"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_size                       = "Standard_D2s_v3"
+
+  scale_settings {
+    min_node_count                       = 2
+    max_node_count                       = 20
+    scale_down_nodes_after_idle_duration = 30
+  }
+
+  vm_priority = "Dedicated"
+}
+
+resource "azurerm_machine_learning_compute_cluster" "with_instances_and_monthly_hrs_dedicated" {
+  name                          = "with-monthly-hrs"
+  location                      = azurerm_resource_group.example.location
+  machine_learning_workspace_id = azurerm_machine_learning_workspace.example.id
+  vm_size                       = "Standard_D2s_v3"
+
+  scale_settings {
+    min_node_count                       = 2
+    max_node_count                       = 20
+    scale_down_nodes_after_idle_duration = 30
+  }
+
+  vm_priority = "Dedicated"
+}
@@ -32,7 +32,7 @@ func getTrafficManagerProfileRegistryItem() *schema.RegistryItem {
 }
 
 func newTrafficManagerProfile(d *schema.ResourceData) schema.CoreResource {
-	region := d.Region
+	region := d.Get("region").String()
 
 	return &azure.TrafficManagerProfile{
 		Address:            d.Address,
@@ -31,9 +31,10 @@ func NewVirtualMachine(d *schema.ResourceData) schema.CoreResource {
 		Region:                     d.Region,
 		StorageImageReferenceOffer: d.Get("storage_image_reference.0.offer").String(),
 		StorageOSDiskOSType:        d.Get("storage_os_disk.0.os_type").String(),
-		LicenseType:                d.Get("license_type").String(),
+		LicenseType:                windowsLicenseType(d, d.Get("license_type").String()),
 		VMSize:                     d.Get("vm_size").String(),
 		StoragesDiskData:           make([]*azure.ManagedDiskData, 0),
+		IsDevTest:                  isDevTest(d),
 	}
 
 	if len(d.Get("storage_os_disk").Array()) > 0 {
@@ -35,8 +35,9 @@ func NewVirtualMachineScaleSet(d *schema.ResourceData) schema.CoreResource {
 		Region:      d.Region,
 		SKUName:     d.Get("sku.0.name").String(),
 		SKUCapacity: d.Get("sku.0.capacity").Int(),
-		LicenseType: d.Get("license_type").String(),
-		IsDevTest:   d.ProjectMetadata["isProduction"] == "false",
+		LicenseType: windowsLicenseType(d, d.Get("license_type").String()),
+		Priority:    azure.NormalizeVMPriority(d.Get("priority").String()),
+		IsDevTest:   isDevTest(d),
 	}
 
 	if !d.IsEmpty("os_profile_windows_config") {
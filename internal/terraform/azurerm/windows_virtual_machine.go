@@ -24,7 +24,7 @@ func getWindowsVirtualMachineRegistryItem() *schema.RegistryItem {
 		Name:      "azurerm_windows_virtual_machine",
 		CoreRFunc: NewWindowsVirtualMachine,
 		Notes: []string{
-			"Low priority, Spot and Reserved instances are not supported.",
+			"Reserved instances are not supported.",
 		},
 	}
 }
@@ -34,9 +34,10 @@ func NewWindowsVirtualMachine(d *schema.ResourceData) schema.CoreResource {
 		Address:                               d.Address,
 		Region:                                d.Region,
 		Size:                                  d.Get("size").String(),
-		LicenseType:                           d.Get("license_type").String(),
+		LicenseType:                           windowsLicenseType(d, d.Get("license_type").String()),
 		AdditionalCapabilitiesUltraSSDEnabled: d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool(),
-		IsDevTest:                             d.ProjectMetadata["isProduction"] == "false",
+		Priority:                              azure.NormalizeVMPriority(d.Get("priority").String()),
+		IsDevTest:                             isDevTest(d),
 	}
 	if len(d.Get("os_disk").Array()) > 0 {
 		diskData := d.Get("os_disk").Array()[0]
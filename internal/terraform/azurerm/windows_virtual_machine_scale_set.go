@@ -21,18 +21,23 @@ import (
 
 func getWindowsVirtualMachineScaleSetRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
-		Name:  "azurerm_windows_virtual_machine_scale_set",
-		RFunc: NewWindowsVirtualMachineScaleSet,
+		Name:      "azurerm_windows_virtual_machine_scale_set",
+		CoreRFunc: NewWindowsVirtualMachineScaleSet,
 	}
 }
-func NewWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+
+// Note-plancost: scale sets are core resources, so that the optimization rules can build their alternatives, e.g. with
+// Spot instances. The instances of the usage data override the instances of the scale set.
+func NewWindowsVirtualMachineScaleSet(d *schema.ResourceData) schema.CoreResource {
 	r := &azure.WindowsVirtualMachineScaleSet{
 		Address:                               d.Address,
 		Region:                                d.Region,
 		SKU:                                   d.Get("sku").String(),
-		LicenseType:                           d.Get("license_type").String(),
-		IsDevTest:                             d.ProjectMetadata["isProduction"] == "false",
+		LicenseType:                           windowsLicenseType(d, d.Get("license_type").String()),
+		IsDevTest:                             isDevTest(d),
 		AdditionalCapabilitiesUltraSSDEnabled: d.Get("additional_capabilities.0.ultra_ssd_enabled").Bool(),
+		Priority:                              azure.NormalizeVMPriority(d.Get("priority").String()),
+		Instances:                             intPtr(d.Get("instances").Int()),
 	}
 	if len(d.Get("os_disk").Array()) > 0 {
 		diskData := d.Get("os_disk").Array()[0]
@@ -42,11 +47,5 @@ func NewWindowsVirtualMachineScaleSet(d *schema.ResourceData, u *schema.UsageDat
 		}
 	}
 
-	r.PopulateUsage(u)
-
-	if u == nil || u.IsEmpty("instances") {
-		r.Instances = intPtr(d.Get("instances").Int())
-	}
-
-	return r.BuildResource()
+	return r
 }
@@ -0,0 +1,32 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/gcp"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getComputeDiskRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "google_compute_disk",
+		CoreRFunc: NewComputeDisk,
+		GetRegion: zoneRegion,
+	}
+}
+
+func NewComputeDisk(d *schema.ResourceData) schema.CoreResource {
+	return &gcp.ComputeDisk{
+		Address: d.Address,
+		Region:  d.Region,
+		ComputeDiskData: gcp.ComputeDiskData{
+			Type:   d.Get("type").String(),
+			SizeGB: d.Get("size").Int(),
+		},
+	}
+}
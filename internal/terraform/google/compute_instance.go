@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/gcp"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getComputeInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "google_compute_instance",
+		CoreRFunc: NewComputeInstance,
+		GetRegion: zoneRegion,
+	}
+}
+
+func NewComputeInstance(d *schema.ResourceData) schema.CoreResource {
+	preemptible := d.Get("scheduling.0.preemptible").Bool() || d.Get("scheduling.0.provisioning_model").String() == "SPOT"
+
+	return &gcp.ComputeInstance{
+		Address:     d.Address,
+		Region:      d.Region,
+		MachineType: d.Get("machine_type").String(),
+		Preemptible: preemptible,
+		BootDisk: gcp.ComputeDiskData{
+			Type:   d.Get("boot_disk.0.initialize_params.0.type").String(),
+			SizeGB: d.Get("boot_disk.0.initialize_params.0.size").Int(),
+		},
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/gcp"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+const (
+	defaultClusterNodeCount = 3
+	defaultRegionZoneCount  = 3
+)
+
+func getContainerClusterRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "google_container_cluster",
+		CoreRFunc: NewContainerCluster,
+		GetRegion: locationRegion,
+	}
+}
+
+func NewContainerCluster(d *schema.ResourceData) schema.CoreResource {
+	location := d.Get("location").String()
+	isRegional := location != "" && !isZone(location)
+
+	r := &gcp.ContainerCluster{
+		Address:     d.Address,
+		Region:      d.Region,
+		IsRegional:  isRegional,
+		IsAutopilot: d.Get("enable_autopilot").Bool(),
+	}
+
+	if !d.Get("remove_default_node_pool").Bool() {
+		r.DefaultNodePool = &gcp.ContainerNodePoolData{
+			MachineType: d.Get("node_config.0.machine_type").String(),
+			DiskType:    d.Get("node_config.0.disk_type").String(),
+			DiskSizeGB:  d.Get("node_config.0.disk_size_gb").Int(),
+			Preemptible: d.Get("node_config.0.preemptible").Bool() || d.Get("node_config.0.spot").Bool(),
+			NodeCount:   d.GetInt64OrDefault("initial_node_count", defaultClusterNodeCount),
+			ZoneCount:   nodePoolZoneCount(isRegional, d.Get("node_locations").Array()),
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,89 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"github.com/tidwall/gjson"
+
+	"github.com/plancost/terraform-provider-plancost/internal/resources/gcp"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getContainerNodePoolRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:                "google_container_node_pool",
+		CoreRFunc:           NewContainerNodePool,
+		ReferenceAttributes: []string{"cluster"},
+		GetRegion: func(defaultRegion string, d *schema.ResourceData) string {
+			if location := nodePoolLocation(d); location != "" {
+				return zoneToRegion(location)
+			}
+			return defaultRegion
+		},
+	}
+}
+
+func NewContainerNodePool(d *schema.ResourceData) schema.CoreResource {
+	location := nodePoolLocation(d)
+	isRegional := location != "" && !isZone(location)
+
+	nodeLocations := d.Get("node_locations").Array()
+	if len(nodeLocations) == 0 {
+		if clusters := d.References("cluster"); len(clusters) > 0 {
+			nodeLocations = clusters[0].Get("node_locations").Array()
+		}
+	}
+
+	nodeCount := d.Get("node_count").Int()
+	if !d.Get("node_count").Exists() {
+		nodeCount = d.GetInt64OrDefault("initial_node_count", 0)
+		if minNodes := d.Get("autoscaling.0.min_node_count"); nodeCount == 0 && minNodes.Exists() {
+			nodeCount = minNodes.Int()
+		}
+	}
+
+	return &gcp.ContainerNodePool{
+		Address: d.Address,
+		Region:  d.Region,
+		ContainerNodePoolData: gcp.ContainerNodePoolData{
+			MachineType: d.Get("node_config.0.machine_type").String(),
+			DiskType:    d.Get("node_config.0.disk_type").String(),
+			DiskSizeGB:  d.Get("node_config.0.disk_size_gb").Int(),
+			Preemptible: d.Get("node_config.0.preemptible").Bool() || d.Get("node_config.0.spot").Bool(),
+			NodeCount:   nodeCount,
+			ZoneCount:   nodePoolZoneCount(isRegional, nodeLocations),
+		},
+	}
+}
+
+// nodePoolLocation returns the location of the node pool, falling back to the location of its cluster.
+func nodePoolLocation(d *schema.ResourceData) string {
+	if location := d.Get("location").String(); location != "" {
+		return location
+	}
+
+	if clusters := d.References("cluster"); len(clusters) > 0 {
+		return clusters[0].Get("location").String()
+	}
+
+	return ""
+}
+
+// nodePoolZoneCount returns the number of zones the nodes of a pool are created in. Regional pools
+// create nodes in three zones of the region unless node_locations is set.
+func nodePoolZoneCount(isRegional bool, nodeLocations []gjson.Result) int64 {
+	if len(nodeLocations) > 0 {
+		return int64(len(nodeLocations))
+	}
+
+	if isRegional {
+		return defaultRegionZoneCount
+	}
+
+	return 1
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+var DefaultProviderRegion = "us-central1"
+
+var zoneRegex = regexp.MustCompile(`^([a-z]+-[a-z]+[0-9]+)-[a-z]$`)
+
+func GetDefaultRefIDFunc(d *schema.ResourceData) []string {
+	defaultRefs := []string{d.Get("id").String()}
+
+	if d.Get("self_link").Exists() {
+		defaultRefs = append(defaultRefs, d.Get("self_link").String())
+	}
+
+	return defaultRefs
+}
+
+func DefaultCloudResourceIDFunc(d *schema.ResourceData) []string {
+	return []string{}
+}
+
+// isZone returns true if the location is a zone (e.g. us-central1-a) rather than a region.
+func isZone(location string) bool {
+	return zoneRegex.MatchString(strings.ToLower(location))
+}
+
+// zoneToRegion returns the region of a zone, or the location unchanged if it is not a zone.
+func zoneToRegion(location string) string {
+	if m := zoneRegex.FindStringSubmatch(strings.ToLower(location)); m != nil {
+		return m[1]
+	}
+	return location
+}
+
+// zoneRegion looks up the region of zonal resources, such as compute instances and disks, from their zone.
+func zoneRegion(defaultRegion string, d *schema.ResourceData) string {
+	if zone := d.Get("zone").String(); zone != "" {
+		return zoneToRegion(zone)
+	}
+	return defaultRegion
+}
+
+// locationRegion looks up the region of resources that take either a zone or a region as their location.
+func locationRegion(defaultRegion string, d *schema.ResourceData) string {
+	if location := d.Get("location").String(); location != "" {
+		return zoneToRegion(location)
+	}
+	return defaultRegion
+}
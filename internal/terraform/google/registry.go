@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import "github.com/plancost/terraform-provider-plancost/internal/schema"
+
+// ResourceRegistry grouped alphabetically
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	getComputeDiskRegistryItem(),
+	getComputeInstanceRegistryItem(),
+	getContainerClusterRegistryItem(),
+	getContainerNodePoolRegistryItem(),
+	getSQLDatabaseInstanceRegistryItem(),
+	getStorageBucketRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	// Cloud SQL
+	"google_sql_database",
+	"google_sql_user",
+
+	// Cloud Storage
+	"google_storage_bucket_acl",
+	"google_storage_bucket_iam_binding",
+	"google_storage_bucket_iam_member",
+	"google_storage_bucket_iam_policy",
+	"google_storage_bucket_object",
+	"google_storage_default_object_acl",
+
+	// Compute Engine
+	"google_compute_attached_disk",
+	"google_compute_firewall",
+	"google_compute_instance_template",
+	"google_compute_network",
+	"google_compute_route",
+	"google_compute_subnetwork",
+
+	// IAM
+	"google_project_iam_binding",
+	"google_project_iam_member",
+	"google_project_iam_policy",
+	"google_service_account",
+	"google_service_account_iam_binding",
+	"google_service_account_iam_member",
+	"google_service_account_key",
+
+	// Resource Manager
+	"google_project_service",
+}
+
+var UsageOnlyResources = []string{}
@@ -0,0 +1,32 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/gcp"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getSQLDatabaseInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "google_sql_database_instance",
+		CoreRFunc: NewSQLDatabaseInstance,
+	}
+}
+
+func NewSQLDatabaseInstance(d *schema.ResourceData) schema.CoreResource {
+	return &gcp.SQLDatabaseInstance{
+		Address:          d.Address,
+		Region:           d.Region,
+		DatabaseVersion:  d.Get("database_version").String(),
+		Tier:             d.Get("settings.0.tier").String(),
+		AvailabilityType: d.Get("settings.0.availability_type").String(),
+		DiskType:         d.Get("settings.0.disk_type").String(),
+		DiskSizeGB:       d.Get("settings.0.disk_size").Int(),
+	}
+}
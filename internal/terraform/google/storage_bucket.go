@@ -0,0 +1,28 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"github.com/plancost/terraform-provider-plancost/internal/resources/gcp"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+)
+
+func getStorageBucketRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:      "google_storage_bucket",
+		CoreRFunc: NewStorageBucket,
+	}
+}
+
+func NewStorageBucket(d *schema.ResourceData) schema.CoreResource {
+	return &gcp.StorageBucket{
+		Address:      d.Address,
+		Location:     d.Get("location").String(),
+		StorageClass: d.Get("storage_class").String(),
+	}
+}
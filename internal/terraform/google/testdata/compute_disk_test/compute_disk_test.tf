@@ -0,0 +1,17 @@
+provider "google" {
+  project = "example-project"
+  region  = "us-central1"
+}
+
+resource "google_compute_disk" "standard" {
+  name = "standard"
+  zone = "us-central1-a"
+  size = 100
+}
+
+resource "google_compute_disk" "balanced" {
+  name = "balanced"
+  zone = "us-central1-a"
+  type = "pd-balanced"
+  size = 50
+}
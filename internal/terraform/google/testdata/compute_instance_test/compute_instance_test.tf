@@ -0,0 +1,22 @@
+provider "google" {
+  project = "example-project"
+  region  = "us-central1"
+}
+
+resource "google_compute_instance" "instance" {
+  name         = "instance"
+  machine_type = "e2-standard-4"
+  zone         = "us-central1-a"
+
+  boot_disk {
+    initialize_params {
+      image = "debian-cloud/debian-12"
+      size  = 50
+      type  = "pd-ssd"
+    }
+  }
+
+  network_interface {
+    network = "default"
+  }
+}
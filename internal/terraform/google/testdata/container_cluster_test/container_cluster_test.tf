@@ -0,0 +1,10 @@
+provider "google" {
+  project = "example-project"
+  region  = "us-central1"
+}
+
+resource "google_container_cluster" "zonal" {
+  name               = "zonal"
+  location           = "us-central1-a"
+  initial_node_count = 3
+}
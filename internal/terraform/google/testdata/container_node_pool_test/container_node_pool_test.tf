@@ -0,0 +1,21 @@
+provider "google" {
+  project = "example-project"
+  region  = "us-central1"
+}
+
+resource "google_container_cluster" "cluster" {
+  name                     = "cluster"
+  location                 = "us-central1-a"
+  remove_default_node_pool = true
+  initial_node_count       = 1
+}
+
+resource "google_container_node_pool" "pool" {
+  name       = "pool"
+  cluster    = google_container_cluster.cluster.id
+  node_count = 2
+
+  node_config {
+    machine_type = "n2-standard-2"
+  }
+}
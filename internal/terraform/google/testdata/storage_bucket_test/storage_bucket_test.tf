@@ -0,0 +1,9 @@
+provider "google" {
+  project = "example-project"
+  region  = "us-central1"
+}
+
+resource "google_storage_bucket" "bucket" {
+  name     = "example-bucket"
+  location = "US"
+}
@@ -0,0 +1,33 @@
+/*
+Copyright (c) 2026 Plancost.
+This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.
+*/
+
+package google
+
+import (
+	"testing"
+
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestZoneToRegion(t *testing.T) {
+	tests := []struct {
+		location string
+		expected string
+		isZone   bool
+	}{
+		{"us-central1-a", "us-central1", true},
+		{"europe-west1-b", "europe-west1", true},
+		{"northamerica-northeast1-c", "northamerica-northeast1", true},
+		{"us-central1", "us-central1", false},
+		{"US", "US", false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, zoneToRegion(test.location))
+		assert.Equal(t, test.isZone, isZone(test.location))
+	}
+}
@@ -2,7 +2,9 @@ package terraform
 
 import (
 	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform/aws"
 	"github.com/plancost/terraform-provider-plancost/internal/terraform/azurerm"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform/google"
 )
 
 type RegistryItemMap map[string]*schema.RegistryItem
@@ -49,6 +51,26 @@ func buildResourceRegistryMap() *RegistryItemMap {
 	for _, registryItem := range createFreeResources(azurerm.FreeResources, azurerm.GetDefaultRefIDFunc, azurerm.DefaultCloudResourceIDFunc) {
 		resourceRegistryMap[registryItem.Name] = registryItem
 	}
+	for _, registryItem := range aws.ResourceRegistry {
+		if registryItem.CloudResourceIDFunc == nil {
+			registryItem.CloudResourceIDFunc = aws.DefaultCloudResourceIDFunc
+		}
+		resourceRegistryMap[registryItem.Name] = registryItem
+		resourceRegistryMap[registryItem.Name].DefaultRefIDFunc = aws.GetDefaultRefIDFunc
+	}
+	for _, registryItem := range createFreeResources(aws.FreeResources, aws.GetDefaultRefIDFunc, aws.DefaultCloudResourceIDFunc) {
+		resourceRegistryMap[registryItem.Name] = registryItem
+	}
+	for _, registryItem := range google.ResourceRegistry {
+		if registryItem.CloudResourceIDFunc == nil {
+			registryItem.CloudResourceIDFunc = google.DefaultCloudResourceIDFunc
+		}
+		resourceRegistryMap[registryItem.Name] = registryItem
+		resourceRegistryMap[registryItem.Name].DefaultRefIDFunc = google.GetDefaultRefIDFunc
+	}
+	for _, registryItem := range createFreeResources(google.FreeResources, google.GetDefaultRefIDFunc, google.DefaultCloudResourceIDFunc) {
+		resourceRegistryMap[registryItem.Name] = registryItem
+	}
 	for _, registryItem := range createFreeResources(otherFreeResources, defaultRefIDFunc, defaultCloudResourceIDFunc) {
 		resourceRegistryMap[registryItem.Name] = registryItem
 	}
@@ -96,6 +118,8 @@ func (r *RegistryItemMap) GetDefaultRefIDFunc(resourceDataType string) schema.Re
 func GetUsageOnlyResources() []string {
 	r := []string{}
 	r = append(r, azurerm.UsageOnlyResources...)
+	r = append(r, aws.UsageOnlyResources...)
+	r = append(r, google.UsageOnlyResources...)
 	return r
 }
 
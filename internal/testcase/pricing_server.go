@@ -0,0 +1,271 @@
+package testcase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// PricingModeLive resolves prices against the pricing API configured for the provider.
+	PricingModeLive = "live"
+	// PricingModeReplay resolves prices from the pricing fixtures only, so tests run without network access.
+	PricingModeReplay = "replay"
+	// PricingModeRecord resolves prices against the pricing API and writes them to the pricing fixtures.
+	PricingModeRecord = "record"
+
+	// DefaultPricingFixtureFile is the pricing fixtures file of a test package, relative to the package directory.
+	DefaultPricingFixtureFile = "testdata/pricing_fixtures.json"
+
+	defaultPricingEndpoint = "https://api.plancost.io"
+)
+
+var (
+	sharedPricingServerOnce sync.Once
+	sharedPricingServer     *PricingServer
+	sharedPricingServerErr  error
+
+	// pricingFixturesMain is set when the tests run through Main, which saves the recorded fixtures.
+	pricingFixturesMain bool
+)
+
+// PricingServer is a fake of the GraphQL pricing API. It serves the results of the pricing fixtures, which are a
+// price snapshot keyed by product filter and price filter. In record mode, queries are forwarded to the upstream
+// pricing API and their results are added to the fixtures.
+type PricingServer struct {
+	*httptest.Server
+
+	mode        string
+	fixtureFile string
+	snapshot    *apiclient.PriceSnapshot
+	upstream    *apiclient.APIClient
+
+	mux    sync.Mutex
+	misses []string
+}
+
+type pricingServerQuery struct {
+	Query     string `json:"query"`
+	Variables struct {
+		ProductFilter *schema.ProductFilter `json:"productFilter"`
+		PriceFilter   *schema.PriceFilter   `json:"priceFilter"`
+	} `json:"variables"`
+}
+
+// NewPricingServer starts a fake pricing API that serves the pricing fixtures in fixtureFile. A missing fixtures file
+// is treated as empty. In record mode, queries are forwarded to the pricing API at upstreamEndpoint.
+func NewPricingServer(fixtureFile, mode, upstreamEndpoint string) (*PricingServer, error) {
+	if mode != PricingModeReplay && mode != PricingModeRecord {
+		return nil, fmt.Errorf("unsupported pricing mode %q, expected %q or %q", mode, PricingModeReplay, PricingModeRecord)
+	}
+
+	snapshot := apiclient.NewPriceSnapshot("USD")
+	if _, err := os.Stat(fixtureFile); err == nil {
+		if snapshot, err = apiclient.LoadPriceSnapshot(fixtureFile); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &PricingServer{
+		mode:        mode,
+		fixtureFile: fixtureFile,
+		snapshot:    snapshot,
+	}
+	if mode == PricingModeRecord {
+		s.upstream = apiclient.NewAPIClient(upstreamEndpoint, "")
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveGraphQL))
+	return s, nil
+}
+
+func (s *PricingServer) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/graphql" {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var queries []pricingServerQuery
+	if err := json.Unmarshal(body, &queries); err != nil {
+		http.Error(w, fmt.Sprintf("invalid GraphQL request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var results []gjson.Result
+	if s.mode == PricingModeRecord {
+		results, err = s.record(body, queries)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	} else {
+		results = s.replay(queries)
+	}
+
+	raw := make([]json.RawMessage, len(results))
+	for i, result := range results {
+		raw[i] = json.RawMessage(result.Raw)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(raw)
+}
+
+// replay returns the fixture result of each query. Queries that aren't in the fixtures get an empty result, the same
+// as a product that the pricing API doesn't know about, and are reported as misses.
+func (s *PricingServer) replay(queries []pricingServerQuery) []gjson.Result {
+	results := make([]gjson.Result, len(queries))
+	for i, query := range queries {
+		lookup := s.snapshot.Lookup
+		if apiclient.IsSavingsPlanQuery(query.Query) {
+			lookup = s.snapshot.LookupSavingsPlan
+		}
+		result, ok := lookup(query.Variables.ProductFilter, query.Variables.PriceFilter)
+		if !ok || result.Raw == "" {
+			if !ok {
+				s.addMiss(query)
+			}
+			result = gjson.Parse(`{"data":{"products":[]}}`)
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// record forwards the queries to the upstream pricing API and adds the results to the fixtures.
+func (s *PricingServer) record(body []byte, queries []pricingServerQuery) ([]gjson.Result, error) {
+	var rawQueries []apiclient.GraphQLQuery
+	if err := json.Unmarshal(body, &rawQueries); err != nil {
+		return nil, err
+	}
+
+	results, err := s.upstream.DoQueries(rawQueries)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(queries) {
+		return nil, fmt.Errorf("pricing API returned %d results for %d queries", len(results), len(queries))
+	}
+
+	for i, query := range queries {
+		if apiclient.IsSavingsPlanQuery(query.Query) {
+			s.snapshot.AddSavingsPlanFilters(query.Variables.ProductFilter, query.Variables.PriceFilter, results[i])
+		} else {
+			s.snapshot.AddFilters(query.Variables.ProductFilter, query.Variables.PriceFilter, results[i])
+		}
+	}
+	return results, nil
+}
+
+func (s *PricingServer) addMiss(query pricingServerQuery) {
+	b, _ := json.Marshal(query.Variables)
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.misses = append(s.misses, string(b))
+}
+
+// Misses returns the filters of the queries that were not in the fixtures, and resets them.
+func (s *PricingServer) Misses() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	misses := s.misses
+	s.misses = nil
+	return misses
+}
+
+// Save writes the fixtures to the fixtures file. It only writes in record mode.
+func (s *PricingServer) Save() error {
+	if s.mode != PricingModeRecord {
+		return nil
+	}
+	return s.snapshot.Write(s.fixtureFile)
+}
+
+// PricingMode returns the pricing mode of the tests, as set by the PLANCOST_PRICING_MODE environment variable.
+// Defaults to PricingModeLive, so that the tests notice when the prices of the pricing API change.
+func PricingMode() string {
+	if mode := os.Getenv("PLANCOST_PRICING_MODE"); mode != "" {
+		return mode
+	}
+	return PricingModeLive
+}
+
+// Main runs the tests of a package that uses the pricing fixtures and then saves the recorded fixtures, once for all
+// tests of the package. Call it from the TestMain function of the package.
+func Main(m *testing.M) {
+	pricingFixturesMain = true
+	code := m.Run()
+
+	if sharedPricingServer != nil {
+		if err := sharedPricingServer.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to save the pricing fixtures: %v\n", err)
+			code = 1
+		}
+		sharedPricingServer.Close()
+	}
+	os.Exit(code)
+}
+
+// UsePricingFixtures points the provider at a fake pricing API that serves the pricing fixtures of the test package,
+// unless the pricing mode is PricingModeLive. The fixtures file defaults to DefaultPricingFixtureFile and can be set
+// with the PLANCOST_PRICING_FIXTURE_FILE environment variable. In replay mode, the test fails if it queries a price
+// that isn't in the fixtures.
+//
+// The provider runs in the test process and reads its settings from the environment, so a single server is shared by
+// all tests of the package, including parallel ones. The price cache is disabled so that every query reaches it.
+// The recorded fixtures are saved by Main.
+func UsePricingFixtures(t *testing.T) {
+	t.Helper()
+
+	mode := PricingMode()
+	if mode == PricingModeLive {
+		return
+	}
+	if mode == PricingModeRecord && !pricingFixturesMain {
+		t.Fatalf("recording the pricing fixtures requires a TestMain function that calls testcase.Main")
+	}
+
+	sharedPricingServerOnce.Do(func() {
+		fixtureFile := os.Getenv("PLANCOST_PRICING_FIXTURE_FILE")
+		if fixtureFile == "" {
+			fixtureFile = DefaultPricingFixtureFile
+		}
+		upstream := os.Getenv("PLANCOST_API_ENDPOINT")
+		if upstream == "" {
+			upstream = defaultPricingEndpoint
+		}
+
+		sharedPricingServer, sharedPricingServerErr = NewPricingServer(fixtureFile, mode, upstream)
+		if sharedPricingServerErr != nil {
+			return
+		}
+		os.Setenv("PLANCOST_API_ENDPOINT", sharedPricingServer.URL)
+		os.Setenv("PLANCOST_DISABLE_PRICE_CACHE", "true")
+		os.Unsetenv("PLANCOST_PRICING_SNAPSHOT_FILE")
+	})
+	if sharedPricingServerErr != nil {
+		t.Fatalf("unable to start the fake pricing API: %v", sharedPricingServerErr)
+	}
+
+	t.Cleanup(func() {
+		// The server is shared, so a parallel test may report the misses of another test, but the run fails either way
+		if misses := sharedPricingServer.Misses(); len(misses) > 0 {
+			t.Errorf("%d price queries are not in the pricing fixtures, run the tests with PLANCOST_PRICING_MODE=record to refresh them: %v", len(misses), misses)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package testcase
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/plancost/terraform-provider-plancost/internal/apiclient"
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPricingServer_RecordReplay(t *testing.T) {
+	var upstreamRequests atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamRequests.Add(1)
+		_, _ = w.Write([]byte(`[{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.005"}]}]}}]`))
+	}))
+	defer upstream.Close()
+
+	vendor := "azure"
+	resources := []*schema.Resource{
+		{
+			Name: "azurerm_public_ip.example",
+			CostComponents: []*schema.CostComponent{
+				{Name: "IP address", ProductFilter: &schema.ProductFilter{VendorName: &vendor}},
+			},
+		},
+	}
+	fetch := func(endpoint string) string {
+		c := apiclient.NewPricingAPIClient(endpoint, "")
+		results, err := c.PerformRequest(c.BatchRequests(resources, 10, "USD")[0])
+		require.NoError(t, err)
+		return results[0].Result.Get("data.products.0.prices.0.USD").String()
+	}
+
+	fixtureFile := filepath.Join(t.TempDir(), "pricing_fixtures.json")
+
+	// Record the price from the upstream pricing API
+	recorder, err := NewPricingServer(fixtureFile, PricingModeRecord, upstream.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "0.005", fetch(recorder.URL))
+	require.NoError(t, recorder.Save())
+	recorder.Close()
+	assert.Equal(t, int32(1), upstreamRequests.Load())
+
+	// Replay it without the upstream pricing API
+	replayer, err := NewPricingServer(fixtureFile, PricingModeReplay, "")
+	require.NoError(t, err)
+	defer replayer.Close()
+	assert.Equal(t, "0.005", fetch(replayer.URL))
+	assert.Equal(t, int32(1), upstreamRequests.Load())
+	assert.Empty(t, replayer.Misses())
+
+	// Queries that weren't recorded have no products
+	vendor = "aws"
+	assert.Equal(t, "", fetch(replayer.URL))
+	assert.Len(t, replayer.Misses(), 1)
+}
+
+func TestNewPricingServer_UnsupportedMode(t *testing.T) {
+	_, err := NewPricingServer(filepath.Join(t.TempDir(), "pricing_fixtures.json"), PricingModeLive, "")
+	assert.ErrorContains(t, err, `unsupported pricing mode "live"`)
+}
+
+func TestPricingServer_SavingsPlan(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"data":{"products":[{"prices":[{"priceHash":"a","USD":"0.096","savingsPlan":[{"term":"1 Year","USD":"0.0798"}]}]}]}}]`))
+	}))
+	defer upstream.Close()
+
+	vendor := "azure"
+	resources := []*schema.Resource{
+		{
+			Name: "azurerm_linux_virtual_machine.app",
+			CostComponents: []*schema.CostComponent{
+				{Name: "Instance usage", ProductFilter: &schema.ProductFilter{VendorName: &vendor}},
+			},
+		},
+	}
+
+	fixtureFile := filepath.Join(t.TempDir(), "pricing_fixtures.json")
+
+	recorder, err := NewPricingServer(fixtureFile, PricingModeRecord, upstream.URL)
+	require.NoError(t, err)
+	c := apiclient.NewPricingAPIClient(recorder.URL, "")
+	_, err = c.PerformRequest(c.BatchSavingsPlanRequests(resources, 10, "USD")[0])
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+	recorder.Close()
+
+	// Savings plan queries are replayed separately from the consumption queries of the same filters
+	replayer, err := NewPricingServer(fixtureFile, PricingModeReplay, "")
+	require.NoError(t, err)
+	defer replayer.Close()
+
+	c = apiclient.NewPricingAPIClient(replayer.URL, "")
+	results, err := c.PerformRequest(c.BatchSavingsPlanRequests(resources, 10, "USD")[0])
+	require.NoError(t, err)
+	assert.Equal(t, "0.0798", results[0].Result.Get("data.products.0.prices.0.savingsPlan.0.USD").String())
+	assert.Empty(t, replayer.Misses())
+
+	_, err = c.PerformRequest(c.BatchRequests(resources, 10, "USD")[0])
+	require.NoError(t, err)
+	assert.Len(t, replayer.Misses(), 1)
+}
@@ -41,6 +41,12 @@ type TestStep struct {
 	ConfigPlanChecks resource.ConfigPlanChecks
 	ExpectError      *regexp.Regexp
 	Check            func(t *testing.T, workDir string)
+	// Apply applies the plan of the step, so that the next steps plan against its state.
+	Apply bool
+	// PlanOnly checks that the plan of the step has changes if ExpectNonEmptyPlan is set, and no changes otherwise,
+	// e.g. that an applied estimate converges.
+	PlanOnly           bool
+	ExpectNonEmptyPlan bool
 }
 
 // Test runs the test case
@@ -48,6 +54,8 @@ func Test(t *testing.T, c TestCase) {
 	if os.Getenv("TF_ACC") == "" {
 		t.Skip("Skipping acceptance test; TF_ACC not set")
 	}
+	UsePricingFixtures(t)
+
 	reattachInfo := tfexec.ReattachInfo{}
 
 	c.ProtoV6ProviderFactories = ProviderFactories
@@ -120,6 +128,9 @@ func Test(t *testing.T, c TestCase) {
 	// Check if init should be skipped from env var
 	skipInit := c.SkipInit || os.Getenv("TF_ACC_SKIP_INIT") != ""
 
+	// The state of applied steps is kept out of the config directories
+	statePath := filepath.Join(t.TempDir(), "terraform.tfstate")
+
 	for i, step := range c.Steps {
 		if step.ConfigDirectory != "" {
 			workDir = step.ConfigDirectory
@@ -138,7 +149,7 @@ func Test(t *testing.T, c TestCase) {
 
 		// Run terraform plan and capture the plan file
 		planFile := filepath.Join(workDir, fmt.Sprintf("plan-%d.tfplan", i))
-		hasChanges, err := tf.Plan(context.TODO(), tfexec.Reattach(reattachInfo), tfexec.Out(planFile))
+		hasChanges, err := tf.Plan(context.TODO(), tfexec.Reattach(reattachInfo), tfexec.Out(planFile), tfexec.State(statePath))
 
 		if step.ExpectError != nil {
 			require.Error(t, err)
@@ -183,5 +194,13 @@ func Test(t *testing.T, c TestCase) {
 		}
 
 		t.Logf("Plan has changes: %v", hasChanges)
+		if step.PlanOnly && hasChanges != step.ExpectNonEmptyPlan {
+			t.Errorf("step %d: expected a plan with changes: %v, got changes: %v", i, step.ExpectNonEmptyPlan, hasChanges)
+		}
+
+		if step.Apply {
+			err = tf.Apply(context.TODO(), tfexec.Reattach(reattachInfo), tfexec.DirOrPlan(planFile), tfexec.StateOut(statePath))
+			require.NoError(t, err, "terraform apply failed")
+		}
 	}
 }
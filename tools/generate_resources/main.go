@@ -13,20 +13,33 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/plancost/terraform-provider-plancost/internal/schema"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform/aws"
 	"github.com/plancost/terraform-provider-plancost/internal/terraform/azurerm"
+	"github.com/plancost/terraform-provider-plancost/internal/terraform/google"
 )
 
 func main() {
 	// Map to store resource name -> pricing ("Free" or "Paid")
 	resourceMap := make(map[string]string)
 
+	var freeResources []string
+	freeResources = append(freeResources, azurerm.FreeResources...)
+	freeResources = append(freeResources, aws.FreeResources...)
+	freeResources = append(freeResources, google.FreeResources...)
+
+	var registry []*schema.RegistryItem
+	registry = append(registry, azurerm.ResourceRegistry...)
+	registry = append(registry, aws.ResourceRegistry...)
+	registry = append(registry, google.ResourceRegistry...)
+
 	// 1. Add explicitly free resources from the registry
-	for _, name := range azurerm.FreeResources {
+	for _, name := range freeResources {
 		resourceMap[name] = "Free"
 	}
 
 	// 2. Process resources in the registry
-	for _, item := range azurerm.ResourceRegistry {
+	for _, item := range registry {
 		// If already marked as free, skip (but ensure it's in the map)
 		if val, ok := resourceMap[item.Name]; ok && val == "Free" {
 			continue